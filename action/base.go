@@ -7,7 +7,10 @@ import (
 
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
 	"github.com/pkg/errors"
 )
 
@@ -54,6 +57,16 @@ type RawTx struct {
 	Data MsgData `json:"data"`
 	Fee  Fee     `json:"fee"`
 	Memo string  `json:"memo"`
+	// Nonce must be the next nonce of the account signing first, it protects the tx against being replayed
+	Nonce uint64 `json:"nonce"`
+	// NonceAccount is the account the nonce belongs to and must be among the signers of the tx, a multisig account
+	// signs with enough of its keys. The nonce is the one of the key signing first if it is not set.
+	NonceAccount Address `json:"nonceAccount,omitempty"`
+	// FeePayer pays the fee and must be among the signers of the tx, a multisig account signs with enough of its keys.
+	// The first signer pays if it is not set.
+	FeePayer Address `json:"feePayer,omitempty"`
+	// FeeGranter, if set, pays the fee instead of the fee payer, out of a fee grant it gave to the fee payer
+	FeeGranter Address `json:"feeGranter,omitempty"`
 }
 
 func (t *RawTx) RawBytes() []byte {
@@ -77,6 +90,95 @@ func (t *SignedTx) SignedBytes() []byte {
 	return value
 }
 
+// Payer returns the address the fee of the tx is charged to, before any fee grant is applied. An explicit fee payer
+// must have signed the tx.
+func (t *SignedTx) Payer(ctx *Context) (Address, error) {
+	if len(t.FeePayer) == 0 {
+		return t.firstSigner()
+	}
+
+	signed, err := t.signedBy(ctx, t.FeePayer)
+	if err != nil {
+		return nil, err
+	}
+	if !signed {
+		return nil, ErrFeePayerNotSigned
+	}
+	return t.FeePayer, nil
+}
+
+// firstSigner returns the address of the key which signed the tx first
+func (t *SignedTx) firstSigner() (Address, error) {
+	if len(t.Signatures) == 0 {
+		return nil, ErrUnmatchSigner
+	}
+	h, err := t.Signatures[0].Signer.GetHandler()
+	if err != nil {
+		return nil, ErrInvalidPubkey
+	}
+	return h.Address(), nil
+}
+
+// signedBy checks the signatures of the address in the tx, a multisig account signs with enough of its keys. It
+// returns false if the address didn't sign at all.
+func (t *SignedTx) signedBy(ctx *Context, addr Address) (bool, error) {
+	if ctx != nil && ctx.MultiSig != nil && ctx.MultiSig.Exists(addr) {
+		account, err := ctx.MultiSig.Get(addr)
+		if err != nil {
+			return false, err
+		}
+		signatures, err := memberSignatures(account, t.Signatures)
+		if err != nil {
+			return false, err
+		}
+		if len(signatures) == 0 {
+			return false, nil
+		}
+		err = ValidateSignatures(ctx, t.RawTx.RawBytes(), []Address{addr}, signatures)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	for _, sig := range t.Signatures {
+		h, err := sig.Signer.GetHandler()
+		if err != nil {
+			return false, ErrInvalidPubkey
+		}
+		if !h.Address().Equal(addr) {
+			continue
+		}
+		if !h.VerifyBytes(t.RawTx.RawBytes(), sig.Signed) {
+			return false, ErrInvalidSignature
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// memberSignatures picks the signatures made by the keys of the multisig account out of the signatures of a tx
+func memberSignatures(account *multisig.Account, signatures []Signature) ([]Signature, error) {
+	members, err := account.Signers()
+	if err != nil {
+		return nil, err
+	}
+	picked := make([]Signature, 0, len(members))
+	for _, sig := range signatures {
+		h, err := sig.Signer.GetHandler()
+		if err != nil {
+			return nil, ErrInvalidPubkey
+		}
+		for _, member := range members {
+			if h.Address().Equal(member) {
+				picked = append(picked, sig)
+				break
+			}
+		}
+	}
+	return picked, nil
+}
+
 func ValidateBasic(data []byte, signerAddr []Address, signatures []Signature) error {
 	for i, s := range signerAddr {
 		pkey := signatures[i].Signer
@@ -96,6 +198,123 @@ func ValidateBasic(data []byte, signerAddr []Address, signatures []Signature) er
 	return nil
 }
 
+// ValidateSignatures checks the signatures of a tx like ValidateBasic, except that a signer registered as a multisig
+// account is matched by the consecutive signatures of its keys, which must reach the threshold of the account
+func ValidateSignatures(ctx *Context, data []byte, signerAddr []Address, signatures []Signature) error {
+	i := 0
+	for _, s := range signerAddr {
+		if ctx.MultiSig != nil && ctx.MultiSig.Exists(s) {
+			account, err := ctx.MultiSig.Get(s)
+			if err != nil {
+				return err
+			}
+			n, err := validateMultiSig(data, account, signatures[i:])
+			if err != nil {
+				return err
+			}
+			i += n
+			continue
+		}
+
+		if i >= len(signatures) {
+			return ErrUnmatchSigner
+		}
+		err := ValidateBasic(data, []Address{s}, signatures[i:i+1])
+		if err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// validateMultiSig verifies the leading signatures made by the keys of the account, and returns how many there are
+func validateMultiSig(data []byte, account *multisig.Account, signatures []Signature) (int, error) {
+	ms, err := account.NewMultiSig(data)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, sig := range signatures {
+		h, err := sig.Signer.GetHandler()
+		if err != nil {
+			return 0, ErrInvalidPubkey
+		}
+		index, err := ms.GetSignerIndex(h.Address())
+		if err != nil || ms.HasAddressSigned(h.Address()) {
+			break
+		}
+		err = ms.AddSignature(keys.Signature{Index: index, PubKey: sig.Signer, Signed: sig.Signed})
+		if err != nil {
+			return 0, ErrInvalidSignature
+		}
+		n++
+	}
+
+	if !ms.IsValid() {
+		return 0, ErrMultiSigThreshold
+	}
+	return n, nil
+}
+
+// ProcessNonce checks the tx carries the next nonce of its signing account and moves the nonce past it. It runs for
+// every tx in both CheckTx and DeliverTx, so a tx can't be applied twice even when the tx index is disabled.
+func ProcessNonce(ctx *Context, tx SignedTx) error {
+	addr, err := NonceAddress(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := ctx.Nonces.Get(addr)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce != nonce {
+		return errors.Wrapf(ErrInvalidNonce, "expected %d, got %d", nonce, tx.Nonce)
+	}
+	return ctx.Nonces.Increment(addr)
+}
+
+// NonceAddress returns the account whose nonce the tx carries, the nonce account if it signed the tx, or else the
+// key which signed it first
+func NonceAddress(ctx *Context, tx SignedTx) (Address, error) {
+	if len(tx.NonceAccount) == 0 {
+		return tx.firstSigner()
+	}
+
+	signed, err := tx.signedBy(ctx, tx.NonceAccount)
+	if err != nil {
+		return nil, err
+	}
+	if !signed {
+		return nil, ErrUnmatchSigner
+	}
+	return tx.NonceAccount, nil
+}
+
+// NextNonce returns the nonce a new tx of the msg must carry, the next one of its first signer, along with the nonce
+// account the tx must name. A multisig signer has a nonce of its own whichever of its keys sign, so it is named as
+// the nonce account, while a key signing for itself is not.
+func NextNonce(nonces *nonce.Store, multiSig *multisig.Store, msg Msg) (uint64, Address, error) {
+	signers := msg.Signers()
+	if len(signers) == 0 {
+		return 0, nil, nil
+	}
+	signer := signers[0]
+	var account Address
+	if multiSig != nil && multiSig.Exists(signer) {
+		account = signer
+	}
+
+	next, err := nonces.Next(signer)
+	if err != nil {
+		logger.Error("error getting nonce", err)
+		return 0, nil, codes.ErrGettingNonce
+	}
+	return next, account, nil
+}
+
 func ValidateFee(feeOpt *fees.FeeOption, fee Fee) error {
 	if fee.Price.Currency != feeOpt.FeeCurrency.Name {
 		return ErrInvalidFeeCurrency
@@ -106,6 +325,19 @@ func ValidateFee(feeOpt *fees.FeeOption, fee Fee) error {
 	return nil
 }
 
+// ValidateBaseFee checks the fee price is at least the base fee of the current block, the base fee follows how full
+// the previous blocks were so it is only checked when a tx enters the mempool
+func ValidateBaseFee(ctx *Context, fee Fee) error {
+	baseFee, err := ctx.FeePool.GetBaseFee()
+	if err != nil {
+		return err
+	}
+	if baseFee.Amount.BigInt().Cmp(fee.Price.Value.BigInt()) > 0 {
+		return errors.Wrapf(ErrBelowBaseFee, "base fee %s", baseFee.Amount.String())
+	}
+	return nil
+}
+
 func BasicFeeHandling(ctx *Context, signedTx SignedTx, start Gas, size Gas, signatureCnt Gas) (bool, Response) {
 	ctx.State.ConsumeVerifySigGas(signatureCnt)
 	ctx.State.ConsumeStorageGas(size)
@@ -116,15 +348,20 @@ func BasicFeeHandling(ctx *Context, signedTx SignedTx, start Gas, size Gas, sign
 		return false, Response{Log: ErrGasOverflow.Error(), GasWanted: signedTx.Fee.Gas, GasUsed: signedTx.Fee.Gas}
 	}
 
-	// only charge the first signer for now
-	signer := signedTx.Signatures[0].Signer
-	h, err := signer.GetHandler()
+	addr, err := signedTx.Payer(ctx)
 	if err != nil {
 		return false, Response{Log: err.Error()}
 	}
-	addr := h.Address()
 
 	charge := signedTx.Fee.Price.ToCoin(ctx.Currencies).MultiplyInt64(int64(used))
+	if len(signedTx.FeeGranter) > 0 {
+		err = ctx.FeeGrants.UseAllowance(signedTx.FeeGranter, addr, *charge.Amount, ctx.Header.Height)
+		if err != nil {
+			return false, Response{Log: errors.Wrap(err, "fee grant").Error()}
+		}
+		addr = signedTx.FeeGranter
+	}
+
 	err = ctx.Balances.MinusFromAddress(addr, charge)
 	if err != nil {
 		return false, Response{Log: errors.Wrap(err, "charge fee").Error()}
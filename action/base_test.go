@@ -0,0 +1,133 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/kv"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestProcessNonce(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	ctx := &Context{State: state, Nonces: nonce.NewStore("nonce", state)}
+
+	pub, _, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	tx := SignedTx{RawTx: RawTx{Type: SEND}, Signatures: []Signature{{Signer: pub}}}
+
+	assert.NoError(t, ProcessNonce(ctx, tx))
+	state.Commit()
+
+	// the same tx can't be applied again
+	err = ProcessNonce(ctx, tx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidNonce.Msg)
+
+	// nor can a tx skip ahead
+	tx.Nonce = 2
+	assert.Error(t, ProcessNonce(ctx, tx))
+
+	tx.Nonce = 1
+	assert.NoError(t, ProcessNonce(ctx, tx))
+
+	assert.Equal(t, ErrUnmatchSigner, ProcessNonce(ctx, SignedTx{}))
+}
+
+func TestProcessNonce_MultiSig(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	ctx := &Context{State: state, Nonces: nonce.NewStore("nonce", state), MultiSig: multisig.NewStore("msig", state)}
+
+	member, _, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	other, otherKey, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	account, err := multisig.NewAccount(1, []keys.PublicKey{member, other})
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.MultiSig.Set(*account))
+	state.Commit()
+
+	sign := func(tx *SignedTx, pub keys.PublicKey, priv keys.PrivateKey) {
+		h, err := priv.GetHandler()
+		assert.NoError(t, err)
+		signed, err := h.Sign(tx.RawBytes())
+		assert.NoError(t, err)
+		tx.Signatures = []Signature{{Signer: pub, Signed: signed}}
+	}
+
+	// the account has a nonce of its own, whichever of its keys signs
+	tx := SignedTx{RawTx: RawTx{Type: SEND, NonceAccount: account.Address()}}
+	sign(&tx, other, otherKey)
+	assert.NoError(t, ProcessNonce(ctx, tx))
+	state.Commit()
+
+	n, err := ctx.Nonces.Get(account.Address())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	h, err := other.GetHandler()
+	assert.NoError(t, err)
+	n, err = ctx.Nonces.Get(h.Address())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, n)
+
+	// an account which didn't sign can't be named
+	stranger, strangerKey, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	tx = SignedTx{RawTx: RawTx{Type: SEND, Nonce: 1, NonceAccount: account.Address()}}
+	sign(&tx, stranger, strangerKey)
+	assert.Equal(t, ErrUnmatchSigner, ProcessNonce(ctx, tx))
+}
+
+type signedMsg struct {
+	signer Address
+}
+
+func (m signedMsg) Signers() []Address           { return []Address{m.signer} }
+func (m signedMsg) Type() Type                   { return SEND }
+func (m signedMsg) Tags() kv.Pairs               { return nil }
+func (m signedMsg) Marshal() ([]byte, error)     { return nil, nil }
+func (m *signedMsg) Unmarshal(data []byte) error { return nil }
+
+func TestNextNonce(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	pending := nonce.NewPending()
+	nonces := nonce.NewStore("nonce", state).WithPending(pending)
+	multiSigs := multisig.NewStore("msig", state)
+
+	member, _, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	other, _, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	account, err := multisig.NewAccount(1, []keys.PublicKey{member, other})
+	assert.NoError(t, err)
+	assert.NoError(t, multiSigs.Set(*account))
+
+	h, err := member.GetHandler()
+	assert.NoError(t, err)
+	assert.NoError(t, nonces.Set(h.Address(), 3))
+	assert.NoError(t, nonces.Set(account.Address(), 7))
+	state.Commit()
+
+	// a multisig signer takes its own nonce and is named as the nonce account
+	next, nonceAccount, err := NextNonce(nonces, multiSigs, &signedMsg{signer: account.Address()})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, next)
+	assert.Equal(t, account.Address(), nonceAccount)
+
+	// txs waiting in the mempool move the nonce ahead of the committed state
+	pending.Set(h.Address(), 5)
+	next, nonceAccount, err = NextNonce(nonces, multiSigs, &signedMsg{signer: h.Address()})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, next)
+	assert.Nil(t, nonceAccount)
+
+	pending.Reset()
+	next, _, err = NextNonce(nonces, multiSigs, &signedMsg{signer: h.Address()})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, next)
+}
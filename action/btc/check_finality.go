@@ -236,6 +236,12 @@ func runReportFinalityMint(ctx *action.Context, tx action.RawTx) (bool, action.R
 			return false, action.Response{Log: "error adding oBTC to address"}
 		}
 
+		err = ctx.Supplies.Add(curr, *oBTCCoin.Amount)
+		if err != nil {
+			ctx.Logger.Error(err)
+			return false, action.Response{Log: "error adding oBTC to supply"}
+		}
+
 		ctx.Logger.Info("btc coin minted to ", f.OwnerAddress)
 	}
 
@@ -221,6 +221,11 @@ func runExtRedeem(ctx *action.Context, tx action.RawTx) (bool, action.Response)
 		return false, action.Response{Log: "failed to subtract currency err:" + err.Error()}
 	}
 
+	err = ctx.Supplies.Remove(btcCurr, *coin.Amount)
+	if err != nil {
+		return false, action.Response{Log: "failed to subtract supply err:" + err.Error()}
+	}
+
 	return true, action.Response{
 		Events: action.GetEvent(redeem.Tags(), "btc_redeem"),
 	}
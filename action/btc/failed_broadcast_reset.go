@@ -199,6 +199,11 @@ func runBroadcastFailureReset(ctx *action.Context, tx action.RawTx) (bool, actio
 		if err != nil {
 			return false, action.Response{Log: "failed to add currency err:" + err.Error()}
 		}
+
+		err = ctx.Supplies.Add(btcCurr, *coin.Amount)
+		if err != nil {
+			return false, action.Response{Log: "failed to add supply err:" + err.Error()}
+		}
 	}
 
 	tracker.Multisig.Msg = nil
@@ -8,7 +8,10 @@ import (
 	"github.com/Oneledger/protocol/data/bitcoin"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
@@ -16,22 +19,29 @@ import (
 )
 
 type Context struct {
-	Router          Router
-	State           *storage.State
-	Header          *abci.Header
-	Accounts        accounts.Wallet
-	Balances        *balance.Store
-	Domains         *ons.DomainStore
-	FeePool         *fees.Store
-	Currencies      *balance.CurrencySet
-	FeeOpt          *fees.FeeOption
-	Validators      *identity.ValidatorStore
-	Witnesses       *identity.WitnessStore
-	BTCTrackers     *bitcoin.TrackerStore
-	ETHTrackers     *ethereum.TrackerStore
-	Logger          *log.Logger
-	JobStore        *jobs.JobStore
-	LockScriptStore *bitcoin.LockScriptStore
+	Router              Router
+	State               *storage.State
+	Header              *abci.Header
+	Accounts            accounts.Wallet
+	Balances            *balance.Store
+	Domains             *ons.DomainStore
+	FeePool             *fees.Store
+	Currencies          *balance.CurrencySet
+	FeeOpt              *fees.FeeOption
+	Validators          *identity.ValidatorStore
+	Witnesses           *identity.WitnessStore
+	BTCTrackers         *bitcoin.TrackerStore
+	ETHTrackers         *ethereum.TrackerStore
+	Logger              *log.Logger
+	JobStore            *jobs.JobStore
+	LockScriptStore     *bitcoin.LockScriptStore
+	ProposalMasterStore *governance.ProposalMasterStore
+	Delegations         *identity.DelegationStore
+	Unbondings          *identity.UnbondingStore
+	MultiSig            *multisig.Store
+	Nonces              *nonce.Store
+	FeeGrants           *fees.GrantStore
+	Supplies            *balance.SupplyStore
 }
 
 func NewContext(r Router, header *abci.Header, state *storage.State,
@@ -40,23 +50,32 @@ func NewContext(r Router, header *abci.Header, state *storage.State,
 	validators *identity.ValidatorStore, witnesses *identity.WitnessStore,
 	domains *ons.DomainStore, btcTrackers *bitcoin.TrackerStore,
 	ethTrackers *ethereum.TrackerStore, jobStore *jobs.JobStore,
-	lockScriptStore *bitcoin.LockScriptStore, logger *log.Logger) *Context {
+	lockScriptStore *bitcoin.LockScriptStore, proposalMaster *governance.ProposalMasterStore,
+	delegations *identity.DelegationStore, unbondings *identity.UnbondingStore, multiSig *multisig.Store,
+	nonces *nonce.Store, feeGrants *fees.GrantStore, supplies *balance.SupplyStore, logger *log.Logger) *Context {
 
 	return &Context{
-		Router:          r,
-		State:           state,
-		Header:          header,
-		Accounts:        wallet,
-		Balances:        balances,
-		Domains:         domains,
-		FeePool:         feePool,
-		Currencies:      currencies,
-		Validators:      validators,
-		Witnesses:       witnesses,
-		BTCTrackers:     btcTrackers,
-		ETHTrackers:     ethTrackers,
-		Logger:          logger,
-		JobStore:        jobStore,
-		LockScriptStore: lockScriptStore,
+		Router:              r,
+		State:               state,
+		Header:              header,
+		Accounts:            wallet,
+		Balances:            balances,
+		Domains:             domains,
+		FeePool:             feePool,
+		Currencies:          currencies,
+		Validators:          validators,
+		Witnesses:           witnesses,
+		BTCTrackers:         btcTrackers,
+		ETHTrackers:         ethTrackers,
+		Logger:              logger,
+		JobStore:            jobStore,
+		LockScriptStore:     lockScriptStore,
+		ProposalMasterStore: proposalMaster,
+		Delegations:         delegations,
+		Unbondings:          unbondings,
+		MultiSig:            multiSig,
+		Nonces:              nonces,
+		FeeGrants:           feeGrants,
+		Supplies:            supplies,
 	}
 }
@@ -0,0 +1,133 @@
+package currency
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+)
+
+var _ action.Msg = &Burn{}
+
+// Burn destroys coins of a currency registered by the issuer, out of the issuer's own balance
+type Burn struct {
+	Issuer action.Address `json:"issuer"`
+	Amount action.Amount  `json:"amount"`
+}
+
+func (b Burn) Marshal() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+func (b *Burn) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, b)
+}
+
+func (b Burn) Signers() []action.Address {
+	return []action.Address{b.Issuer.Bytes()}
+}
+
+func (b Burn) Type() action.Type {
+	return action.BURN
+}
+
+func (b Burn) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(b.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: b.Issuer.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.currency"),
+		Value: []byte(b.Amount.Currency),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = burnTx{}
+
+type burnTx struct {
+}
+
+func (burnTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	burn := &Burn{}
+	err := burn.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), burn.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if !burn.Amount.IsValid(ctx.Currencies) || burn.Amount.Value.BigInt().Sign() <= 0 {
+		return false, errors.Wrap(action.ErrInvalidAmount, burn.Amount.String())
+	}
+
+	if burn.Issuer.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	return true, nil
+}
+
+func (burnTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Burn Transaction for CheckTx", tx)
+	return runBurn(ctx, tx)
+}
+
+func (burnTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Burn Transaction for DeliverTx", tx)
+	return runBurn(ctx, tx)
+}
+
+func (burnTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runBurn(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	burn := &Burn{}
+	err := burn.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = checkIssuer(ctx, burn.Issuer)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	ts, err := mintable(ctx, burn.Amount.Currency)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Balances.MinusFromAddress(burn.Issuer.Bytes(), burn.Amount.ToCoin(ctx.Currencies))
+	if err != nil {
+		log := fmt.Sprint("error debiting balance in burn transaction ", burn.Issuer, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	err = ctx.Supplies.Remove(ts.Currency, burn.Amount.Value)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, burn.Amount.Currency).Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(burn.Tags(), "burn")}
+}
@@ -0,0 +1,52 @@
+package currency
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(RegisterCurrency), "action_cregister")
+	serialize.RegisterConcrete(new(Mint), "action_mint")
+	serialize.RegisterConcrete(new(Burn), "action_burn")
+}
+
+func EnableCurrency(r action.Router) error {
+	err := r.AddHandler(action.CURRENCY_REGISTER, registerCurrencyTx{})
+	if err != nil {
+		return errors.Wrap(err, "registerCurrencyTx")
+	}
+	err = r.AddHandler(action.MINT, mintTx{})
+	if err != nil {
+		return errors.Wrap(err, "mintTx")
+	}
+	err = r.AddHandler(action.BURN, burnTx{})
+	if err != nil {
+		return errors.Wrap(err, "burnTx")
+	}
+	return nil
+}
+
+// checkIssuer makes sure the signer of the tx is the issuer approved by governance
+func checkIssuer(ctx *action.Context, issuer action.Address) error {
+	approved := ctx.Supplies.GetOptions().Issuer
+	if len(approved) == 0 || !approved.Equal(issuer) {
+		return errors.New("not the approved issuer: " + issuer.String())
+	}
+	return nil
+}
+
+// mintable returns the supply of a currency registered by the issuer
+func mintable(ctx *action.Context, name string) (*balance.TokenSupply, error) {
+	ts, err := ctx.Supplies.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if ts == nil || !ts.Mintable {
+		return nil, errors.New("currency is not issued by the issuer: " + name)
+	}
+	return ts, nil
+}
@@ -0,0 +1,138 @@
+package currency
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+)
+
+var _ action.Msg = &Mint{}
+
+// Mint creates new coins of a currency registered by the issuer and pays them to the receiver
+type Mint struct {
+	Issuer action.Address `json:"issuer"`
+	To     action.Address `json:"to"`
+	Amount action.Amount  `json:"amount"`
+}
+
+func (m Mint) Marshal() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *Mint) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m Mint) Signers() []action.Address {
+	return []action.Address{m.Issuer.Bytes()}
+}
+
+func (m Mint) Type() action.Type {
+	return action.MINT
+}
+
+func (m Mint) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(m.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: m.Issuer.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.to"),
+		Value: m.To.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.currency"),
+		Value: []byte(m.Amount.Currency),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = mintTx{}
+
+type mintTx struct {
+}
+
+func (mintTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	mint := &Mint{}
+	err := mint.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), mint.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if !mint.Amount.IsValid(ctx.Currencies) || mint.Amount.Value.BigInt().Sign() <= 0 {
+		return false, errors.Wrap(action.ErrInvalidAmount, mint.Amount.String())
+	}
+
+	if mint.Issuer.Err() != nil || mint.To.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+	return true, nil
+}
+
+func (mintTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Mint Transaction for CheckTx", tx)
+	return runMint(ctx, tx)
+}
+
+func (mintTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Mint Transaction for DeliverTx", tx)
+	return runMint(ctx, tx)
+}
+
+func (mintTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runMint(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	mint := &Mint{}
+	err := mint.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = checkIssuer(ctx, mint.Issuer)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	ts, err := mintable(ctx, mint.Amount.Currency)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Supplies.Add(ts.Currency, mint.Amount.Value)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, mint.Amount.Currency).Error()}
+	}
+
+	err = ctx.Balances.AddToAddress(mint.To.Bytes(), mint.Amount.ToCoin(ctx.Currencies))
+	if err != nil {
+		log := fmt.Sprint("error crediting balance in mint transaction ", mint.To, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	return true, action.Response{Events: action.GetEvent(mint.Tags(), "mint")}
+}
@@ -0,0 +1,152 @@
+package currency
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+)
+
+var _ action.Msg = &RegisterCurrency{}
+
+// RegisterCurrency adds a new currency on the OneLedger chain, it can be used once the block it is registered in is
+// committed
+type RegisterCurrency struct {
+	Issuer  action.Address `json:"issuer"`
+	Name    string         `json:"name"`
+	Decimal int64          `json:"decimal"`
+	Unit    string         `json:"unit"`
+	// most of the currency that can be minted, 0 for no cap
+	SupplyCap balance.Amount `json:"supplyCap"`
+}
+
+func (r RegisterCurrency) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *RegisterCurrency) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func (r RegisterCurrency) Signers() []action.Address {
+	return []action.Address{r.Issuer.Bytes()}
+}
+
+func (r RegisterCurrency) Type() action.Type {
+	return action.CURRENCY_REGISTER
+}
+
+func (r RegisterCurrency) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(r.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: r.Issuer.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.currency"),
+		Value: []byte(r.Name),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (r RegisterCurrency) currency(id int64) balance.Currency {
+	return balance.Currency{
+		Id:      id,
+		Name:    r.Name,
+		Chain:   chain.ONELEDGER,
+		Decimal: r.Decimal,
+		Unit:    r.Unit,
+	}
+}
+
+var _ action.Tx = registerCurrencyTx{}
+
+type registerCurrencyTx struct {
+}
+
+func (registerCurrencyTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	register := &RegisterCurrency{}
+	err := register.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), register.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if register.Issuer.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	err = balance.ValidateCurrency(register.currency(0))
+	if err != nil {
+		return false, errors.Wrap(action.ErrMissingData, err.Error())
+	}
+	if register.SupplyCap.BigInt().Sign() < 0 {
+		return false, errors.Wrap(action.ErrInvalidAmount, "negative supply cap")
+	}
+	return true, nil
+}
+
+func (registerCurrencyTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing RegisterCurrency Transaction for CheckTx", tx)
+	return runRegister(ctx, tx)
+}
+
+func (registerCurrencyTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing RegisterCurrency Transaction for DeliverTx", tx)
+	return runRegister(ctx, tx)
+}
+
+func (registerCurrencyTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runRegister(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	register := &RegisterCurrency{}
+	err := register.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = checkIssuer(ctx, register.Issuer)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	if _, ok := ctx.Currencies.GetCurrencyByName(register.Name); ok {
+		return false, action.Response{Log: balance.ErrDuplicateCurrency.Error()}
+	}
+
+	id, err := ctx.Supplies.AssignCurrencyId(ctx.Currencies)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// the currency set picks the currency up when the block is committed
+	err = ctx.Supplies.Register(register.currency(id), register.SupplyCap)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, register.Name).Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(register.Tags(), "currency_register")}
+}
@@ -17,6 +17,10 @@ var (
 	ErrNotEnoughFund      = codes.ProtocolError{codes.TxErrInsufficientFunds, "not enough fund"}
 	ErrGasOverflow        = codes.ProtocolError{codes.TxErrGasOverflow, "gas used exceed limit"}
 	ErrInvalidExtTx       = codes.ProtocolError{codes.TxErrInvalidExtTx, "invalid external tx"}
+	ErrMultiSigThreshold  = codes.ProtocolError{codes.TxErrMultiSigThreshold, "not enough signatures for multisig account"}
+	ErrInvalidNonce       = codes.ProtocolError{codes.TxErrInvalidNonce, "invalid nonce"}
+	ErrFeePayerNotSigned  = codes.ProtocolError{codes.TxErrFeePayerNotSigned, "fee payer didn't sign the tx"}
+	ErrBelowBaseFee       = codes.ProtocolError{codes.TxErrBelowBaseFee, "fee price is smaller than base fee"}
 
 	ErrInvalidAddress = codes.ErrBadAddress
 
@@ -249,6 +249,10 @@ func refundTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx Report
 	if err != nil {
 		return errors.New("Unable to update total Eth supply")
 	}
+	err = ctx.Supplies.Add(c, *oEthRefundCoin.Amount)
+	if err != nil {
+		return errors.Wrap(err, "Unable to update Eth supply")
+	}
 	return nil
 }
 
@@ -276,6 +280,10 @@ func mintTokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx ReportFi
 	if err != nil {
 		return errors.Wrap(err, "Unable to update total Eth supply")
 	}
+	err = ctx.Supplies.Add(curr, *oEthCoin.Amount)
+	if err != nil {
+		return errors.Wrap(err, "Unable to update Eth supply")
+	}
 
 	tracker.State = trackerlib.Released
 	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
@@ -356,6 +364,10 @@ func mintERC20tokens(ctx *action.Context, tracker *trackerlib.Tracker, oltTx Rep
 	if err != nil {
 		return errors.Errorf("Unable to update totalSupply for token : %s", token.TokName)
 	}
+	err = ctx.Supplies.Add(curr, *otokenCoin.Amount)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to update supply for token : %s", token.TokName)
+	}
 
 	tracker.State = trackerlib.Released
 	err = ctx.ETHTrackers.WithPrefixType(trackerlib.PrefixOngoing).Set(tracker)
@@ -154,6 +154,10 @@ func runERC20Reddem(ctx *action.Context, tx action.RawTx) (bool, action.Response
 	if err != nil {
 		return false, action.Response{Log: action.ErrNotEnoughFund.Error()}
 	}
+	err = ctx.Supplies.Remove(c, *coin.Amount)
+	if err != nil {
+		return false, action.Response{Log: action.ErrNotEnoughFund.Error()}
+	}
 
 	witnesses, err := ctx.Witnesses.GetWitnessAddresses(chain.ETHEREUM)
 	if err != nil {
@@ -0,0 +1,143 @@
+package feegrant
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/fees"
+)
+
+var _ action.Msg = &FeeGrant{}
+
+// FeeGrant lets the granter pay the fees of the grantee's txs, a new grant replaces the one given before
+type FeeGrant struct {
+	Granter    action.Address `json:"granter"`
+	Grantee    action.Address `json:"grantee"`
+	SpendLimit action.Amount  `json:"spendLimit"`
+	// last height the grant can be used at, 0 for a grant which doesn't expire
+	Expiry int64 `json:"expiry"`
+}
+
+func (g FeeGrant) Marshal() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+func (g *FeeGrant) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, g)
+}
+
+func (g FeeGrant) Signers() []action.Address {
+	return []action.Address{g.Granter.Bytes()}
+}
+
+func (g FeeGrant) Type() action.Type {
+	return action.FEE_GRANT
+}
+
+func (g FeeGrant) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(g.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: g.Granter.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.grantee"),
+		Value: g.Grantee.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.expiry"),
+		Value: []byte(strconv.FormatInt(g.Expiry, 10)),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+var _ action.Tx = feeGrantTx{}
+
+type feeGrantTx struct {
+}
+
+func (feeGrantTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	grant := &FeeGrant{}
+	err := grant.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), grant.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if grant.Granter.Err() != nil || grant.Grantee.Err() != nil || grant.Granter.Equal(grant.Grantee) {
+		return false, action.ErrInvalidAddress
+	}
+
+	// allowances are spent on fees, so they are given in the fee currency
+	if grant.SpendLimit.Currency != ctx.FeePool.GetOpt().FeeCurrency.Name {
+		return false, action.ErrInvalidFeeCurrency
+	}
+	if !grant.SpendLimit.IsValid(ctx.Currencies) || grant.SpendLimit.Value.BigInt().Sign() <= 0 {
+		return false, errors.Wrap(action.ErrInvalidAmount, grant.SpendLimit.String())
+	}
+
+	if grant.Expiry < 0 {
+		return false, errors.Wrap(action.ErrMissingData, "expiry height can't be negative")
+	}
+
+	return true, nil
+}
+
+func (g feeGrantTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing FeeGrant Transaction for CheckTx", tx)
+	return runFeeGrant(ctx, tx)
+}
+
+func (g feeGrantTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing FeeGrant Transaction for DeliverTx", tx)
+	return runFeeGrant(ctx, tx)
+}
+
+func (feeGrantTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runFeeGrant(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	grant := &FeeGrant{}
+	err := grant.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	if grant.Expiry > 0 && grant.Expiry < ctx.Header.Height {
+		return false, action.Response{Log: "fee grant expires before the current height"}
+	}
+
+	err = ctx.FeeGrants.Set(fees.Grant{
+		Granter:    grant.Granter,
+		Grantee:    grant.Grantee,
+		SpendLimit: grant.SpendLimit.Value,
+		Expiry:     grant.Expiry,
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(grant.Tags(), "fee_grant")}
+}
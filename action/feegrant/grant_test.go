@@ -0,0 +1,196 @@
+package feegrant
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var olt = balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+
+type account struct {
+	pub  keys.PublicKey
+	priv keys.PrivateKey
+	addr keys.Address
+}
+
+func newAccount(t *testing.T) account {
+	pub, priv, err := keys.NewKeyPairFromTendermint()
+	assert.NoError(t, err)
+	h, err := pub.GetHandler()
+	assert.NoError(t, err)
+	return account{pub, priv, h.Address()}
+}
+
+func (a account) sign(t *testing.T, tx action.RawTx) action.Signature {
+	h, err := a.priv.GetHandler()
+	assert.NoError(t, err)
+	signed, err := h.Sign(tx.RawBytes())
+	assert.NoError(t, err)
+	return action.Signature{Signer: a.pub, Signed: signed}
+}
+
+func setup(t *testing.T) (*action.Context, *storage.State) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+
+	currencies := balance.NewCurrencySet()
+	assert.NoError(t, currencies.Register(olt))
+
+	feePool := fees.NewStore("f", state)
+	feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+
+	ctx := action.NewContext(nil, &abci.Header{Height: 5}, state, nil, balance.NewStore("b", state), currencies,
+		feePool, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fees.NewGrantStore("fg", state), nil,
+		log.NewLoggerWithPrefix(os.Stdout, "test"))
+	return ctx, state
+}
+
+func balanceOf(t *testing.T, ctx *action.Context, addr keys.Address) int64 {
+	coins, err := ctx.Balances.GetBalance(addr, ctx.Currencies)
+	assert.NoError(t, err)
+	return coins.GetCoin(olt).Amount.BigInt().Int64()
+}
+
+func sendTx() action.SignedTx {
+	tx := action.RawTx{
+		Type: action.SEND,
+		Fee:  action.Fee{Price: action.Amount{Currency: "OLT", Value: *balance.NewAmount(1)}, Gas: 100000},
+	}
+	return action.SignedTx{RawTx: tx}
+}
+
+func TestFeeGrant(t *testing.T) {
+	ctx, state := setup(t)
+	granter, user := newAccount(t), newAccount(t)
+	assert.NoError(t, ctx.Balances.AddToAddress(granter.addr, balance.Coin{Currency: olt, Amount: balance.NewAmount(100000)}))
+
+	grant := FeeGrant{
+		Granter:    granter.addr,
+		Grantee:    user.addr,
+		SpendLimit: action.Amount{Currency: "OLT", Value: *balance.NewAmount(50000)},
+		Expiry:     100,
+	}
+	data, err := grant.Marshal()
+	assert.NoError(t, err)
+	ok, resp := runFeeGrant(ctx, action.RawTx{Type: action.FEE_GRANT, Data: data})
+	assert.True(t, ok, resp.Log)
+	state.Commit()
+
+	// the user holds no OLT, the granter pays for it
+	tx := sendTx()
+	tx.FeeGranter = granter.addr
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx)}
+	ok, resp = action.BasicFeeHandling(ctx, tx, 0, 0, 1)
+	assert.True(t, ok, resp.Log)
+	state.Commit()
+
+	paid := 100000 - balanceOf(t, ctx, granter.addr)
+	assert.True(t, paid > 0)
+	g, err := ctx.FeeGrants.Get(granter.addr, user.addr)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 50000-paid, g.SpendLimit.BigInt().Int64())
+
+	// no grant for another user
+	other := newAccount(t)
+	tx = sendTx()
+	tx.FeeGranter = granter.addr
+	tx.Signatures = []action.Signature{other.sign(t, tx.RawTx)}
+	ok, _ = action.BasicFeeHandling(ctx, tx, 0, 0, 1)
+	assert.False(t, ok)
+
+	// nothing left to pay with once the grant is revoked
+	revoke := FeeRevoke{Granter: granter.addr, Grantee: user.addr}
+	data, err = revoke.Marshal()
+	assert.NoError(t, err)
+	ok, resp = runFeeRevoke(ctx, action.RawTx{Type: action.FEE_REVOKE, Data: data})
+	assert.True(t, ok, resp.Log)
+	state.Commit()
+
+	tx = sendTx()
+	tx.FeeGranter = granter.addr
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx)}
+	ok, _ = action.BasicFeeHandling(ctx, tx, 0, 0, 1)
+	assert.False(t, ok)
+}
+
+func TestFeePayer(t *testing.T) {
+	ctx, state := setup(t)
+	sponsor, user := newAccount(t), newAccount(t)
+	assert.NoError(t, ctx.Balances.AddToAddress(sponsor.addr, balance.Coin{Currency: olt, Amount: balance.NewAmount(100000)}))
+	state.Commit()
+
+	// the fee payer has to sign the tx
+	tx := sendTx()
+	tx.FeePayer = sponsor.addr
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx)}
+	_, err := tx.Payer(ctx)
+	assert.Equal(t, action.ErrFeePayerNotSigned, err)
+	ok, _ := action.BasicFeeHandling(ctx, tx, 0, 0, 1)
+	assert.False(t, ok)
+
+	// a signature over something else doesn't count
+	other := sendTx()
+	other.Memo = "other"
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx), sponsor.sign(t, other.RawTx)}
+	_, err = tx.Payer(ctx)
+	assert.Equal(t, action.ErrInvalidSignature, err)
+
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx), sponsor.sign(t, tx.RawTx)}
+	payer, err := tx.Payer(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, sponsor.addr, payer)
+	ok, resp := action.BasicFeeHandling(ctx, tx, 0, 0, 1)
+	assert.True(t, ok, resp.Log)
+	state.Commit()
+	assert.True(t, balanceOf(t, ctx, sponsor.addr) < 100000)
+
+	// without a fee payer the first signer pays
+	tx = sendTx()
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx)}
+	payer, err = tx.Payer(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, user.addr, payer)
+}
+
+func TestFeePayer_MultiSig(t *testing.T) {
+	ctx, state := setup(t)
+	ctx.MultiSig = multisig.NewStore("msig", state)
+	a, b, user := newAccount(t), newAccount(t), newAccount(t)
+	account, err := multisig.NewAccount(2, []keys.PublicKey{a.pub, b.pub})
+	assert.NoError(t, err)
+	assert.NoError(t, ctx.MultiSig.Set(*account))
+	assert.NoError(t, ctx.Balances.AddToAddress(account.Address(), balance.Coin{Currency: olt, Amount: balance.NewAmount(100000)}))
+	state.Commit()
+
+	// the account pays once enough of its keys signed
+	tx := sendTx()
+	tx.FeePayer = account.Address()
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx)}
+	_, err = tx.Payer(ctx)
+	assert.Equal(t, action.ErrFeePayerNotSigned, err)
+
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx), a.sign(t, tx.RawTx)}
+	_, err = tx.Payer(ctx)
+	assert.Error(t, err)
+
+	tx.Signatures = []action.Signature{user.sign(t, tx.RawTx), a.sign(t, tx.RawTx), b.sign(t, tx.RawTx)}
+	payer, err := tx.Payer(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address(), payer)
+	ok, resp := action.BasicFeeHandling(ctx, tx, 0, 0, 1)
+	assert.True(t, ok, resp.Log)
+	state.Commit()
+	assert.True(t, balanceOf(t, ctx, account.Address()) < 100000)
+}
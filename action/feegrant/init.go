@@ -0,0 +1,25 @@
+package feegrant
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(FeeGrant), "action_fgrant")
+	serialize.RegisterConcrete(new(FeeRevoke), "action_frevoke")
+}
+
+func EnableFeeGrant(r action.Router) error {
+	err := r.AddHandler(action.FEE_GRANT, feeGrantTx{})
+	if err != nil {
+		return errors.Wrap(err, "feeGrantTx")
+	}
+	err = r.AddHandler(action.FEE_REVOKE, feeRevokeTx{})
+	if err != nil {
+		return errors.Wrap(err, "feeRevokeTx")
+	}
+	return nil
+}
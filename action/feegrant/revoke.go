@@ -0,0 +1,113 @@
+package feegrant
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+)
+
+var _ action.Msg = &FeeRevoke{}
+
+// FeeRevoke takes back what is left of a fee grant
+type FeeRevoke struct {
+	Granter action.Address `json:"granter"`
+	Grantee action.Address `json:"grantee"`
+}
+
+func (r FeeRevoke) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *FeeRevoke) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func (r FeeRevoke) Signers() []action.Address {
+	return []action.Address{r.Granter.Bytes()}
+}
+
+func (r FeeRevoke) Type() action.Type {
+	return action.FEE_REVOKE
+}
+
+func (r FeeRevoke) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(r.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: r.Granter.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.grantee"),
+		Value: r.Grantee.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = feeRevokeTx{}
+
+type feeRevokeTx struct {
+}
+
+func (feeRevokeTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	revoke := &FeeRevoke{}
+	err := revoke.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), revoke.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if revoke.Granter.Err() != nil || revoke.Grantee.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	return true, nil
+}
+
+func (r feeRevokeTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing FeeRevoke Transaction for CheckTx", tx)
+	return runFeeRevoke(ctx, tx)
+}
+
+func (r feeRevokeTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing FeeRevoke Transaction for DeliverTx", tx)
+	return runFeeRevoke(ctx, tx)
+}
+
+func (feeRevokeTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runFeeRevoke(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	revoke := &FeeRevoke{}
+	err := revoke.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.FeeGrants.Delete(revoke.Granter, revoke.Grantee)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(revoke.Tags(), "fee_revoke")}
+}
@@ -0,0 +1,138 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &CancelProposal{}
+
+type CancelProposal struct {
+	ProposalId gov.ProposalID `json:"proposalId"`
+	Proposer   keys.Address   `json:"proposer"`
+	Reason     string         `json:"reason"`
+}
+
+func (c CancelProposal) Signers() []action.Address {
+	return []action.Address{c.Proposer.Bytes()}
+}
+
+func (c CancelProposal) Type() action.Type {
+	return action.PROPOSAL_CANCEL
+}
+
+func (c CancelProposal) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.proposalId"),
+		Value: []byte(c.ProposalId),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.proposer"),
+		Value: c.Proposer.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (c CancelProposal) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CancelProposal) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+var _ action.Tx = cancelProposalTx{}
+
+type cancelProposalTx struct {
+}
+
+func (cancelProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	cancelProposal := &CancelProposal{}
+	err := cancelProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateBasic(tx.RawBytes(), cancelProposal.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	//validate transaction specific field
+	if len(cancelProposal.ProposalId) == 0 {
+		return false, errors.Wrap(action.ErrMissingData, "proposal id")
+	}
+
+	if cancelProposal.Proposer.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	return true, nil
+}
+
+func (c cancelProposalTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CancelProposal Transaction for CheckTx", tx)
+	return runCancelProposal(ctx, tx)
+}
+
+func (c cancelProposalTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CancelProposal Transaction for DeliverTx", tx)
+	return runCancelProposal(ctx, tx)
+}
+
+func (c cancelProposalTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCancelProposal(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	cancelProposal := &CancelProposal{}
+	err := cancelProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	proposals := ctx.ProposalMasterStore.Proposal
+	proposal, err := proposals.WithPrefixType(gov.ProposalStateActive).Get(cancelProposal.ProposalId)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("active proposal not found: %s", cancelProposal.ProposalId)}
+	}
+
+	if !proposal.Proposer.Equal(cancelProposal.Proposer) {
+		return false, action.Response{Log: "only the proposer can cancel a proposal"}
+	}
+
+	// once voting has started the proposal can no longer be cancelled
+	if proposal.Status != gov.ProposalStatusFunding {
+		return false, action.Response{Log: "proposal is not in funding status"}
+	}
+
+	proposal.Status = gov.ProposalStatusCompleted
+	proposal.Outcome = gov.ProposalOutcomeCancelled
+	err = proposals.Transition(proposal, gov.ProposalStateActive, gov.ProposalStateFailed)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(cancelProposal.Tags(), "cancel_proposal")}
+}
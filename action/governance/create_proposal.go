@@ -0,0 +1,209 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/utils"
+)
+
+var _ action.Msg = &CreateProposal{}
+
+type CreateProposal struct {
+	ProposalType   gov.ProposalType  `json:"proposalType"`
+	Description    string            `json:"description"`
+	Proposer       keys.Address      `json:"proposer"`
+	InitialFunding action.Amount     `json:"initialFunding"`
+	ConfigUpdate   *gov.ConfigUpdate `json:"configUpdate,omitempty"`
+}
+
+func (c CreateProposal) Signers() []action.Address {
+	return []action.Address{c.Proposer.Bytes()}
+}
+
+func (c CreateProposal) Type() action.Type {
+	return action.PROPOSAL_CREATE
+}
+
+func (c CreateProposal) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.proposer"),
+		Value: c.Proposer.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.proposalType"),
+		Value: []byte(c.ProposalType.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (c CreateProposal) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CreateProposal) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+var _ action.Tx = createProposalTx{}
+
+type createProposalTx struct {
+}
+
+func (createProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	createProposal := &CreateProposal{}
+	err := createProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateBasic(tx.RawBytes(), createProposal.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	//validate transaction specific field
+	if (&gov.ProposalOptions{}).GetOption(createProposal.ProposalType) == nil {
+		return false, errors.Wrap(action.ErrMissingData, "invalid proposal type")
+	}
+
+	if len(createProposal.Description) == 0 {
+		return false, errors.Wrap(action.ErrMissingData, "description")
+	}
+
+	if createProposal.Proposer.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	err = validateFunds(ctx, createProposal.InitialFunding)
+	if err != nil {
+		return false, err
+	}
+
+	// only config update proposals carry the options they change
+	if createProposal.ProposalType == gov.ProposalTypeConfigUpdate {
+		if createProposal.ConfigUpdate == nil || createProposal.ConfigUpdate.IsEmpty() {
+			return false, errors.Wrap(action.ErrMissingData, "config update")
+		}
+		if createProposal.ConfigUpdate.ActivationHeight <= 0 {
+			return false, errors.Wrap(action.ErrMissingData, "activation height")
+		}
+		err = createProposal.ConfigUpdate.Validate(ctx.Currencies)
+		if err != nil {
+			return false, errors.Wrap(err, "config update")
+		}
+	} else if createProposal.ConfigUpdate != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, "config update is only allowed in config update proposals")
+	}
+
+	return true, nil
+}
+
+func (c createProposalTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CreateProposal Transaction for CheckTx", tx)
+	return runCreateProposal(ctx, tx)
+}
+
+func (c createProposalTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CreateProposal Transaction for DeliverTx", tx)
+	return runCreateProposal(ctx, tx)
+}
+
+func (c createProposalTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCreateProposal(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	createProposal := &CreateProposal{}
+	err := createProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	proposals := ctx.ProposalMasterStore.Proposal
+	opt := proposals.GetOptions().GetOption(createProposal.ProposalType)
+	if opt == nil {
+		return false, action.Response{Log: "invalid proposal type"}
+	}
+
+	// the initial funding must at least match the minimum set for this proposal type
+	funding := createProposal.InitialFunding.Value
+	if funding.BigInt().Cmp(opt.InitialFunding.BigInt()) < 0 {
+		log := fmt.Sprint("initial funding is less than the required ", opt.InitialFunding.String())
+		return false, action.Response{Log: log}
+	}
+
+	height := ctx.Header.Height
+	proposal := gov.NewProposal(
+		gov.NewProposalID(utils.SHA2(tx.RawBytes())),
+		createProposal.ProposalType,
+		createProposal.Description,
+		createProposal.Proposer,
+		height+opt.FundingDeadline,
+		opt.FundingGoal,
+		height+opt.FundingDeadline+opt.VotingDeadline,
+		opt.PassPercentage,
+	)
+
+	// the update can't be activated before the voting is over, it is checked again once voting starts and the actual
+	// voting deadline is known
+	if createProposal.ConfigUpdate != nil {
+		if createProposal.ConfigUpdate.ActivationHeight <= proposal.VotingDeadline {
+			log := fmt.Sprint("activation height must be after the voting deadline ", proposal.VotingDeadline)
+			return false, action.Response{Log: log}
+		}
+		proposal.ConfigUpdate = createProposal.ConfigUpdate
+	}
+
+	_, _, err = proposals.QueryAllStores(proposal.ProposalID)
+	if err == nil {
+		return false, action.Response{Log: fmt.Sprintf("proposal already exists: %s", proposal.ProposalID)}
+	}
+
+	coin := createProposal.InitialFunding.ToCoin(ctx.Currencies)
+	err = ctx.Balances.MinusFromAddress(createProposal.Proposer.Bytes(), coin)
+	if err != nil {
+		log := fmt.Sprint("error debiting initial funding from proposer ", createProposal.Proposer, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	err = ctx.ProposalMasterStore.ProposalFund.AddFunds(proposal.ProposalID, createProposal.Proposer,
+		gov.NewAmountFromBigInt(funding.BigInt()))
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = proposals.WithPrefixType(gov.ProposalStateActive).Set(proposal)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	tags := append(createProposal.Tags(), kv.Pair{
+		Key:   []byte("tx.proposalId"),
+		Value: []byte(proposal.ProposalID),
+	})
+	return true, action.Response{
+		Events: action.GetEvent(tags, "create_proposal"),
+		Info:   string(proposal.ProposalID),
+	}
+}
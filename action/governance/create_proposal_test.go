@@ -0,0 +1,90 @@
+package governance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var olt = balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+
+func setupChain(t *testing.T, proposer keys.Address) (*action.Context, *storage.ChainState) {
+	cs := storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, ""))
+	state := storage.NewState(cs)
+
+	currencies := balance.NewCurrencySet()
+	assert.NoError(t, currencies.Register(olt))
+
+	feePool := fees.NewStore("f", state)
+	feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9})
+
+	balances := balance.NewStore("b", state)
+	assert.NoError(t, balances.AddToAddress(proposer, olt.NewCoinFromInt(100)))
+
+	proposals := gov.NewProposalStore("propActive", "propPassed", "propFailed", state)
+	proposals.SetOptions(&gov.ProposalOptions{
+		General: gov.ProposalOption{
+			InitialFunding:  olt.NewCoinFromInt(10).Amount,
+			FundingGoal:     olt.NewCoinFromInt(50).Amount,
+			FundingDeadline: 10,
+			VotingDeadline:  20,
+			PassPercentage:  51,
+		},
+	})
+	pms := gov.NewProposalMasterStore(proposals,
+		gov.NewProposalFundStore("propFunds", state),
+		gov.NewProposalVoteStore("propVotes", state))
+
+	state.Commit()
+
+	header := &abci.Header{Height: 5}
+	ctx := action.NewContext(nil, header, state, nil, balances, currencies, feePool, nil, nil, nil, nil, nil, nil,
+		nil, pms, nil, nil, nil, nil, nil, nil, log.NewLoggerWithPrefix(os.Stdout, "test"))
+	return ctx, cs
+}
+
+// Every validator must end up with the same app hash after delivering the same proposal creating block
+func TestCreateProposal_DeterministicState(t *testing.T) {
+	proposer := keys.Address(make([]byte, 20))
+	proposer[0] = 0x01
+
+	create := CreateProposal{
+		ProposalType:   gov.ProposalTypeGeneral,
+		Description:    "test proposal",
+		Proposer:       proposer,
+		InitialFunding: action.Amount{Currency: olt.Name, Value: *olt.NewCoinFromInt(20).Amount},
+	}
+	data, err := create.Marshal()
+	assert.NoError(t, err)
+	tx := action.RawTx{Type: action.PROPOSAL_CREATE, Data: data, Memo: "test"}
+
+	hashes := make([][]byte, 0, 2)
+	ids := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		ctx, cs := setupChain(t, proposer)
+
+		ctx.State.BeginTxSession()
+		ok, resp := createProposalTx{}.ProcessDeliver(ctx, tx)
+		assert.True(t, ok, resp.Log)
+		ctx.State.CommitTxSession()
+		ctx.State.Commit()
+
+		hashes = append(hashes, cs.Hash)
+		ids = append(ids, resp.Info)
+	}
+
+	assert.Equal(t, ids[0], ids[1])
+	assert.Equal(t, hashes[0], hashes[1])
+}
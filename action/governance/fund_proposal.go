@@ -0,0 +1,148 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &FundProposal{}
+
+type FundProposal struct {
+	ProposalId    gov.ProposalID `json:"proposalId"`
+	FunderAddress keys.Address   `json:"funderAddress"`
+	FundValue     action.Amount  `json:"fundValue"`
+}
+
+func (f FundProposal) Signers() []action.Address {
+	return []action.Address{f.FunderAddress.Bytes()}
+}
+
+func (f FundProposal) Type() action.Type {
+	return action.PROPOSAL_FUND
+}
+
+func (f FundProposal) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(f.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.proposalId"),
+		Value: []byte(f.ProposalId),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.funder"),
+		Value: f.FunderAddress.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (f FundProposal) Marshal() ([]byte, error) {
+	return json.Marshal(f)
+}
+
+func (f *FundProposal) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, f)
+}
+
+var _ action.Tx = fundProposalTx{}
+
+type fundProposalTx struct {
+}
+
+func (fundProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	fundProposal := &FundProposal{}
+	err := fundProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateBasic(tx.RawBytes(), fundProposal.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	//validate transaction specific field
+	if len(fundProposal.ProposalId) == 0 {
+		return false, errors.Wrap(action.ErrMissingData, "proposal id")
+	}
+
+	if fundProposal.FunderAddress.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	err = validateFunds(ctx, fundProposal.FundValue)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (f fundProposalTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing FundProposal Transaction for CheckTx", tx)
+	return runFundProposal(ctx, tx)
+}
+
+func (f fundProposalTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing FundProposal Transaction for DeliverTx", tx)
+	return runFundProposal(ctx, tx)
+}
+
+func (f fundProposalTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runFundProposal(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	fundProposal := &FundProposal{}
+	err := fundProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// only active proposals that are still raising funds can be funded
+	proposal, err := ctx.ProposalMasterStore.Proposal.WithPrefixType(gov.ProposalStateActive).Get(fundProposal.ProposalId)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("active proposal not found: %s", fundProposal.ProposalId)}
+	}
+
+	if proposal.Status != gov.ProposalStatusFunding {
+		return false, action.Response{Log: "proposal is not in funding status"}
+	}
+
+	if ctx.Header.Height > proposal.FundingDeadline {
+		return false, action.Response{Log: "funding deadline has passed"}
+	}
+
+	coin := fundProposal.FundValue.ToCoin(ctx.Currencies)
+	err = ctx.Balances.MinusFromAddress(fundProposal.FunderAddress.Bytes(), coin)
+	if err != nil {
+		log := fmt.Sprint("error debiting funds from funder ", fundProposal.FunderAddress, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	err = ctx.ProposalMasterStore.ProposalFund.AddFunds(proposal.ProposalID, fundProposal.FunderAddress,
+		gov.NewAmountFromBigInt(fundProposal.FundValue.Value.BigInt()))
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(fundProposal.Tags(), "fund_proposal")}
+}
@@ -0,0 +1,58 @@
+package governance
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(CreateProposal), "action_cp")
+	serialize.RegisterConcrete(new(CancelProposal), "action_cancelp")
+	serialize.RegisterConcrete(new(FundProposal), "action_fp")
+	serialize.RegisterConcrete(new(VoteProposal), "action_vp")
+	serialize.RegisterConcrete(new(WithdrawFunds), "action_wf")
+}
+
+func EnableGovernance(r action.Router) error {
+	err := r.AddHandler(action.PROPOSAL_CREATE, createProposalTx{})
+	if err != nil {
+		return errors.Wrap(err, "createProposalTx")
+	}
+	err = r.AddHandler(action.PROPOSAL_CANCEL, cancelProposalTx{})
+	if err != nil {
+		return errors.Wrap(err, "cancelProposalTx")
+	}
+	err = r.AddHandler(action.PROPOSAL_FUND, fundProposalTx{})
+	if err != nil {
+		return errors.Wrap(err, "fundProposalTx")
+	}
+	err = r.AddHandler(action.PROPOSAL_VOTE, voteProposalTx{})
+	if err != nil {
+		return errors.Wrap(err, "voteProposalTx")
+	}
+	err = r.AddHandler(action.PROPOSAL_WITHDRAW_FUNDS, withdrawFundsTx{})
+	if err != nil {
+		return errors.Wrap(err, "withdrawFundsTx")
+	}
+	return nil
+}
+
+// validateFunds checks that an amount is valid and given in the currency used to fund proposals
+func validateFunds(ctx *action.Context, amount action.Amount) error {
+	if !amount.IsValid(ctx.Currencies) || amount.Value.BigInt().Sign() <= 0 {
+		return errors.Wrap(action.ErrInvalidAmount, amount.String())
+	}
+	if amount.Currency != ctx.FeePool.GetOpt().FeeCurrency.Name {
+		return errors.Wrap(action.ErrInvalidCurrency, amount.Currency)
+	}
+	return nil
+}
+
+// fundingCoin converts a proposal amount back to a coin in the currency used to fund proposals
+func fundingCoin(ctx *action.Context, amt *gov.ProposalAmount) balance.Coin {
+	return ctx.FeePool.GetOpt().FeeCurrency.NewCoinFromAmount(*balance.NewAmountFromBigInt(amt.BigInt()))
+}
@@ -0,0 +1,163 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &VoteProposal{}
+
+type VoteProposal struct {
+	ProposalId gov.ProposalID  `json:"proposalId"`
+	Address    keys.Address    `json:"address"`
+	Opinion    gov.VoteOpinion `json:"opinion"`
+}
+
+func (v VoteProposal) Signers() []action.Address {
+	return []action.Address{v.Address.Bytes()}
+}
+
+func (v VoteProposal) Type() action.Type {
+	return action.PROPOSAL_VOTE
+}
+
+func (v VoteProposal) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(v.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.proposalId"),
+		Value: []byte(v.ProposalId),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.voter"),
+		Value: v.Address.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.opinion"),
+		Value: []byte(v.Opinion.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+func (v VoteProposal) Marshal() ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (v *VoteProposal) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, v)
+}
+
+var _ action.Tx = voteProposalTx{}
+
+type voteProposalTx struct {
+}
+
+func (voteProposalTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	voteProposal := &VoteProposal{}
+	err := voteProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateBasic(tx.RawBytes(), voteProposal.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	//validate transaction specific field
+	if len(voteProposal.ProposalId) == 0 {
+		return false, errors.Wrap(action.ErrMissingData, "proposal id")
+	}
+
+	if voteProposal.Address.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	switch voteProposal.Opinion {
+	case gov.OPIN_POSITIVE, gov.OPIN_NEGATIVE, gov.OPIN_GIVEUP:
+	default:
+		return false, errors.Wrap(action.ErrMissingData, "invalid opinion")
+	}
+
+	return true, nil
+}
+
+func (v voteProposalTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing VoteProposal Transaction for CheckTx", tx)
+	return runVoteProposal(ctx, tx)
+}
+
+func (v voteProposalTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing VoteProposal Transaction for DeliverTx", tx)
+	return runVoteProposal(ctx, tx)
+}
+
+func (v voteProposalTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runVoteProposal(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	voteProposal := &VoteProposal{}
+	err := voteProposal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	proposal, err := ctx.ProposalMasterStore.Proposal.WithPrefixType(gov.ProposalStateActive).Get(voteProposal.ProposalId)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("active proposal not found: %s", voteProposal.ProposalId)}
+	}
+
+	if proposal.Status != gov.ProposalStatusVoting {
+		return false, action.Response{Log: "proposal is not in voting status"}
+	}
+
+	if ctx.Header.Height > proposal.VotingDeadline {
+		return false, action.Response{Log: "voting deadline has passed"}
+	}
+
+	// only validators recorded when voting started can vote, with the power they had at that time
+	votes := ctx.ProposalMasterStore.ProposalVote
+	addrs, records, err := votes.GetVotesByID(proposal.ProposalID)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	var vote *gov.ProposalVote
+	for i, addr := range addrs {
+		if addr.Equal(voteProposal.Address) {
+			vote = records[i]
+			break
+		}
+	}
+	if vote == nil {
+		return false, action.Response{Log: fmt.Sprintf("address can't vote on this proposal: %s", voteProposal.Address)}
+	}
+
+	vote.Opinion = voteProposal.Opinion
+	err = votes.Update(proposal.ProposalID, vote)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(voteProposal.Tags(), "vote_proposal")}
+}
@@ -0,0 +1,141 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	gov "github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+var _ action.Msg = &WithdrawFunds{}
+
+type WithdrawFunds struct {
+	ProposalId  gov.ProposalID `json:"proposalId"`
+	Contributor keys.Address   `json:"contributor"`
+}
+
+func (w WithdrawFunds) Signers() []action.Address {
+	return []action.Address{w.Contributor.Bytes()}
+}
+
+func (w WithdrawFunds) Type() action.Type {
+	return action.PROPOSAL_WITHDRAW_FUNDS
+}
+
+func (w WithdrawFunds) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(w.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.proposalId"),
+		Value: []byte(w.ProposalId),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.contributor"),
+		Value: w.Contributor.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+func (w WithdrawFunds) Marshal() ([]byte, error) {
+	return json.Marshal(w)
+}
+
+func (w *WithdrawFunds) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, w)
+}
+
+var _ action.Tx = withdrawFundsTx{}
+
+type withdrawFundsTx struct {
+}
+
+func (withdrawFundsTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	withdrawFunds := &WithdrawFunds{}
+	err := withdrawFunds.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateBasic(tx.RawBytes(), withdrawFunds.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	//validate transaction specific field
+	if len(withdrawFunds.ProposalId) == 0 {
+		return false, errors.Wrap(action.ErrMissingData, "proposal id")
+	}
+
+	if withdrawFunds.Contributor.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	return true, nil
+}
+
+func (w withdrawFundsTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing WithdrawFunds Transaction for CheckTx", tx)
+	return runWithdrawFunds(ctx, tx)
+}
+
+func (w withdrawFundsTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing WithdrawFunds Transaction for DeliverTx", tx)
+	return runWithdrawFunds(ctx, tx)
+}
+
+func (w withdrawFundsTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runWithdrawFunds(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	withdrawFunds := &WithdrawFunds{}
+	err := withdrawFunds.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// funds can only be taken back from proposals that failed or were cancelled
+	_, err = ctx.ProposalMasterStore.Proposal.WithPrefixType(gov.ProposalStateFailed).Get(withdrawFunds.ProposalId)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("failed proposal not found: %s", withdrawFunds.ProposalId)}
+	}
+
+	funds := ctx.ProposalMasterStore.ProposalFund
+	amt, err := funds.GetFundsForProposalByFunder(withdrawFunds.ProposalId, withdrawFunds.Contributor)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if amt.BigInt().Sign() <= 0 {
+		return false, action.Response{Log: "no funds to withdraw"}
+	}
+
+	_, err = funds.DeleteFunds(withdrawFunds.ProposalId, withdrawFunds.Contributor)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Balances.AddToAddress(withdrawFunds.Contributor.Bytes(), fundingCoin(ctx, amt))
+	if err != nil {
+		log := fmt.Sprint("error crediting funds to contributor ", withdrawFunds.Contributor, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	return true, action.Response{Events: action.GetEvent(withdrawFunds.Tags(), "withdraw_funds")}
+}
@@ -10,21 +10,52 @@ import (
 type Type int
 
 const (
-	SEND Type = 0x01
+	SEND           Type = 0x01
+	MULTI_SEND     Type = 0x02
+	CREATE_VESTING Type = 0x03
 
 	//staking related transaction
 	APPLYVALIDATOR Type = 0x11
 	WITHDRAW       Type = 0x12
 	PURGE          Type = 0x13
+	DELEGATE       Type = 0x14
+	UNDELEGATE     Type = 0x15
+	UNSTAKE        Type = 0x16
+	UNJAIL         Type = 0x17
 
 	//ons related transaction
-	DOMAIN_CREATE     Type = 0x21
-	DOMAIN_UPDATE     Type = 0x22
-	DOMAIN_SELL       Type = 0x23
-	DOMAIN_PURCHASE   Type = 0x24
-	DOMAIN_SEND       Type = 0x25
-	DOMAIN_DELETE_SUB Type = 0x26
-	DOMAIN_RENEW      Type = 0x27
+	DOMAIN_CREATE           Type = 0x21
+	DOMAIN_UPDATE           Type = 0x22
+	DOMAIN_SELL             Type = 0x23
+	DOMAIN_PURCHASE         Type = 0x24
+	DOMAIN_SEND             Type = 0x25
+	DOMAIN_DELETE_SUB       Type = 0x26
+	DOMAIN_RENEW            Type = 0x27
+	DOMAIN_SET_RECORDS      Type = 0x28
+	DOMAIN_SET_PRIMARY      Type = 0x29
+	DOMAIN_BID_COMMIT       Type = 0x2A
+	DOMAIN_BID_REVEAL       Type = 0x2B
+	DOMAIN_AUCTION_FINALIZE Type = 0x2C
+	DOMAIN_MAKE_OFFER       Type = 0x2D
+	DOMAIN_CANCEL_OFFER     Type = 0x2E
+	DOMAIN_ACCEPT_OFFER     Type = 0x2F
+
+	//governance related transaction
+	PROPOSAL_CREATE         Type = 0x31
+	PROPOSAL_CANCEL         Type = 0x32
+	PROPOSAL_FUND           Type = 0x33
+	PROPOSAL_VOTE           Type = 0x34
+	PROPOSAL_WITHDRAW_FUNDS Type = 0x35
+
+	//account related transaction
+	MULTISIG_CREATE Type = 0x41
+	FEE_GRANT       Type = 0x42
+	FEE_REVOKE      Type = 0x43
+
+	//currency related transaction
+	CURRENCY_REGISTER Type = 0x51
+	MINT              Type = 0x52
+	BURN              Type = 0x53
 
 	BTC_LOCK                   Type = 0x81
 	BTC_ADD_SIGNATURE          Type = 0x82
@@ -63,10 +94,22 @@ func (t Type) String() string {
 	switch t {
 	case SEND:
 		return "SEND"
+	case MULTI_SEND:
+		return "MULTI_SEND"
+	case CREATE_VESTING:
+		return "CREATE_VESTING"
 	case APPLYVALIDATOR:
 		return "APPLY_VALIDATOR"
 	case WITHDRAW:
 		return "WITHDRAW"
+	case DELEGATE:
+		return "DELEGATE"
+	case UNDELEGATE:
+		return "UNDELEGATE"
+	case UNSTAKE:
+		return "UNSTAKE"
+	case UNJAIL:
+		return "UNJAIL"
 	case DOMAIN_CREATE:
 		return "DOMAIN_CREATE"
 	case DOMAIN_UPDATE:
@@ -81,6 +124,47 @@ func (t Type) String() string {
 		return "DOMAIN_DELETE_SUB"
 	case DOMAIN_RENEW:
 		return "DOMAIN_RENEW"
+	case DOMAIN_SET_RECORDS:
+		return "DOMAIN_SET_RECORDS"
+	case DOMAIN_SET_PRIMARY:
+		return "DOMAIN_SET_PRIMARY"
+	case DOMAIN_BID_COMMIT:
+		return "DOMAIN_BID_COMMIT"
+	case DOMAIN_BID_REVEAL:
+		return "DOMAIN_BID_REVEAL"
+	case DOMAIN_AUCTION_FINALIZE:
+		return "DOMAIN_AUCTION_FINALIZE"
+	case DOMAIN_MAKE_OFFER:
+		return "DOMAIN_MAKE_OFFER"
+	case DOMAIN_CANCEL_OFFER:
+		return "DOMAIN_CANCEL_OFFER"
+	case DOMAIN_ACCEPT_OFFER:
+		return "DOMAIN_ACCEPT_OFFER"
+
+	case PROPOSAL_CREATE:
+		return "PROPOSAL_CREATE"
+	case PROPOSAL_CANCEL:
+		return "PROPOSAL_CANCEL"
+	case PROPOSAL_FUND:
+		return "PROPOSAL_FUND"
+	case PROPOSAL_VOTE:
+		return "PROPOSAL_VOTE"
+	case PROPOSAL_WITHDRAW_FUNDS:
+		return "PROPOSAL_WITHDRAW_FUNDS"
+
+	case MULTISIG_CREATE:
+		return "MULTISIG_CREATE"
+	case FEE_GRANT:
+		return "FEE_GRANT"
+	case FEE_REVOKE:
+		return "FEE_REVOKE"
+
+	case CURRENCY_REGISTER:
+		return "CURRENCY_REGISTER"
+	case MINT:
+		return "MINT"
+	case BURN:
+		return "BURN"
 
 	case BTC_LOCK:
 		return "BTC_LOCK"
@@ -0,0 +1,132 @@
+package multisig
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+)
+
+var _ action.Msg = &CreateMultiSig{}
+
+// CreateMultiSig registers a multisig account on chain, the account address is derived from the threshold and keys
+type CreateMultiSig struct {
+	Creator   action.Address   `json:"creator"`
+	Threshold int              `json:"threshold"`
+	PubKeys   []keys.PublicKey `json:"pubKeys"`
+}
+
+func (c CreateMultiSig) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CreateMultiSig) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+func (c CreateMultiSig) Signers() []action.Address {
+	return []action.Address{c.Creator.Bytes()}
+}
+
+func (c CreateMultiSig) Type() action.Type {
+	return action.MULTISIG_CREATE
+}
+
+func (c CreateMultiSig) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: c.Creator.Bytes(),
+	}
+	tags = append(tags, tag, tag2)
+
+	account := multisig.Account{Threshold: c.Threshold, PubKeys: c.PubKeys}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.multisig"),
+		Value: account.Address().Bytes(),
+	}
+	return append(tags, tag3)
+}
+
+var _ action.Tx = createMultiSigTx{}
+
+type createMultiSigTx struct {
+}
+
+func (createMultiSigTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	create := &CreateMultiSig{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateBasic(tx.RawBytes(), create.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if create.Creator.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	_, err = multisig.NewAccount(create.Threshold, create.PubKeys)
+	if err != nil {
+		return false, errors.Wrap(action.ErrMissingData, err.Error())
+	}
+
+	return true, nil
+}
+
+func (c createMultiSigTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CreateMultiSig Transaction for CheckTx", tx)
+	return runCreateMultiSig(ctx, tx)
+}
+
+func (c createMultiSigTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CreateMultiSig Transaction for DeliverTx", tx)
+	return runCreateMultiSig(ctx, tx)
+}
+
+func (createMultiSigTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCreateMultiSig(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	create := &CreateMultiSig{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	account, err := multisig.NewAccount(create.Threshold, create.PubKeys)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	addr := account.Address()
+	if ctx.MultiSig.Exists(addr) {
+		return false, action.Response{Log: "multisig account already registered: " + addr.String()}
+	}
+
+	err = ctx.MultiSig.Set(*account)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(create.Tags(), "create_multisig"), Info: addr.String()}
+}
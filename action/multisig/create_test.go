@@ -0,0 +1,92 @@
+package multisig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+type member struct {
+	pub  keys.PublicKey
+	priv keys.PrivateKey
+}
+
+func (m member) sign(t *testing.T, data []byte) action.Signature {
+	h, err := m.priv.GetHandler()
+	assert.NoError(t, err)
+	signed, err := h.Sign(data)
+	assert.NoError(t, err)
+	return action.Signature{Signer: m.pub, Signed: signed}
+}
+
+func (m member) address(t *testing.T) keys.Address {
+	h, err := m.pub.GetHandler()
+	assert.NoError(t, err)
+	return h.Address()
+}
+
+func setup(t *testing.T) (*action.Context, []member) {
+	state := storage.NewState(storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, "")))
+	ctx := action.NewContext(nil, nil, state, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, multisig.NewStore("msig", state), nil, nil, nil, log.NewLoggerWithPrefix(os.Stdout, "test"))
+
+	members := make([]member, 0, 3)
+	pubKeys := make([]keys.PublicKey, 0, 3)
+	for i := 0; i < 3; i++ {
+		pub, priv, err := keys.NewKeyPairFromTendermint()
+		assert.NoError(t, err)
+		members = append(members, member{pub, priv})
+		pubKeys = append(pubKeys, pub)
+	}
+
+	create := CreateMultiSig{Creator: members[0].address(t), Threshold: 2, PubKeys: pubKeys}
+	data, err := create.Marshal()
+	assert.NoError(t, err)
+	ok, resp := runCreateMultiSig(ctx, action.RawTx{Type: action.MULTISIG_CREATE, Data: data})
+	assert.True(t, ok, resp.Log)
+	state.Commit()
+
+	// the same account can't be registered twice
+	ok, _ = runCreateMultiSig(ctx, action.RawTx{Type: action.MULTISIG_CREATE, Data: data})
+	assert.False(t, ok)
+
+	return ctx, members
+}
+
+func TestValidateSignatures_MultiSig(t *testing.T) {
+	ctx, members := setup(t)
+	account, err := multisig.NewAccount(2, []keys.PublicKey{members[0].pub, members[1].pub, members[2].pub})
+	assert.NoError(t, err)
+	addr := account.Address()
+	assert.True(t, ctx.MultiSig.Exists(addr))
+	data := []byte("transfer from the multisig account")
+
+	// threshold met by any two members
+	sigs := []action.Signature{members[0].sign(t, data), members[2].sign(t, data)}
+	assert.NoError(t, action.ValidateSignatures(ctx, data, []action.Address{addr}, sigs))
+
+	// not enough members signed
+	sigs = []action.Signature{members[1].sign(t, data)}
+	assert.Equal(t, action.ErrMultiSigThreshold, action.ValidateSignatures(ctx, data, []action.Address{addr}, sigs))
+
+	// the same member twice doesn't count twice
+	sigs = []action.Signature{members[1].sign(t, data), members[1].sign(t, data)}
+	assert.Error(t, action.ValidateSignatures(ctx, data, []action.Address{addr}, sigs))
+
+	// a wrong signature from a member
+	sigs = []action.Signature{members[0].sign(t, data), members[1].sign(t, []byte("something else"))}
+	assert.Error(t, action.ValidateSignatures(ctx, data, []action.Address{addr}, sigs))
+
+	// ordinary signers next to a multisig signer
+	single := members[1].address(t)
+	sigs = []action.Signature{members[0].sign(t, data), members[1].sign(t, data), members[1].sign(t, data)}
+	assert.NoError(t, action.ValidateSignatures(ctx, data, []action.Address{addr, single}, sigs))
+}
@@ -0,0 +1,20 @@
+package multisig
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+func init() {
+	serialize.RegisterConcrete(new(CreateMultiSig), "action_cms")
+}
+
+func EnableMultiSig(r action.Router) error {
+	err := r.AddHandler(action.MULTISIG_CREATE, createMultiSigTx{})
+	if err != nil {
+		return errors.Wrap(err, "createMultiSigTx")
+	}
+	return nil
+}
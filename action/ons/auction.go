@@ -0,0 +1,464 @@
+package ons
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/ons"
+)
+
+/*
+		Auctions
+
+	When auctions are enabled in the ons options, top level names are sold by sealed bid auctions instead of
+DOMAIN_CREATE. The first committed bid on a free name opens its auction. Bidders commit the hash of their bid
+along with a deposit, which is escrowed and must cover the bid, then reveal the bid once the commit window is over.
+The auction is settled at the end of the reveal window, and the highest bidder finalizes it to get the domain at
+the price of the second highest bid.
+
+*/
+
+var _ Ons = &BidCommit{}
+
+type BidCommit struct {
+	Bidder action.Address `json:"bidder"`
+	Name   ons.Name       `json:"name"`
+	// hash of the name, the bidder, the bid amount and a salt, see ons.BidCommitment
+	Commitment []byte        `json:"commitment"`
+	Deposit    action.Amount `json:"deposit"`
+}
+
+func (bc BidCommit) Marshal() ([]byte, error) {
+	return json.Marshal(bc)
+}
+
+func (bc *BidCommit) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, bc)
+}
+
+func (bc BidCommit) OnsName() string {
+	return bc.Name.String()
+}
+
+func (bc BidCommit) Signers() []action.Address {
+	return []action.Address{bc.Bidder}
+}
+
+func (bc BidCommit) Type() action.Type {
+	return action.DOMAIN_BID_COMMIT
+}
+
+func (bc BidCommit) Tags() kv.Pairs {
+	return domainTags(bc.Type(), bc.Bidder, bc.Name)
+}
+
+var _ action.Tx = bidCommitTx{}
+
+type bidCommitTx struct {
+}
+
+func (bidCommitTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	commit := &BidCommit{}
+	err := commit.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), commit.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if commit.Bidder.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if len(commit.Name) <= 0 || len(commit.Commitment) == 0 {
+		return false, action.ErrMissingData
+	}
+
+	if !commit.Name.IsValid() || commit.Name.IsSub() {
+		return false, ErrInvalidDomain
+	}
+
+	if !commit.Deposit.IsValid(ctx.Currencies) {
+		return false, action.ErrInvalidAmount
+	}
+
+	return true, nil
+}
+
+func (bidCommitTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runBidCommit(ctx, tx)
+}
+
+func (bidCommitTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runBidCommit(ctx, tx)
+}
+
+func (bidCommitTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runBidCommit(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	commit := &BidCommit{}
+	err := commit.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	opt := ctx.Domains.GetOptions()
+	if !opt.AuctionsEnabled() {
+		return false, action.Response{Log: "domain auctions are not enabled"}
+	}
+	if !verifyDomainName(commit.Name, opt) {
+		return false, action.Response{Log: ErrInvalidDomain.Error()}
+	}
+	if ctx.Domains.Exists(commit.Name) {
+		return false, action.Response{Log: fmt.Sprintf("domain already exist: %s", commit.Name)}
+	}
+
+	if commit.Deposit.Currency != opt.Currency {
+		return false, action.Response{Log: fmt.Sprintf("deposit must be in %s", opt.Currency)}
+	}
+	minBid := opt.GetMinAuctionBid()
+	if commit.Deposit.Value.BigInt().Cmp(minBid.BigInt()) < 0 {
+		return false, action.Response{Log: fmt.Sprintf("deposit less than the minimum bid: %s", minBid.String())}
+	}
+
+	// the first bid opens the auction of the name
+	auction, err := ctx.Domains.GetAuction(commit.Name)
+	if err == ons.ErrAuctionNotFound {
+		auction = ons.NewAuction(commit.Name, ctx.Header.Height, opt)
+	} else if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if ctx.Header.Height > auction.CommitEnd {
+		return false, action.Response{Log: ons.ErrAuctionClosed.Error()}
+	}
+	if _, ok := auction.GetBid(commit.Bidder.Bytes()); ok {
+		return false, action.Response{Log: fmt.Sprintf("bid already committed by: %s", commit.Bidder)}
+	}
+
+	deposit := commit.Deposit.ToCoin(ctx.Currencies)
+	err = ctx.Balances.MinusFromAddress(commit.Bidder.Bytes(), deposit)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, commit.Bidder.String()).Error()}
+	}
+	err = ctx.Balances.AddToAddress(ons.AuctionEscrow, deposit)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	auction.Bids = append(auction.Bids, ons.Bid{
+		Bidder:     commit.Bidder.Bytes(),
+		Commitment: commit.Commitment,
+		Deposit:    commit.Deposit.Value,
+	})
+	err = ctx.Domains.SetAuction(auction)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(commit.Tags(), "domain_bid_commit")}
+}
+
+var _ Ons = &BidReveal{}
+
+type BidReveal struct {
+	Bidder action.Address `json:"bidder"`
+	Name   ons.Name       `json:"name"`
+	Amount action.Amount  `json:"amount"`
+	Salt   []byte         `json:"salt"`
+}
+
+func (br BidReveal) Marshal() ([]byte, error) {
+	return json.Marshal(br)
+}
+
+func (br *BidReveal) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, br)
+}
+
+func (br BidReveal) OnsName() string {
+	return br.Name.String()
+}
+
+func (br BidReveal) Signers() []action.Address {
+	return []action.Address{br.Bidder}
+}
+
+func (br BidReveal) Type() action.Type {
+	return action.DOMAIN_BID_REVEAL
+}
+
+func (br BidReveal) Tags() kv.Pairs {
+	return domainTags(br.Type(), br.Bidder, br.Name)
+}
+
+var _ action.Tx = bidRevealTx{}
+
+type bidRevealTx struct {
+}
+
+func (bidRevealTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	reveal := &BidReveal{}
+	err := reveal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), reveal.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if reveal.Bidder.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if len(reveal.Name) <= 0 {
+		return false, action.ErrMissingData
+	}
+
+	if !reveal.Amount.IsValid(ctx.Currencies) {
+		return false, action.ErrInvalidAmount
+	}
+
+	return true, nil
+}
+
+func (bidRevealTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runBidReveal(ctx, tx)
+}
+
+func (bidRevealTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runBidReveal(ctx, tx)
+}
+
+func (bidRevealTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runBidReveal(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	reveal := &BidReveal{}
+	err := reveal.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	auction, err := ctx.Domains.GetAuction(reveal.Name)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if ctx.Header.Height <= auction.CommitEnd || ctx.Header.Height > auction.RevealEnd {
+		return false, action.Response{Log: ons.ErrAuctionClosed.Error()}
+	}
+
+	bid, ok := auction.GetBid(reveal.Bidder.Bytes())
+	if !ok {
+		return false, action.Response{Log: ons.ErrBidNotFound.Error()}
+	}
+	if bid.Revealed {
+		return false, action.Response{Log: "bid already revealed"}
+	}
+
+	opt := ctx.Domains.GetOptions()
+	if reveal.Amount.Currency != opt.Currency {
+		return false, action.Response{Log: fmt.Sprintf("bid must be in %s", opt.Currency)}
+	}
+	commitment := ons.BidCommitment(reveal.Name, reveal.Bidder.Bytes(), reveal.Amount.Value, reveal.Salt)
+	if !bytes.Equal(commitment, bid.Commitment) {
+		return false, action.Response{Log: "bid doesn't match the commitment"}
+	}
+	minBid := opt.GetMinAuctionBid()
+	if reveal.Amount.Value.BigInt().Cmp(minBid.BigInt()) < 0 {
+		return false, action.Response{Log: fmt.Sprintf("bid less than the minimum bid: %s", minBid.String())}
+	}
+	if reveal.Amount.Value.BigInt().Cmp(bid.Deposit.BigInt()) > 0 {
+		return false, action.Response{Log: "bid more than the deposit"}
+	}
+
+	bid.Revealed = true
+	bid.Amount = reveal.Amount.Value
+	err = ctx.Domains.SetAuction(auction)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(reveal.Tags(), "domain_bid_reveal")}
+}
+
+var _ Ons = &AuctionFinalize{}
+
+type AuctionFinalize struct {
+	Winner      action.Address `json:"winner"`
+	Beneficiary action.Address `json:"beneficiary"`
+	Name        ons.Name       `json:"name"`
+	Uri         string         `json:"uri"`
+}
+
+func (af AuctionFinalize) Marshal() ([]byte, error) {
+	return json.Marshal(af)
+}
+
+func (af *AuctionFinalize) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, af)
+}
+
+func (af AuctionFinalize) OnsName() string {
+	return af.Name.String()
+}
+
+func (af AuctionFinalize) Signers() []action.Address {
+	return []action.Address{af.Winner}
+}
+
+func (af AuctionFinalize) Type() action.Type {
+	return action.DOMAIN_AUCTION_FINALIZE
+}
+
+func (af AuctionFinalize) Tags() kv.Pairs {
+	return domainTags(af.Type(), af.Winner, af.Name)
+}
+
+var _ action.Tx = auctionFinalizeTx{}
+
+type auctionFinalizeTx struct {
+}
+
+func (auctionFinalizeTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	finalize := &AuctionFinalize{}
+	err := finalize.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), finalize.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if finalize.Winner.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if len(finalize.Name) <= 0 {
+		return false, action.ErrMissingData
+	}
+
+	return true, nil
+}
+
+func (auctionFinalizeTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runAuctionFinalize(ctx, tx)
+}
+
+func (auctionFinalizeTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runAuctionFinalize(ctx, tx)
+}
+
+func (auctionFinalizeTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runAuctionFinalize(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	finalize := &AuctionFinalize{}
+	err := finalize.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	auction, err := ctx.Domains.GetAuction(finalize.Name)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if !auction.Settled || ctx.Header.Height > auction.FinalizeEnd {
+		return false, action.Response{Log: ons.ErrAuctionClosed.Error()}
+	}
+	if !bytes.Equal(auction.Winner, finalize.Winner) {
+		return false, action.Response{Log: fmt.Sprintf("auction not won by: %s", finalize.Winner)}
+	}
+	bid, ok := auction.GetBid(auction.Winner)
+	if !ok {
+		return false, action.Response{Log: ons.ErrBidNotFound.Error()}
+	}
+
+	opt := ctx.Domains.GetOptions()
+	if len(finalize.Uri) > 0 && !opt.IsValidURI(finalize.Uri) {
+		return false, action.Response{Log: "invalid uri provided"}
+	}
+
+	// the winner pays the second highest bid, the rest of the deposit goes back
+	currency, ok := ctx.Currencies.GetCurrencyByName(opt.Currency)
+	if !ok {
+		return false, action.Response{Log: fmt.Sprintf("currency not registered: %s", opt.Currency)}
+	}
+	deposit := currency.NewCoinFromAmount(bid.Deposit)
+	price := currency.NewCoinFromAmount(auction.Price)
+	change, err := deposit.Minus(price)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	err = ctx.Balances.MinusFromAddress(ons.AuctionEscrow, deposit)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	err = ctx.FeePool.AddToPool(price)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if change.Amount.BigInt().Sign() > 0 {
+		err = ctx.Balances.AddToAddress(auction.Winner, change)
+		if err != nil {
+			return false, action.Response{Log: err.Error()}
+		}
+	}
+
+	extend, err := calculateExpiry(&auction.Price, &opt.BaseDomainPrice, &opt.PerBlockFees)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	domain, err := ons.NewDomain(
+		finalize.Winner,
+		finalize.Beneficiary,
+		finalize.Name.String(),
+		ctx.Header.Height,
+		finalize.Uri,
+		ctx.State.Version()+extend,
+	)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	err = ctx.Domains.Set(domain)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Domains.DeleteAuction(finalize.Name)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(finalize.Tags(), "domain_auction_finalize")}
+}
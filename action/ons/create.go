@@ -86,7 +86,7 @@ func (domainCreateTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, e
 	}
 
 	//Validate whether signers match those of the transaction and verify the signed transaction.
-	err = action.ValidateBasic(tx.RawBytes(), create.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), create.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -146,6 +146,11 @@ func runCreate(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 
 	isSub := create.Name.IsSub()
 
+	// top level names are sold by auction when auctions are enabled
+	if !isSub && ctx.Domains.GetOptions().AuctionsEnabled() {
+		return false, action.Response{Log: fmt.Sprintf("domain is sold by auction: %s", create.Name)}
+	}
+
 	// check domain existence and set to db
 	if ctx.Domains.Exists(create.Name) {
 		return false, action.Response{Log: fmt.Sprintf("domain already exist: %s", create.Name)}
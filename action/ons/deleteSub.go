@@ -68,7 +68,7 @@ func (d deleteSubTx) Validate(ctx *action.Context, signedTx action.SignedTx) (bo
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(signedTx.RawBytes(), del.Signers(), signedTx.Signatures)
+	err = action.ValidateSignatures(ctx, signedTx.RawBytes(), del.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, err
 	}
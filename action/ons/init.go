@@ -2,8 +2,10 @@ package ons
 
 import (
 	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
 
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/serialize"
 )
 
@@ -19,6 +21,14 @@ func init() {
 	serialize.RegisterConcrete(new(DomainSend), "action_dsend")
 	serialize.RegisterConcrete(new(DomainPurchase), "action_dp")
 	serialize.RegisterConcrete(new(RenewDomain), "action_dr")
+	serialize.RegisterConcrete(new(DomainSetRecords), "action_drec")
+	serialize.RegisterConcrete(new(DomainSetPrimary), "action_dprim")
+	serialize.RegisterConcrete(new(BidCommit), "action_dbc")
+	serialize.RegisterConcrete(new(BidReveal), "action_dbr")
+	serialize.RegisterConcrete(new(AuctionFinalize), "action_daf")
+	serialize.RegisterConcrete(new(MakeOffer), "action_dmo")
+	serialize.RegisterConcrete(new(CancelOffer), "action_dco")
+	serialize.RegisterConcrete(new(AcceptOffer), "action_dao")
 }
 
 func EnableONS(r action.Router) error {
@@ -50,6 +60,38 @@ func EnableONS(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "deleteSubTx")
 	}
+	err = r.AddHandler(action.DOMAIN_SET_RECORDS, domainSetRecordsTx{})
+	if err != nil {
+		return errors.Wrap(err, "domainSetRecordsTx")
+	}
+	err = r.AddHandler(action.DOMAIN_SET_PRIMARY, domainSetPrimaryTx{})
+	if err != nil {
+		return errors.Wrap(err, "domainSetPrimaryTx")
+	}
+	err = r.AddHandler(action.DOMAIN_BID_COMMIT, bidCommitTx{})
+	if err != nil {
+		return errors.Wrap(err, "bidCommitTx")
+	}
+	err = r.AddHandler(action.DOMAIN_BID_REVEAL, bidRevealTx{})
+	if err != nil {
+		return errors.Wrap(err, "bidRevealTx")
+	}
+	err = r.AddHandler(action.DOMAIN_AUCTION_FINALIZE, auctionFinalizeTx{})
+	if err != nil {
+		return errors.Wrap(err, "auctionFinalizeTx")
+	}
+	err = r.AddHandler(action.DOMAIN_MAKE_OFFER, makeOfferTx{})
+	if err != nil {
+		return errors.Wrap(err, "makeOfferTx")
+	}
+	err = r.AddHandler(action.DOMAIN_CANCEL_OFFER, cancelOfferTx{})
+	if err != nil {
+		return errors.Wrap(err, "cancelOfferTx")
+	}
+	err = r.AddHandler(action.DOMAIN_ACCEPT_OFFER, acceptOfferTx{})
+	if err != nil {
+		return errors.Wrap(err, "acceptOfferTx")
+	}
 
 	return nil
 }
@@ -58,3 +100,24 @@ type Ons interface {
 	action.Msg
 	OnsName() string
 }
+
+// domainTags are the tags of the domain txs made by an address
+func domainTags(txType action.Type, addr action.Address, name ons.Name) kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(txType.String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: addr.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.domain"),
+		Value: []byte(name.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
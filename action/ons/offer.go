@@ -0,0 +1,431 @@
+package ons
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/ons"
+)
+
+/*
+		Offers
+
+	Anyone can make an offer on a domain, whether or not it is for sale. The offered amount is escrowed until the
+owner accepts the offer, the bidder cancels it, or it expires and is refunded at the end of its expiry height. When
+the owner accepts an offer the domain goes to the bidder, the owner gets the amount, and the other offers on the
+domain are refunded.
+
+*/
+
+var _ Ons = &MakeOffer{}
+
+type MakeOffer struct {
+	Bidder       action.Address `json:"bidder"`
+	Name         ons.Name       `json:"name"`
+	Amount       action.Amount  `json:"amount"`
+	ExpireHeight int64          `json:"expireHeight"`
+}
+
+func (mo MakeOffer) Marshal() ([]byte, error) {
+	return json.Marshal(mo)
+}
+
+func (mo *MakeOffer) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, mo)
+}
+
+func (mo MakeOffer) OnsName() string {
+	return mo.Name.String()
+}
+
+func (mo MakeOffer) Signers() []action.Address {
+	return []action.Address{mo.Bidder}
+}
+
+func (mo MakeOffer) Type() action.Type {
+	return action.DOMAIN_MAKE_OFFER
+}
+
+func (mo MakeOffer) Tags() kv.Pairs {
+	return domainTags(mo.Type(), mo.Bidder, mo.Name)
+}
+
+var _ action.Tx = makeOfferTx{}
+
+type makeOfferTx struct {
+}
+
+func (makeOfferTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	offer := &MakeOffer{}
+	err := offer.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), offer.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if offer.Bidder.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if len(offer.Name) <= 0 || offer.ExpireHeight <= 0 {
+		return false, action.ErrMissingData
+	}
+
+	if !offer.Name.IsValid() {
+		return false, ErrInvalidDomain
+	}
+
+	if !offer.Amount.IsValid(ctx.Currencies) || offer.Amount.Value.BigInt().Sign() <= 0 {
+		return false, action.ErrInvalidAmount
+	}
+
+	return true, nil
+}
+
+func (makeOfferTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runMakeOffer(ctx, tx)
+}
+
+func (makeOfferTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runMakeOffer(ctx, tx)
+}
+
+func (makeOfferTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runMakeOffer(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	mo := &MakeOffer{}
+	err := mo.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	domain, err := ctx.Domains.Get(mo.Name)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("domain doesn't exist: %s", mo.Name)}
+	}
+	if domain.Name.IsSub() {
+		return false, action.Response{Log: "cannot make an offer on a subdomain"}
+	}
+	if domain.IsExpired(ctx.Header.Height) {
+		return false, action.Response{Log: fmt.Sprintf("domain is expired: %s", mo.Name)}
+	}
+	if bytes.Equal(domain.Owner, mo.Bidder) {
+		return false, action.Response{Log: "domain already owned by the bidder"}
+	}
+	if mo.ExpireHeight <= ctx.Header.Height {
+		return false, action.Response{Log: fmt.Sprintf("offer expires before the current height: %d", ctx.Header.Height)}
+	}
+
+	opt := ctx.Domains.GetOptions()
+	if mo.Amount.Currency != opt.Currency {
+		return false, action.Response{Log: fmt.Sprintf("offer must be in %s", opt.Currency)}
+	}
+	if _, err := ctx.Domains.GetOffer(mo.Name, mo.Bidder.Bytes()); err == nil {
+		return false, action.Response{Log: fmt.Sprintf("offer already made by: %s", mo.Bidder)}
+	}
+
+	coin := mo.Amount.ToCoin(ctx.Currencies)
+	err = ctx.Balances.MinusFromAddress(mo.Bidder.Bytes(), coin)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, mo.Bidder.String()).Error()}
+	}
+	err = ctx.Balances.AddToAddress(ons.OfferEscrow, coin)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Domains.SetOffer(&ons.Offer{
+		Name:           mo.Name,
+		Bidder:         mo.Bidder.Bytes(),
+		Amount:         mo.Amount.Value,
+		ExpireHeight:   mo.ExpireHeight,
+		CreationHeight: ctx.Header.Height,
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(mo.Tags(), "domain_make_offer")}
+}
+
+var _ Ons = &CancelOffer{}
+
+type CancelOffer struct {
+	Bidder action.Address `json:"bidder"`
+	Name   ons.Name       `json:"name"`
+}
+
+func (co CancelOffer) Marshal() ([]byte, error) {
+	return json.Marshal(co)
+}
+
+func (co *CancelOffer) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, co)
+}
+
+func (co CancelOffer) OnsName() string {
+	return co.Name.String()
+}
+
+func (co CancelOffer) Signers() []action.Address {
+	return []action.Address{co.Bidder}
+}
+
+func (co CancelOffer) Type() action.Type {
+	return action.DOMAIN_CANCEL_OFFER
+}
+
+func (co CancelOffer) Tags() kv.Pairs {
+	return domainTags(co.Type(), co.Bidder, co.Name)
+}
+
+var _ action.Tx = cancelOfferTx{}
+
+type cancelOfferTx struct {
+}
+
+func (cancelOfferTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	cancel := &CancelOffer{}
+	err := cancel.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), cancel.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if cancel.Bidder.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if len(cancel.Name) <= 0 {
+		return false, action.ErrMissingData
+	}
+
+	return true, nil
+}
+
+func (cancelOfferTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runCancelOffer(ctx, tx)
+}
+
+func (cancelOfferTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runCancelOffer(ctx, tx)
+}
+
+func (cancelOfferTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCancelOffer(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	cancel := &CancelOffer{}
+	err := cancel.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	offer, err := ctx.Domains.GetOffer(cancel.Name, cancel.Bidder.Bytes())
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	currency, ok := ctx.Currencies.GetCurrencyByName(ctx.Domains.GetOptions().Currency)
+	if !ok {
+		return false, action.Response{Log: action.ErrInvalidCurrency.Error()}
+	}
+	err = ctx.Domains.RefundOffer(offer, ctx.Balances, currency)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(cancel.Tags(), "domain_cancel_offer")}
+}
+
+var _ Ons = &AcceptOffer{}
+
+type AcceptOffer struct {
+	Owner  action.Address `json:"owner"`
+	Name   ons.Name       `json:"name"`
+	Bidder action.Address `json:"bidder"`
+	// the amount of the offer being accepted, the accept fails if the bidder replaced the offer
+	Amount action.Amount `json:"amount"`
+}
+
+func (ao AcceptOffer) Marshal() ([]byte, error) {
+	return json.Marshal(ao)
+}
+
+func (ao *AcceptOffer) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, ao)
+}
+
+func (ao AcceptOffer) OnsName() string {
+	return ao.Name.String()
+}
+
+func (ao AcceptOffer) Signers() []action.Address {
+	return []action.Address{ao.Owner}
+}
+
+func (ao AcceptOffer) Type() action.Type {
+	return action.DOMAIN_ACCEPT_OFFER
+}
+
+func (ao AcceptOffer) Tags() kv.Pairs {
+	tags := domainTags(ao.Type(), ao.Owner, ao.Name)
+	tag := kv.Pair{
+		Key:   []byte("tx.to"),
+		Value: ao.Bidder.Bytes(),
+	}
+	return append(tags, tag)
+}
+
+var _ action.Tx = acceptOfferTx{}
+
+type acceptOfferTx struct {
+}
+
+func (acceptOfferTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	accept := &AcceptOffer{}
+	err := accept.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), accept.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if accept.Owner.Err() != nil || accept.Bidder.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if !accept.Amount.IsValid(ctx.Currencies) || accept.Amount.Value.BigInt().Sign() <= 0 {
+		return false, action.ErrInvalidAmount
+	}
+
+	if len(accept.Name) <= 0 {
+		return false, action.ErrMissingData
+	}
+
+	return true, nil
+}
+
+func (acceptOfferTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runAcceptOffer(ctx, tx)
+}
+
+func (acceptOfferTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runAcceptOffer(ctx, tx)
+}
+
+func (acceptOfferTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runAcceptOffer(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	accept := &AcceptOffer{}
+	err := accept.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	domain, err := ctx.Domains.Get(accept.Name)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("domain doesn't exist: %s", accept.Name)}
+	}
+	if !bytes.Equal(domain.Owner, accept.Owner) {
+		return false, action.Response{Log: fmt.Sprintf("domain is not owned by: %s", accept.Owner)}
+	}
+	if domain.IsExpired(ctx.Header.Height) {
+		return false, action.Response{Log: fmt.Sprintf("domain is expired: %s", accept.Name)}
+	}
+
+	offer, err := ctx.Domains.GetOffer(accept.Name, accept.Bidder.Bytes())
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	if offer.ExpireHeight < ctx.Header.Height {
+		return false, action.Response{Log: "offer is expired"}
+	}
+
+	currency, ok := ctx.Currencies.GetCurrencyByName(ctx.Domains.GetOptions().Currency)
+	if !ok {
+		return false, action.Response{Log: action.ErrInvalidCurrency.Error()}
+	}
+	if accept.Amount.Currency != currency.Name || accept.Amount.Value.BigInt().Cmp(offer.Amount.BigInt()) != 0 {
+		return false, action.Response{Log: fmt.Sprintf("offer amount doesn't match, offer is %s", offer.Amount.String())}
+	}
+
+	// the owner gets the escrowed amount
+	coin := currency.NewCoinFromAmount(offer.Amount)
+	err = ctx.Balances.MinusFromAddress(ons.OfferEscrow, coin)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	err = ctx.Balances.AddToAddress(domain.Owner, coin)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	err = ctx.Domains.DeleteOffer(offer)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// the other offers on the domain are refunded
+	others := make([]*ons.Offer, 0)
+	ctx.Domains.IterateOffers(accept.Name, func(other *ons.Offer) bool {
+		if !bytes.Equal(other.Bidder, offer.Bidder) {
+			others = append(others, other)
+		}
+		return false
+	})
+	for _, other := range others {
+		err = ctx.Domains.RefundOffer(other, ctx.Balances, currency)
+		if err != nil {
+			return false, action.Response{Log: err.Error()}
+		}
+	}
+
+	// the domain keeps its expiry and goes to the bidder like a sale
+	domain.ResetAfterSale(offer.Bidder, offer.Bidder, 0, ctx.Header.Height)
+	err = ctx.Domains.DeleteAllSubdomains(domain.Name)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	err = ctx.Domains.Set(domain)
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, "failed to update domain").Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(accept.Tags(), "domain_accept_offer")}
+}
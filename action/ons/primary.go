@@ -0,0 +1,140 @@
+package ons
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/ons"
+)
+
+var _ Ons = &DomainSetPrimary{}
+
+// DomainSetPrimary claims the domain as the primary name of the address it resolves to, the name shown for the
+// address by reverse lookups
+type DomainSetPrimary struct {
+	Address action.Address `json:"address"`
+	// an empty name clears the primary name of the address
+	Name ons.Name `json:"name"`
+}
+
+func (dp DomainSetPrimary) Marshal() ([]byte, error) {
+	return json.Marshal(dp)
+}
+
+func (dp *DomainSetPrimary) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, dp)
+}
+
+func (dp DomainSetPrimary) OnsName() string {
+	return dp.Name.String()
+}
+
+func (dp DomainSetPrimary) Signers() []action.Address {
+	return []action.Address{dp.Address}
+}
+
+func (dp DomainSetPrimary) Type() action.Type {
+	return action.DOMAIN_SET_PRIMARY
+}
+
+func (dp DomainSetPrimary) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(dp.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: dp.Address.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.domain"),
+		Value: []byte(dp.Name.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = domainSetPrimaryTx{}
+
+type domainSetPrimaryTx struct {
+}
+
+func (domainSetPrimaryTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	primary := &DomainSetPrimary{}
+	err := primary.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), primary.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if primary.Address.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if len(primary.Name) > 0 && !primary.Name.IsValid() {
+		return false, ErrInvalidDomain
+	}
+
+	return true, nil
+}
+
+func (domainSetPrimaryTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runSetPrimary(ctx, tx)
+}
+
+func (domainSetPrimaryTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runSetPrimary(ctx, tx)
+}
+
+func (domainSetPrimaryTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runSetPrimary(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	primary := &DomainSetPrimary{}
+	err := primary.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	if len(primary.Name) == 0 {
+		if _, ok := ctx.Domains.GetPrimary(primary.Address); !ok {
+			return false, action.Response{Log: fmt.Sprintf("no primary name set for: %s", primary.Address)}
+		}
+	} else {
+		d, err := ctx.Domains.Get(primary.Name)
+		if err != nil {
+			return false, action.Response{Log: fmt.Sprintf("domain doesn't exist: %s", primary.Name)}
+		}
+		if !d.IsActive(ctx.Header.Height) {
+			return false, action.Response{Log: fmt.Sprintf("domain is not active: %s", primary.Name)}
+		}
+		// only the address the domain resolves to can claim it
+		if !bytes.Equal(d.Beneficiary, primary.Address) {
+			return false, action.Response{Log: fmt.Sprintf("domain doesn't resolve to: %s", primary.Address)}
+		}
+	}
+
+	err = ctx.Domains.SetPrimary(primary.Address.Bytes(), primary.Name)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	return true, action.Response{Events: action.GetEvent(primary.Tags(), "set_domain_primary")}
+}
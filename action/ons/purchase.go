@@ -79,7 +79,7 @@ func (domainPurchaseTx) Validate(ctx *action.Context, tx action.SignedTx) (bool,
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), buy.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), buy.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -159,6 +159,10 @@ func runPurchaseDomain(ctx *action.Context, tx action.RawTx) (bool, action.Respo
 	remain := buy.Offering.ToCoin(ctx.Currencies)
 
 	opt := ctx.Domains.GetOptions()
+	// an expired domain is kept for its owner to renew until the grace period is over
+	if domain.IsInGracePeriod(ctx.State.Version(), opt.GracePeriodBlocks) {
+		return false, action.Response{Log: "domain is in its grace period, only the owner can renew it"}
+	}
 	var extend int64
 	// if the domain is on sale and not expired
 	if (ctx.State.Version() <= domain.ExpireHeight) && domain.OnSaleFlag {
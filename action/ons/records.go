@@ -0,0 +1,162 @@
+package ons
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/ons"
+)
+
+var _ Ons = &DomainSetRecords{}
+
+type DomainSetRecords struct {
+	Owner action.Address `json:"owner"`
+	Name  ons.Name       `json:"name"`
+	// records to set on the domain, a record with an empty value removes the record of the domain it identifies
+	Records []ons.Record `json:"records"`
+}
+
+func (dr DomainSetRecords) Marshal() ([]byte, error) {
+	return json.Marshal(dr)
+}
+
+func (dr *DomainSetRecords) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, dr)
+}
+
+func (dr DomainSetRecords) OnsName() string {
+	return dr.Name.String()
+}
+
+func (dr DomainSetRecords) Signers() []action.Address {
+	return []action.Address{dr.Owner}
+}
+
+func (dr DomainSetRecords) Type() action.Type {
+	return action.DOMAIN_SET_RECORDS
+}
+
+func (dr DomainSetRecords) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(dr.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: dr.Owner.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.domain"),
+		Value: []byte(dr.Name.String()),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = domainSetRecordsTx{}
+
+type domainSetRecordsTx struct {
+}
+
+func (domainSetRecordsTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	set := &DomainSetRecords{}
+	err := set.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), set.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if set.Owner == nil || len(set.Name) <= 0 || len(set.Records) == 0 {
+		return false, action.ErrMissingData
+	}
+
+	if !set.Name.IsValid() {
+		return false, ErrInvalidDomain
+	}
+
+	opt := ctx.Domains.GetOptions()
+	for _, record := range set.Records {
+		err = record.Validate(opt)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (domainSetRecordsTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runSetRecords(ctx, tx)
+}
+
+func (domainSetRecordsTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	return runSetRecords(ctx, tx)
+}
+
+func (domainSetRecordsTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runSetRecords(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	set := &DomainSetRecords{}
+	err := set.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	d, err := ctx.Domains.Get(set.Name)
+	if err != nil {
+		return false, action.Response{Log: fmt.Sprintf("domain doesn't exist: %s", set.Name)}
+	}
+
+	if !bytes.Equal(d.Owner, set.Owner) {
+		return false, action.Response{Log: fmt.Sprintf("domain is not owned by: %s", hex.EncodeToString(set.Owner))}
+	}
+
+	if d.IsExpired(ctx.Header.Height) {
+		return false, action.Response{Log: fmt.Sprintf("domain is expired: %s", set.Name)}
+	}
+
+	if !d.IsChangeable(ctx.Header.Height) {
+		return false, action.Response{Log: fmt.Sprintf("domain is not changable: %s, last change: %d ,current height :%d", set.Name, d.LastUpdateHeight, ctx.Header.Height)}
+	}
+
+	opt := ctx.Domains.GetOptions()
+	for _, record := range set.Records {
+		err = record.Validate(opt)
+		if err != nil {
+			return false, action.Response{Log: err.Error()}
+		}
+		// records are kept for the life of the domain, so their bytes are paid as storage
+		ctx.State.ConsumeStorageGas(action.Gas(record.Size()))
+		d.SetRecord(record)
+	}
+	if len(d.Records) > opt.GetMaxRecords() {
+		return false, action.Response{Log: ons.ErrTooManyRecords.Error()}
+	}
+
+	d.SetLastUpdatedHeight(ctx.Header.Height)
+	err = ctx.Domains.Set(d)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	return true, action.Response{Events: action.GetEvent(set.Tags(), "set_domain_records")}
+}
@@ -76,7 +76,7 @@ func (r RenewDomainTx) Validate(ctx *action.Context, signedTx action.SignedTx) (
 	}
 
 	//Validate whether signers match those of the transaction and verify the signed transaction.
-	err = action.ValidateBasic(signedTx.RawBytes(), renewDomain.Signers(), signedTx.Signatures)
+	err = action.ValidateSignatures(ctx, signedTx.RawBytes(), renewDomain.Signers(), signedTx.Signatures)
 	if err != nil {
 		return false, errors.Wrap(err, err.Error())
 	}
@@ -145,8 +145,9 @@ func runRenew(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 		return false, action.Response{Log: err.Error()}
 	}
 
-	// if domain is expired it can't be renewed
-	if domain.IsExpired(ctx.State.Version()) {
+	// an expired domain can only be renewed during its grace period
+	height := ctx.State.Version()
+	if domain.IsExpired(height) && !domain.IsInGracePeriod(height, ctx.Domains.GetOptions().GracePeriodBlocks) {
 		return false, action.Response{Log: "domain already expired, need to purchase again"}
 	}
 
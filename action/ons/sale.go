@@ -92,7 +92,7 @@ func (domainSaleTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, err
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), sale.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), sale.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -91,7 +91,7 @@ func (domainSendTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, err
 	}
 
 	// validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), send.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), send.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -72,7 +72,7 @@ func (domainUpdateTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, e
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
 
-	err = action.ValidateBasic(tx.RawBytes(), update.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), update.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -51,7 +51,7 @@ func (a applyTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error)
 	if err != nil {
 		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
 	}
-	err = action.ValidateBasic(tx.RawBytes(), apply.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), apply.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -99,7 +99,7 @@ func checkBalances(ctx *action.Context, address action.Address, stake action.Amo
 		return false, action.ErrInvalidAmount
 	}
 
-	err := balances.CheckBalanceFromAddress(address.Bytes(), stake.ToCoin(ctx.Currencies))
+	err := balances.CheckStakeFromAddress(address.Bytes(), stake.ToCoin(ctx.Currencies))
 	if err != nil {
 		return false, action.ErrNotEnoughFund
 	}
@@ -148,7 +148,7 @@ func runApply(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
 			return false, action.Response{Log: err.Error()}
 		}
 
-		err = balances.MinusFromAddress(apply.StakeAddress.Bytes(), apply.Stake.ToCoin(ctx.Currencies))
+		err = balances.MinusStakeFromAddress(apply.StakeAddress.Bytes(), apply.Stake.ToCoin(ctx.Currencies))
 		if err != nil {
 			return false, action.Response{Log: errors.Wrap(err, apply.StakeAddress.String()).Error()}
 		}
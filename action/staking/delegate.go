@@ -0,0 +1,166 @@
+package staking
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/identity"
+)
+
+var _ action.Msg = &Delegate{}
+
+type Delegate struct {
+	// account from which the stake is delegated
+	Delegator action.Address `json:"delegator"`
+	// validator the stake is delegated to
+	Validator action.Address `json:"validator"`
+	Amount    action.Amount  `json:"amount"`
+}
+
+func (d Delegate) Marshal() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *Delegate) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+func (d Delegate) Signers() []action.Address {
+	return []action.Address{d.Delegator.Bytes()}
+}
+
+func (d Delegate) Type() action.Type {
+	return action.DELEGATE
+}
+
+func (d Delegate) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(d.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: d.Delegator.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: d.Validator.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = delegateTx{}
+
+type delegateTx struct {
+}
+
+func (delegateTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	delegate := &Delegate{}
+	err := delegate.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), delegate.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	err = validateDelegation(ctx, delegate.Delegator, delegate.Validator, delegate.Amount)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// validateDelegation checks the fields shared by delegate and undelegate transactions
+func validateDelegation(ctx *action.Context, delegator action.Address, validator action.Address, amount action.Amount) error {
+	if delegator.Err() != nil || validator.Err() != nil {
+		return action.ErrInvalidAddress
+	}
+
+	if !amount.IsValid(ctx.Currencies) || amount.Value.BigInt().Sign() <= 0 {
+		return action.ErrInvalidAmount
+	}
+
+	// delegations are made in the stake token, same as the validator stake
+	if amount.Currency != "VT" {
+		return action.ErrInvalidAmount
+	}
+	return nil
+}
+
+func (d delegateTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Delegate Transaction for CheckTx", tx)
+	return runDelegate(ctx, tx)
+}
+
+func (d delegateTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Delegate Transaction for DeliverTx", tx)
+	return runDelegate(ctx, tx)
+}
+
+func (delegateTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runDelegate(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	delegate := &Delegate{}
+	err := delegate.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// stake can only be delegated to an active validator
+	if !ctx.Validators.IsValidatorAddress(delegate.Validator) {
+		return false, action.Response{Log: "validator not found: " + delegate.Validator.String()}
+	}
+
+	_, err = checkBalances(ctx, delegate.Delegator, delegate.Amount)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Balances.MinusStakeFromAddress(delegate.Delegator.Bytes(), delegate.Amount.ToCoin(ctx.Currencies))
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, delegate.Delegator.String()).Error()}
+	}
+
+	delegated, err := ctx.Delegations.Get(delegate.Delegator, delegate.Validator)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	amt := big.NewInt(0).Add(delegated.BigInt(), delegate.Amount.Value.BigInt())
+
+	err = ctx.Delegations.Set(identity.Delegation{
+		Delegator: delegate.Delegator,
+		Validator: delegate.Validator,
+		Amount:    *balance.NewAmountFromBigInt(amt),
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Validators.HandleDelegation(delegate.Validator, delegate.Amount.Value)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(delegate.Tags(), "delegate")}
+}
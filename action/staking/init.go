@@ -9,6 +9,10 @@ import (
 
 func init() {
 	serialize.RegisterConcrete(new(ApplyValidator), "action_av")
+	serialize.RegisterConcrete(new(Delegate), "action_dlg")
+	serialize.RegisterConcrete(new(Undelegate), "action_udlg")
+	serialize.RegisterConcrete(new(Unstake), "action_ust")
+	serialize.RegisterConcrete(new(Unjail), "action_unjail")
 }
 
 func EnableApplyValidator(r action.Router) error {
@@ -22,6 +26,16 @@ func EnableApplyValidator(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "withdrawTx")
 	}
+
+	err = r.AddHandler(action.UNSTAKE, unstakeTx{})
+	if err != nil {
+		return errors.Wrap(err, "unstakeTx")
+	}
+
+	err = r.AddHandler(action.UNJAIL, unjailTx{})
+	if err != nil {
+		return errors.Wrap(err, "unjailTx")
+	}
 	return nil
 }
 
@@ -32,3 +46,16 @@ func EnablePurgeValidator(r action.Router) error {
 	}
 	return nil
 }
+
+func EnableDelegation(r action.Router) error {
+	err := r.AddHandler(action.DELEGATE, delegateTx{})
+	if err != nil {
+		return errors.Wrap(err, "delegateTx")
+	}
+
+	err = r.AddHandler(action.UNDELEGATE, undelegateTx{})
+	if err != nil {
+		return errors.Wrap(err, "undelegateTx")
+	}
+	return nil
+}
@@ -66,7 +66,7 @@ func (withdrawTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), Withdraw.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), Withdraw.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,149 @@
+package staking
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/identity"
+)
+
+var _ action.Msg = &Undelegate{}
+
+type Undelegate struct {
+	// account which delegated the stake, it gets the stake back
+	Delegator action.Address `json:"delegator"`
+	// validator the stake was delegated to
+	Validator action.Address `json:"validator"`
+	Amount    action.Amount  `json:"amount"`
+}
+
+func (u Undelegate) Marshal() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (u *Undelegate) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, u)
+}
+
+func (u Undelegate) Signers() []action.Address {
+	return []action.Address{u.Delegator.Bytes()}
+}
+
+func (u Undelegate) Type() action.Type {
+	return action.UNDELEGATE
+}
+
+func (u Undelegate) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(u.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: u.Delegator.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: u.Validator.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = undelegateTx{}
+
+type undelegateTx struct {
+}
+
+func (undelegateTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	undelegate := &Undelegate{}
+	err := undelegate.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), undelegate.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	err = validateDelegation(ctx, undelegate.Delegator, undelegate.Validator, undelegate.Amount)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (u undelegateTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Undelegate Transaction for CheckTx", tx)
+	return runUndelegate(ctx, tx)
+}
+
+func (u undelegateTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Undelegate Transaction for DeliverTx", tx)
+	return runUndelegate(ctx, tx)
+}
+
+func (undelegateTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runUndelegate(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	undelegate := &Undelegate{}
+	err := undelegate.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	delegated, err := ctx.Delegations.Get(undelegate.Delegator, undelegate.Validator)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+	amt := big.NewInt(0).Sub(delegated.BigInt(), undelegate.Amount.Value.BigInt())
+	if amt.Sign() < 0 {
+		return false, action.Response{Log: "undelegate more than delegated: " + delegated.String()}
+	}
+
+	err = ctx.Delegations.Set(identity.Delegation{
+		Delegator: undelegate.Delegator,
+		Validator: undelegate.Validator,
+		Amount:    *balance.NewAmountFromBigInt(amt),
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Validators.HandleUndelegation(undelegate.Validator, undelegate.Amount.Value)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// the delegation is paid back after the unbonding period, same as the validator stake
+	err = ctx.Unbondings.Add(identity.Unbonding{
+		Address:        undelegate.Delegator,
+		Validator:      undelegate.Validator,
+		Amount:         undelegate.Amount.Value,
+		MatureHeight:   ctx.Header.Height + ctx.Validators.GetOptions().UnbondingBlocks,
+		CreationHeight: ctx.Header.Height,
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(undelegate.Tags(), "undelegate")}
+}
@@ -0,0 +1,122 @@
+package staking
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+)
+
+var _ action.Msg = &Unjail{}
+
+type Unjail struct {
+	// staking account of the validator, only it can bring the validator back
+	StakeAddress     action.Address `json:"stakeAddress"`
+	ValidatorAddress action.Address `json:"validatorAddress"`
+}
+
+func (u Unjail) Marshal() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (u *Unjail) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, u)
+}
+
+func (u Unjail) Signers() []action.Address {
+	return []action.Address{u.StakeAddress.Bytes()}
+}
+
+func (u Unjail) Type() action.Type {
+	return action.UNJAIL
+}
+
+func (u Unjail) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(u.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: u.StakeAddress.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: u.ValidatorAddress.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = unjailTx{}
+
+type unjailTx struct {
+}
+
+func (unjailTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	unjail := &Unjail{}
+	err := unjail.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), unjail.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if unjail.StakeAddress.Err() != nil || unjail.ValidatorAddress.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	return true, nil
+}
+
+func (u unjailTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Unjail Transaction for CheckTx", tx)
+	return runUnjail(ctx, tx)
+}
+
+func (u unjailTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Unjail Transaction for DeliverTx", tx)
+	return runUnjail(ctx, tx)
+}
+
+func (unjailTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runUnjail(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	unjail := &Unjail{}
+	err := unjail.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	validator, err := ctx.Validators.Get(unjail.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	if !validator.StakeAddress.Equal(unjail.StakeAddress) {
+		return false, action.Response{Log: "stake address doesn't match the validator: " + unjail.StakeAddress.String()}
+	}
+
+	err = ctx.Validators.HandleUnjail(unjail.ValidatorAddress, ctx.Header.Height)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(unjail.Tags(), "unjail")}
+}
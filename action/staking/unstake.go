@@ -0,0 +1,152 @@
+package staking
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/identity"
+)
+
+var _ action.Msg = &Unstake{}
+
+type Unstake struct {
+	// staking account of the validator, the unstaked funds are paid back to it
+	StakeAddress     action.Address `json:"stakeAddress"`
+	ValidatorAddress action.Address `json:"validatorAddress"`
+	Amount           action.Amount  `json:"amount"`
+}
+
+func (u Unstake) Marshal() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (u *Unstake) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, u)
+}
+
+func (u Unstake) Signers() []action.Address {
+	return []action.Address{u.StakeAddress.Bytes()}
+}
+
+func (u Unstake) Type() action.Type {
+	return action.UNSTAKE
+}
+
+func (u Unstake) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(u.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: u.StakeAddress.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.validator"),
+		Value: u.ValidatorAddress.Bytes(),
+	}
+
+	tags = append(tags, tag, tag2, tag3)
+	return tags
+}
+
+var _ action.Tx = unstakeTx{}
+
+type unstakeTx struct {
+}
+
+func (unstakeTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	unstake := &Unstake{}
+	err := unstake.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), unstake.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	if unstake.StakeAddress.Err() != nil || unstake.ValidatorAddress.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	if !unstake.Amount.IsValid(ctx.Currencies) || unstake.Amount.Value.BigInt().Sign() <= 0 {
+		return false, action.ErrInvalidAmount
+	}
+
+	if unstake.Amount.Currency != "VT" {
+		return false, action.ErrInvalidAmount
+	}
+
+	return true, nil
+}
+
+func (u unstakeTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Unstake Transaction for CheckTx", tx)
+	return runUnstake(ctx, tx)
+}
+
+func (u unstakeTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing Unstake Transaction for DeliverTx", tx)
+	return runUnstake(ctx, tx)
+}
+
+func (unstakeTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runUnstake(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	unstake := &Unstake{}
+	err := unstake.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	validator, err := ctx.Validators.Get(unstake.ValidatorAddress)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	// only the staking account of the validator can take the stake out
+	if !validator.StakeAddress.Equal(unstake.StakeAddress) {
+		return false, action.Response{Log: "stake address doesn't match the validator: " + unstake.StakeAddress.String()}
+	}
+
+	if validator.Staking.BigInt().Cmp(unstake.Amount.Value.BigInt()) < 0 {
+		return false, action.Response{Log: "unstake more than staked: " + validator.Staking.String()}
+	}
+
+	// the power drops right away, while the funds wait for the unbonding period
+	err = ctx.Validators.HandleUnstake(identity.Unstake{
+		Address: unstake.ValidatorAddress,
+		Amount:  unstake.Amount.Value,
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = ctx.Unbondings.Add(identity.Unbonding{
+		Address:        unstake.StakeAddress,
+		Validator:      unstake.ValidatorAddress,
+		Amount:         unstake.Amount.Value,
+		MatureHeight:   ctx.Header.Height + ctx.Validators.GetOptions().UnbondingBlocks,
+		CreationHeight: ctx.Header.Height,
+	})
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(unstake.Tags(), "unstake")}
+}
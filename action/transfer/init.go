@@ -9,6 +9,7 @@ import (
 func init() {
 
 	serialize.RegisterConcrete(new(Send), "action_send")
+	serialize.RegisterConcrete(new(MultiSend), "action_msend")
 
 }
 
@@ -18,5 +19,10 @@ func EnableSend(r action.Router) error {
 	if err != nil {
 		return errors.Wrap(err, "sendTx")
 	}
+
+	err = r.AddHandler(action.MULTI_SEND, multiSendTx{})
+	if err != nil {
+		return errors.Wrap(err, "multiSendTx")
+	}
 	return nil
 }
@@ -0,0 +1,200 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+)
+
+var _ action.Msg = &MultiSend{}
+
+// Transfer is one side of a multi send, an address and the amount it pays or receives
+type Transfer struct {
+	Address action.Address `json:"address"`
+	Amount  action.Amount  `json:"amount"`
+}
+
+// MultiSend moves funds from one or more inputs to many outputs in one tx, the inputs must pay exactly what the
+// outputs receive in every currency
+type MultiSend struct {
+	Inputs  []Transfer `json:"inputs"`
+	Outputs []Transfer `json:"outputs"`
+}
+
+func (s MultiSend) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *MultiSend) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Signers are the distinct input addresses, in the order they first appear
+func (s MultiSend) Signers() []action.Address {
+	signers := make([]action.Address, 0, len(s.Inputs))
+	seen := make(map[string]bool)
+	for _, in := range s.Inputs {
+		if seen[in.Address.String()] {
+			continue
+		}
+		seen[in.Address.String()] = true
+		signers = append(signers, in.Address.Bytes())
+	}
+	return signers
+}
+
+func (s MultiSend) Type() action.Type {
+	return action.MULTI_SEND
+}
+
+func (s MultiSend) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tags = append(tags, kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(s.Type().String()),
+	})
+	for _, in := range s.Signers() {
+		tags = append(tags, kv.Pair{
+			Key:   []byte("tx.owner"),
+			Value: in.Bytes(),
+		})
+	}
+	for _, out := range s.Outputs {
+		tags = append(tags, kv.Pair{
+			Key:   []byte("tx.to"),
+			Value: out.Address.Bytes(),
+		})
+	}
+	return tags
+}
+
+// totals sums the transfers by currency
+func totals(transfers []Transfer) map[string]*big.Int {
+	sums := make(map[string]*big.Int)
+	for _, t := range transfers {
+		sum, ok := sums[t.Amount.Currency]
+		if !ok {
+			sum = big.NewInt(0)
+			sums[t.Amount.Currency] = sum
+		}
+		sum.Add(sum, t.Amount.Value.BigInt())
+	}
+	return sums
+}
+
+func (s MultiSend) validateTransfers(ctx *action.Context) error {
+	if len(s.Inputs) == 0 || len(s.Outputs) == 0 {
+		return errors.Wrap(action.ErrMissingData, "multi send needs inputs and outputs")
+	}
+	for _, list := range [][]Transfer{s.Inputs, s.Outputs} {
+		for _, t := range list {
+			if t.Address.Err() != nil {
+				return action.ErrInvalidAddress
+			}
+			if !t.Amount.IsValid(ctx.Currencies) || t.Amount.Value.BigInt().Sign() <= 0 {
+				return errors.Wrap(action.ErrInvalidAmount, t.Amount.String())
+			}
+		}
+	}
+
+	ins, outs := totals(s.Inputs), totals(s.Outputs)
+	if len(ins) != len(outs) {
+		return errors.Wrap(action.ErrInvalidAmount, "inputs and outputs are in different currencies")
+	}
+	for currency, in := range ins {
+		out, ok := outs[currency]
+		if !ok || in.Cmp(out) != 0 {
+			return errors.Wrapf(action.ErrInvalidAmount, "inputs and outputs don't match in %s", currency)
+		}
+	}
+	return nil
+}
+
+var _ action.Tx = multiSendTx{}
+
+type multiSendTx struct {
+}
+
+func (multiSendTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	send := &MultiSend{}
+	err := send.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), send.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	err = send.validateTransfers(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s multiSendTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (ok bool, result action.Response) {
+	ctx.Logger.Debug("Processing MultiSend Transaction for CheckTx", tx)
+	ok, result = runMultiSend(ctx, tx)
+	return
+}
+
+func (s multiSendTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (ok bool, result action.Response) {
+	ctx.Logger.Debug("Processing MultiSend Transaction for DeliverTx", tx)
+	ok, result = runMultiSend(ctx, tx)
+	return
+}
+
+// ProcessFee charges the signature gas once per signature of the tx, the balance writes of every output are
+// charged as storage gas by the state
+func (multiSendTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, action.Gas(len(signedTx.Signatures)))
+}
+
+// runMultiSend applies all the transfers, the tx session is discarded by the caller if any of them fails so either
+// all of them apply or none
+func runMultiSend(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	balances := ctx.Balances
+
+	send := &MultiSend{}
+	err := send.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	err = send.validateTransfers(ctx)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	for _, in := range send.Inputs {
+		err = balances.MinusFromAddress(in.Address.Bytes(), in.Amount.ToCoin(ctx.Currencies))
+		if err != nil {
+			log := fmt.Sprint("error debiting balance in multi send transaction ", in.Address, "err", err)
+			return false, action.Response{Log: log}
+		}
+	}
+
+	for _, out := range send.Outputs {
+		err = balances.AddToAddress(out.Address.Bytes(), out.Amount.ToCoin(ctx.Currencies))
+		if err != nil {
+			log := fmt.Sprint("error crediting balance in multi send transaction ", out.Address, "err", err)
+			return false, action.Response{Log: log}
+		}
+	}
+
+	return true, action.Response{Events: action.GetEvent(send.Tags(), "multi_send_tx")}
+}
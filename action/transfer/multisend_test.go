@@ -0,0 +1,83 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/log"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func olt(value int64) action.Amount {
+	return action.Amount{Currency: "OLT", Value: *balance.NewAmount(value)}
+}
+
+func TestMultiSend(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("multisend", db.NewDB("test", db.MemDBBackend, "")))
+	currencies := balance.NewCurrencySet()
+	currency := balance.Currency{Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18}
+	assert.NoError(t, currencies.Register(currency))
+
+	ctx := &action.Context{
+		Balances:   balance.NewStore("b", cs),
+		Currencies: currencies,
+		Logger:     new(log.Logger),
+	}
+
+	alice := keys.Address([]byte("alice-address-000000"))
+	bob := keys.Address([]byte("bob-address-00000000"))
+	carol := keys.Address([]byte("carol-address-000000"))
+	dave := keys.Address([]byte("dave-address-0000000"))
+	assert.NoError(t, ctx.Balances.AddToAddress(alice, currency.NewCoinFromAmount(*balance.NewAmount(100))))
+	assert.NoError(t, ctx.Balances.AddToAddress(bob, currency.NewCoinFromAmount(*balance.NewAmount(50))))
+	cs.Commit()
+
+	send := MultiSend{
+		Inputs:  []Transfer{{alice, olt(60)}, {bob, olt(40)}, {alice, olt(10)}},
+		Outputs: []Transfer{{carol, olt(30)}, {dave, olt(40)}},
+	}
+	assert.Len(t, send.Signers(), 2)
+	assert.Error(t, send.validateTransfers(ctx))
+
+	send.Outputs = append(send.Outputs, Transfer{carol, olt(40)})
+	assert.NoError(t, send.validateTransfers(ctx))
+
+	data, err := send.Marshal()
+	assert.NoError(t, err)
+	ok, resp := runMultiSend(ctx, action.RawTx{Type: action.MULTI_SEND, Data: data})
+	assert.True(t, ok, resp.Log)
+	cs.Commit()
+
+	expected := []struct {
+		addr   keys.Address
+		amount int64
+	}{{alice, 30}, {bob, 10}, {carol, 70}, {dave, 40}}
+	for _, e := range expected {
+		coin, err := ctx.Balances.GetBalanceForCurr(e.addr, &currency)
+		assert.NoError(t, err)
+		assert.EqualValues(t, e.amount, coin.Amount.BigInt().Int64())
+	}
+
+	// bob can't pay 20 more, nothing is applied once the session is discarded
+	send = MultiSend{
+		Inputs:  []Transfer{{alice, olt(10)}, {bob, olt(20)}},
+		Outputs: []Transfer{{dave, olt(30)}},
+	}
+	data, err = send.Marshal()
+	assert.NoError(t, err)
+	cs.BeginTxSession()
+	ok, _ = runMultiSend(ctx, action.RawTx{Type: action.MULTI_SEND, Data: data})
+	assert.False(t, ok)
+	cs.DiscardTxSession()
+	cs.Commit()
+
+	coin, err := ctx.Balances.GetBalanceForCurr(alice, &currency)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 30, coin.Amount.BigInt().Int64())
+}
@@ -66,7 +66,7 @@ func (sendTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
 	}
 
 	//validate basic signature
-	err = action.ValidateBasic(tx.RawBytes(), send.Signers(), tx.Signatures)
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), send.Signers(), tx.Signatures)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,162 @@
+package vesting
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+)
+
+var _ action.Msg = &CreateVesting{}
+
+// CreateVesting sends the amount to the receiver locked under a vesting schedule, see balance.Vesting
+type CreateVesting struct {
+	From        action.Address `json:"from"`
+	To          action.Address `json:"to"`
+	Amount      action.Amount  `json:"amount"`
+	StartHeight int64          `json:"startHeight"`
+	CliffHeight int64          `json:"cliffHeight"`
+	EndHeight   int64          `json:"endHeight"`
+}
+
+func (c CreateVesting) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *CreateVesting) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+func (c CreateVesting) Signers() []action.Address {
+	return []action.Address{c.From.Bytes()}
+}
+
+func (c CreateVesting) Type() action.Type {
+	return action.CREATE_VESTING
+}
+
+func (c CreateVesting) Tags() kv.Pairs {
+	tags := make([]kv.Pair, 0)
+
+	tag := kv.Pair{
+		Key:   []byte("tx.type"),
+		Value: []byte(c.Type().String()),
+	}
+	tag2 := kv.Pair{
+		Key:   []byte("tx.owner"),
+		Value: c.From.Bytes(),
+	}
+	tag3 := kv.Pair{
+		Key:   []byte("tx.to"),
+		Value: c.To.Bytes(),
+	}
+	tag4 := kv.Pair{
+		Key:   []byte("tx.end_height"),
+		Value: []byte(strconv.FormatInt(c.EndHeight, 10)),
+	}
+
+	tags = append(tags, tag, tag2, tag3, tag4)
+	return tags
+}
+
+func (c CreateVesting) vesting() balance.Vesting {
+	return balance.Vesting{
+		Address:     c.To,
+		Currency:    c.Amount.Currency,
+		Amount:      c.Amount.Value,
+		StartHeight: c.StartHeight,
+		CliffHeight: c.CliffHeight,
+		EndHeight:   c.EndHeight,
+	}
+}
+
+var _ action.Tx = createVestingTx{}
+
+type createVestingTx struct {
+}
+
+func (createVestingTx) Validate(ctx *action.Context, tx action.SignedTx) (bool, error) {
+	create := &CreateVesting{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, errors.Wrap(action.ErrWrongTxType, err.Error())
+	}
+
+	//validate basic signature
+	err = action.ValidateSignatures(ctx, tx.RawBytes(), create.Signers(), tx.Signatures)
+	if err != nil {
+		return false, err
+	}
+
+	err = action.ValidateFee(ctx.FeePool.GetOpt(), tx.Fee)
+	if err != nil {
+		return false, err
+	}
+
+	//validate transaction specific field
+	if !create.Amount.IsValid(ctx.Currencies) {
+		return false, errors.Wrap(action.ErrInvalidAmount, create.Amount.String())
+	}
+
+	if create.From.Err() != nil || create.To.Err() != nil {
+		return false, action.ErrInvalidAddress
+	}
+
+	err = create.vesting().Validate()
+	if err != nil {
+		return false, errors.Wrap(action.ErrMissingData, err.Error())
+	}
+	return true, nil
+}
+
+func (createVestingTx) ProcessCheck(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CreateVesting Transaction for CheckTx", tx)
+	return runCreateVesting(ctx, tx)
+}
+
+func (createVestingTx) ProcessDeliver(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	ctx.Logger.Debug("Processing CreateVesting Transaction for DeliverTx", tx)
+	return runCreateVesting(ctx, tx)
+}
+
+func (createVestingTx) ProcessFee(ctx *action.Context, signedTx action.SignedTx, start action.Gas, size action.Gas) (bool, action.Response) {
+	return action.BasicFeeHandling(ctx, signedTx, start, size, 1)
+}
+
+func runCreateVesting(ctx *action.Context, tx action.RawTx) (bool, action.Response) {
+	create := &CreateVesting{}
+	err := create.Unmarshal(tx.Data)
+	if err != nil {
+		return false, action.Response{Log: err.Error()}
+	}
+
+	if create.EndHeight <= ctx.Header.Height {
+		return false, action.Response{Log: fmt.Sprintf("vesting already ended at height %d", create.EndHeight)}
+	}
+
+	coin := create.Amount.ToCoin(ctx.Currencies)
+
+	err = ctx.Balances.MinusFromAddress(create.From.Bytes(), coin)
+	if err != nil {
+		log := fmt.Sprint("error debiting balance in create vesting transaction ", create.From, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	err = ctx.Balances.AddToAddress(create.To.Bytes(), coin)
+	if err != nil {
+		log := fmt.Sprint("error crediting balance in create vesting transaction ", create.To, "err", err)
+		return false, action.Response{Log: log}
+	}
+
+	err = ctx.Balances.AddVesting(create.vesting())
+	if err != nil {
+		return false, action.Response{Log: errors.Wrap(err, create.To.String()).Error()}
+	}
+
+	return true, action.Response{Events: action.GetEvent(create.Tags(), "create_vesting")}
+}
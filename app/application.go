@@ -18,6 +18,7 @@ import (
 	"github.com/Oneledger/protocol/data/bitcoin"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/ethereum"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/event"
 	"github.com/Oneledger/protocol/identity"
@@ -131,6 +132,21 @@ func (app *App) setupState(stateBytes []byte) error {
 	if err != nil {
 		return errors.Wrap(err, "Error in setting up ONS options")
 	}
+
+	err = app.Context.govern.SetProposalOptions(initial.Governance.PropOptions)
+	if err != nil {
+		return errors.Wrap(err, "Error in setting up proposal options")
+	}
+
+	err = app.Context.govern.SetStakingOptions(initial.Governance.StakingOptions)
+	if err != nil {
+		return errors.Wrap(err, "Error in setting up staking options")
+	}
+
+	err = app.Context.govern.SetIssuanceOptions(initial.Governance.IssuanceOptions)
+	if err != nil {
+		return errors.Wrap(err, "Error in setting up issuance options")
+	}
 	// (1) Register all the currencies and fee
 	for _, currency := range initial.Currencies {
 		err := balanceCtx.Currencies().Register(currency)
@@ -145,10 +161,27 @@ func (app *App) setupState(stateBytes []byte) error {
 	}
 	app.Context.feePool.SetupOpt(&initial.Governance.FeeOption)
 	app.Context.domains.SetOptions(&initial.Governance.ONSOptions)
+	app.Context.proposalMaster.Proposal.SetOptions(&initial.Governance.PropOptions)
+	app.Context.validators.SetOptions(&initial.Governance.StakingOptions)
+	app.Context.supplies.SetOptions(&initial.Governance.IssuanceOptions)
 
 	app.Context.btcTrackers.SetConfig(bitcoin.NewBTCConfig(app.Context.cfg.ChainDriver, initial.Governance.BTCCDOption.ChainType))
 	app.Context.btcTrackers.SetOption(initial.Governance.BTCCDOption)
 
+	// currencies registered by the issuer are only known to the supply store
+	for _, supply := range initial.Supplies {
+		err := app.Context.supplies.WithState(app.Context.deliver).Set(supply)
+		if err != nil {
+			return errors.Wrap(err, "failed to set initial supply")
+		}
+		if _, ok := balanceCtx.Currencies().GetCurrencyByName(supply.Currency.Name); !ok && supply.Mintable {
+			err = balanceCtx.Currencies().Register(supply.Currency)
+			if err != nil {
+				return errors.Wrapf(err, "failed to register currency %s", supply.Currency.Name)
+			}
+		}
+	}
+
 	// (2) Set balances to all those mentioned
 	for _, bal := range initial.Balances {
 		key := storage.StoreKey(bal.Address)
@@ -161,6 +194,25 @@ func (app *App) setupState(stateBytes []byte) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to set balance")
 		}
+		for _, vesting := range bal.Vestings {
+			err = balanceCtx.Store().WithState(app.Context.deliver).AddVesting(balance.Vesting{
+				Address:     bal.Address,
+				Currency:    bal.Currency,
+				Amount:      vesting.Amount,
+				StartHeight: vesting.StartHeight,
+				CliffHeight: vesting.CliffHeight,
+				EndHeight:   vesting.EndHeight,
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to set vesting")
+			}
+		}
+		if len(initial.Supplies) == 0 {
+			err = app.Context.supplies.WithState(app.Context.deliver).Add(c, bal.Amount)
+			if err != nil {
+				return errors.Wrap(err, "failed to set supply")
+			}
+		}
 	}
 
 	for _, stake := range initial.Staking {
@@ -174,6 +226,50 @@ func (app *App) setupState(stateBytes []byte) error {
 		}
 	}
 
+	// delegations are added once the validators they back are set up
+	for _, delegation := range initial.Delegations {
+		err := app.Context.delegations.WithState(app.Context.deliver).Set(identity.Delegation(delegation))
+		if err != nil {
+			return errors.Wrap(err, "failed to set initial delegation")
+		}
+		err = app.Context.validators.WithState(app.Context.deliver).HandleDelegation(delegation.Validator, delegation.Amount)
+		if err != nil {
+			return errors.Wrap(err, "failed to handle initial delegation")
+		}
+	}
+
+	for _, unbonding := range initial.Unbondings {
+		err := app.Context.unbondings.WithState(app.Context.deliver).Add(identity.Unbonding(unbonding))
+		if err != nil {
+			return errors.Wrap(err, "failed to set initial unbonding")
+		}
+	}
+
+	for _, account := range initial.MultiSigAccounts {
+		err := account.Validate()
+		if err != nil {
+			return errors.Wrap(err, "invalid initial multisig account")
+		}
+		err = app.Context.multiSig.WithState(app.Context.deliver).Set(account)
+		if err != nil {
+			return errors.Wrap(err, "failed to set initial multisig account")
+		}
+	}
+
+	for _, n := range initial.Nonces {
+		err := app.Context.nonces.WithState(app.Context.deliver).Set(n.Address, n.Nonce)
+		if err != nil {
+			return errors.Wrap(err, "failed to set initial nonce")
+		}
+	}
+
+	for _, grant := range initial.FeeGrants {
+		err := app.Context.feeGrants.WithState(app.Context.deliver).Set(grant)
+		if err != nil {
+			return errors.Wrap(err, "failed to set initial fee grant")
+		}
+	}
+
 	for _, domain := range initial.Domains {
 		if ons.GetNameFromString(domain.Name).IsValid() {
 			d, err := ons.NewDomain(domain.Owner, domain.Beneficiary, domain.Name, 0, domain.URI, domain.ExpireHeight)
@@ -335,6 +431,38 @@ func (app *App) Prepare() error {
 		}
 		app.Context.domains.SetOptions(onsOpt)
 
+		// domains stored before the expiry index existed are never released otherwise
+		indexed, err := app.Context.domains.WithState(app.Context.deliver).IndexExpiries()
+		if err != nil {
+			return err
+		}
+		if indexed > 0 {
+			app.logger.Info("indexed the expiry of existing domains", "count", indexed)
+		}
+
+		propOpt, err := app.Context.govern.GetProposalOptions()
+		if err != nil {
+			return err
+		}
+		app.Context.proposalMaster.Proposal.SetOptions(propOpt)
+
+		stakingOpt, err := app.Context.govern.GetStakingOptions()
+		if err != nil {
+			return err
+		}
+		app.Context.validators.SetOptions(stakingOpt)
+
+		issueOpt, err := app.Context.govern.GetIssuanceOptions()
+		if err != nil {
+			return err
+		}
+		app.Context.supplies.SetOptions(issueOpt)
+
+		err = app.syncCurrencies()
+		if err != nil {
+			return err
+		}
+
 		cdOpt, err := app.Context.govern.GetETHChainDriverOption()
 		if err != nil {
 			return err
@@ -373,7 +501,8 @@ func (app *App) Prepare() error {
 		return err
 	}
 	app.Context.internalService = event.NewService(app.Context.node,
-		log.NewLoggerWithPrefix(app.Context.logWriter, "internal_service"), internalRouter, app.node)
+		log.NewLoggerWithPrefix(app.Context.logWriter, "internal_service"), internalRouter, app.node,
+		nonce.NewStore("nonce", storage.NewState(app.Context.chainstate)))
 
 	return nil
 }
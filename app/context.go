@@ -10,10 +10,15 @@ import (
 	db "github.com/tendermint/tm-db"
 
 	"github.com/Oneledger/protocol/action"
+	action_currency "github.com/Oneledger/protocol/action/currency"
 	"github.com/Oneledger/protocol/action/eth"
+	"github.com/Oneledger/protocol/action/feegrant"
+	action_gov "github.com/Oneledger/protocol/action/governance"
+	action_msig "github.com/Oneledger/protocol/action/multisig"
 	action_ons "github.com/Oneledger/protocol/action/ons"
 	"github.com/Oneledger/protocol/action/staking"
 	"github.com/Oneledger/protocol/action/transfer"
+	"github.com/Oneledger/protocol/action/vesting"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/config"
@@ -24,7 +29,10 @@ import (
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/jobs"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/txindex"
 	"github.com/Oneledger/protocol/event"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
@@ -48,21 +56,30 @@ type context struct {
 	check      *storage.State
 	deliver    *storage.State
 
-	balances    *balance.Store
-	domains     *ons.DomainStore
-	validators  *identity.ValidatorStore // Set of validators currently active
-	witnesses   *identity.WitnessStore   // Set of witnesses currently active
-	feePool     *fees.Store
-	govern      *governance.Store
-	btcTrackers *bitcoin.TrackerStore  // tracker for bitcoin balance UTXO
-	ethTrackers *ethereum.TrackerStore // Tracker store for ongoing ethereum trackers
-	currencies  *balance.CurrencySet
+	balances       *balance.Store
+	domains        *ons.DomainStore
+	validators     *identity.ValidatorStore // Set of validators currently active
+	witnesses      *identity.WitnessStore   // Set of witnesses currently active
+	feePool        *fees.Store
+	govern         *governance.Store
+	proposalMaster *governance.ProposalMasterStore
+	delegations    *identity.DelegationStore
+	unbondings     *identity.UnbondingStore
+	multiSig       *multisig.Store
+	nonces         *nonce.Store
+	pendingNonces  *nonce.Pending
+	feeGrants      *fees.GrantStore
+	supplies       *balance.SupplyStore
+	btcTrackers    *bitcoin.TrackerStore  // tracker for bitcoin balance UTXO
+	ethTrackers    *ethereum.TrackerStore // Tracker store for ongoing ethereum trackers
+	currencies     *balance.CurrencySet
 
 	//storage which is not a chain state
 	accounts accounts.Wallet
 
 	jobStore        *jobs.JobStore
 	lockScriptStore *bitcoin.LockScriptStore
+	txIndex         *txindex.Store
 	internalService *event.Service
 	jobBus          *event.JobBus
 
@@ -93,11 +110,19 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 	ctx.check = storage.NewState(ctx.chainstate)
 
 	ctx.validators = identity.NewValidatorStore("v", storage.NewState(ctx.chainstate))
+	ctx.delegations = identity.NewDelegationStore("dl", storage.NewState(ctx.chainstate))
+	ctx.unbondings = identity.NewUnbondingStore("ub", storage.NewState(ctx.chainstate))
+	ctx.multiSig = multisig.NewStore("msig", storage.NewState(ctx.chainstate))
+	ctx.nonces = nonce.NewStore("nonce", storage.NewState(ctx.chainstate))
+	ctx.pendingNonces = nonce.NewPending()
 	ctx.witnesses = identity.NewWitnessStore("w", storage.NewState(ctx.chainstate))
 	ctx.balances = balance.NewStore("b", storage.NewState(ctx.chainstate))
 	ctx.domains = ons.NewDomainStore("d", storage.NewState(ctx.chainstate))
 	ctx.feePool = fees.NewStore("f", storage.NewState(ctx.chainstate))
+	ctx.feeGrants = fees.NewGrantStore("fg", storage.NewState(ctx.chainstate))
+	ctx.supplies = balance.NewSupplyStore("supply", storage.NewState(ctx.chainstate))
 	ctx.govern = governance.NewStore("g", storage.NewState(ctx.chainstate))
+	ctx.proposalMaster = newProposalMasterStore(ctx.chainstate)
 
 	ctx.btcTrackers = bitcoin.NewTrackerStore("btct", storage.NewState(ctx.chainstate))
 
@@ -109,6 +134,7 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 
 	ctx.jobStore = jobs.NewJobStore(cfg, ctx.dbDir())
 	ctx.lockScriptStore = bitcoin.NewLockScriptStore(cfg, ctx.dbDir())
+	ctx.txIndex = txindex.NewStore(cfg, ctx.dbDir())
 
 	ctx.actionRouter = action.NewRouter("action")
 
@@ -127,14 +153,27 @@ func newContext(logWriter io.Writer, cfg config.Server, nodeCtx *node.Context) (
 
 	_ = transfer.EnableSend(ctx.actionRouter)
 	_ = staking.EnableApplyValidator(ctx.actionRouter)
+	_ = staking.EnableDelegation(ctx.actionRouter)
 	_ = action_ons.EnableONS(ctx.actionRouter)
 	//"btc" service temporarily disabled
 	//_ = btc.EnableBTC(ctx.actionRouter)
 	_ = eth.EnableETH(ctx.actionRouter)
+	_ = action_gov.EnableGovernance(ctx.actionRouter)
+	_ = action_msig.EnableMultiSig(ctx.actionRouter)
+	_ = feegrant.EnableFeeGrant(ctx.actionRouter)
+	_ = vesting.EnableVesting(ctx.actionRouter)
+	_ = action_currency.EnableCurrency(ctx.actionRouter)
 
 	return ctx, nil
 }
 
+func newProposalMasterStore(chainstate *storage.ChainState) *governance.ProposalMasterStore {
+	proposals := governance.NewProposalStore("propActive", "propPassed", "propFailed", storage.NewState(chainstate))
+	proposalFunds := governance.NewProposalFundStore("propFunds", storage.NewState(chainstate))
+	proposalVotes := governance.NewProposalVoteStore("propVotes", storage.NewState(chainstate))
+	return governance.NewProposalMasterStore(proposals, proposalFunds, proposalVotes)
+}
+
 func (ctx context) dbDir() string {
 	return filepath.Join(ctx.cfg.RootDir(), ctx.cfg.Node.DBDir)
 }
@@ -157,6 +196,13 @@ func (ctx *context) Action(header *Header, state *storage.State) *action.Context
 		ctx.ethTrackers.WithState(state),
 		ctx.jobStore,
 		ctx.lockScriptStore,
+		ctx.proposalMaster.WithState(state),
+		ctx.delegations.WithState(state),
+		ctx.unbondings.WithState(state),
+		ctx.multiSig.WithState(state),
+		ctx.nonces.WithState(state),
+		ctx.feeGrants.WithState(state),
+		ctx.supplies.WithState(state),
 		log.NewLoggerWithPrefix(ctx.logWriter, "action").WithLevel(log.Level(ctx.cfg.Node.LogLevel)),
 	)
 
@@ -172,6 +218,10 @@ func (ctx *context) ValidatorCtx() *identity.ValidatorContext {
 	return identity.NewValidatorContext(
 		ctx.balances.WithState(ctx.deliver),
 		ctx.feePool.WithState(ctx.deliver),
+		ctx.delegations.WithState(ctx.deliver),
+		ctx.currencies,
+		ctx.supplies.WithState(ctx.deliver),
+		ctx.unbondings.WithState(ctx.deliver),
 	)
 }
 
@@ -191,6 +241,7 @@ func (ctx *context) Services() (service.Map, error) {
 
 	btcTrackers := bitcoin.NewTrackerStore("btct", storage.NewState(ctx.chainstate))
 	btcTrackers.SetConfig(ctx.btcTrackers.GetConfig())
+	btcTrackers.SetOption(ctx.btcTrackers.GetOption())
 
 	feePool := fees.NewStore("f", storage.NewState(ctx.chainstate))
 	feePool.SetupOpt(ctx.feePool.GetOpt())
@@ -216,6 +267,12 @@ func (ctx *context) Services() (service.Map, error) {
 		Services:     extSvcs,
 		EthTrackers:  ethTracker,
 		Trackers:     btcTrackers,
+		MultiSig:     multisig.NewStore("msig", storage.NewState(ctx.chainstate)),
+		Nonces:       nonce.NewStore("nonce", storage.NewState(ctx.chainstate)).WithPending(ctx.pendingNonces),
+		Unbondings:   identity.NewUnbondingStore("ub", storage.NewState(ctx.chainstate)),
+		ProposalFund: governance.NewProposalFundStore("propFunds", storage.NewState(ctx.chainstate)),
+		Supplies:     balance.NewSupplyStore("supply", storage.NewState(ctx.chainstate)),
+		TxIndex:      ctx.txIndex,
 	}
 
 	return service.NewMap(svcCtx)
@@ -238,6 +295,8 @@ func (ctx *context) Restful() (service.RestfulRouter, error) {
 		Router:       ctx.actionRouter,
 		Logger:       log.NewLoggerWithPrefix(ctx.logWriter, "restful").WithLevel(log.Level(ctx.cfg.Node.LogLevel)),
 		Services:     extSvcs,
+		MultiSig:     ctx.multiSig,
+		Nonces:       ctx.nonces,
 
 		Trackers: ctx.btcTrackers,
 	}
@@ -245,12 +304,18 @@ func (ctx *context) Restful() (service.RestfulRouter, error) {
 }
 
 type StorageCtx struct {
-	Balances   *balance.Store
-	Domains    *ons.DomainStore
-	Validators *identity.ValidatorStore // Set of validators currently active
-	FeePool    *fees.Store
-	Govern     *governance.Store
-	Trackers   *ethereum.TrackerStore //TODO: Create struct to contain all tracker types including Bitcoin.
+	Balances    *balance.Store
+	Domains     *ons.DomainStore
+	Validators  *identity.ValidatorStore // Set of validators currently active
+	Delegations *identity.DelegationStore
+	Unbondings  *identity.UnbondingStore
+	MultiSig    *multisig.Store
+	Nonces      *nonce.Store
+	FeeGrants   *fees.GrantStore
+	Supplies    *balance.SupplyStore
+	FeePool     *fees.Store
+	Govern      *governance.Store
+	Trackers    *ethereum.TrackerStore //TODO: Create struct to contain all tracker types including Bitcoin.
 
 	Currencies *balance.CurrencySet
 	FeeOption  *fees.FeeOption
@@ -261,17 +326,23 @@ type StorageCtx struct {
 
 func (ctx *context) Storage() StorageCtx {
 	return StorageCtx{
-		Version:    ctx.chainstate.Version,
-		Hash:       ctx.chainstate.Hash,
-		Chainstate: ctx.chainstate,
-		Balances:   ctx.balances,
-		Domains:    ctx.domains,
-		Validators: ctx.validators,
-		FeePool:    ctx.feePool,
-		Govern:     ctx.govern,
-		Currencies: ctx.currencies,
-		FeeOption:  ctx.feePool.GetOpt(),
-		Trackers:   ctx.ethTrackers,
+		Version:     ctx.chainstate.Version,
+		Hash:        ctx.chainstate.Hash,
+		Chainstate:  ctx.chainstate,
+		Balances:    ctx.balances,
+		Domains:     ctx.domains,
+		Validators:  ctx.validators,
+		Delegations: ctx.delegations,
+		Unbondings:  ctx.unbondings,
+		MultiSig:    ctx.multiSig,
+		Nonces:      ctx.nonces,
+		FeeGrants:   ctx.feeGrants,
+		Supplies:    ctx.supplies,
+		FeePool:     ctx.feePool,
+		Govern:      ctx.govern,
+		Currencies:  ctx.currencies,
+		FeeOption:   ctx.feePool.GetOpt(),
+		Trackers:    ctx.ethTrackers,
 	}
 }
 
@@ -13,12 +13,16 @@ import (
 	"github.com/Oneledger/protocol/action"
 	ceth "github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/jobs"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/txindex"
 	"github.com/Oneledger/protocol/event"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
@@ -49,14 +53,6 @@ func (app *App) infoServer() infoServer {
 	}
 }
 
-func (app *App) queryer() queryer {
-	return func(RequestQuery) ResponseQuery {
-		defer app.handlePanic()
-		// Do stuff
-		return ResponseQuery{}
-	}
-}
-
 func (app *App) optionSetter() optionSetter {
 	return func(req RequestSetOption) ResponseSetOption {
 		defer app.handlePanic()
@@ -112,6 +108,9 @@ func (app *App) blockBeginner() blockBeginner {
 		gc := getGasCalculator(app.genesisDoc.ConsensusParams)
 		app.Context.deliver = storage.NewState(app.Context.chainstate).WithGas(gc)
 
+		// slash the misbehaving validators before the validator set is set up for this block
+		doSlashing(app.Context.validators, app.Context.ValidatorCtx(), req, app.logger, app.Context.deliver)
+
 		// update the validator set
 		err := app.Context.validators.Setup(req, app.Context.node.ValidatorAddress())
 		if err != nil {
@@ -164,6 +163,26 @@ func (app *App) txChecker() txChecker {
 				Log:  err.Error(),
 			}
 		}
+
+		err = action.ValidateBaseFee(txCtx, tx.Fee)
+		if err != nil {
+			app.logger.Debug("Check Tx below base fee: ", err.Error())
+			return ResponseCheckTx{
+				Code: CodeNotOK.uint32(),
+				Log:  err.Error(),
+			}
+		}
+
+		err = action.ProcessNonce(txCtx, *tx)
+		if err != nil {
+			app.logger.Debug("Check Tx invalid nonce: ", err.Error())
+			app.Context.check.DiscardTxSession()
+			return ResponseCheckTx{
+				Code: CodeNotOK.uint32(),
+				Log:  err.Error(),
+			}
+		}
+
 		ok, response := handler.ProcessCheck(txCtx, tx.RawTx)
 
 		feeOk, feeResponse := handler.ProcessFee(txCtx, *tx, gas, storage.Gas(len(msg.Tx)))
@@ -183,6 +202,10 @@ func (app *App) txChecker() txChecker {
 			app.Context.check.DiscardTxSession()
 		} else {
 			app.Context.check.CommitTxSession()
+			// new txs of the signer are built past the ones waiting in the mempool
+			if addr, err := action.NonceAddress(txCtx, *tx); err == nil {
+				app.Context.pendingNonces.Set(addr, tx.Nonce+1)
+			}
 		}
 
 		app.logger.Detail("Check Tx: ", result, "log", response.Log)
@@ -218,6 +241,19 @@ func (app *App) txDeliverer() txDeliverer {
 
 		gas := txCtx.State.ConsumedGas()
 
+		err = action.ProcessNonce(txCtx, *tx)
+		if err != nil {
+			app.logger.Detail("Deliver Tx invalid nonce: ", err.Error())
+			app.Context.deliver.DiscardTxSession()
+			return ResponseDeliverTx{
+				Code: CodeNotOK.uint32(),
+				Log:  err.Error(),
+			}
+		}
+		// the nonce is used up even if the tx fails, so a failed tx can't be replayed
+		app.Context.deliver.CommitTxSession()
+		app.Context.deliver.BeginTxSession()
+
 		ok, response := handler.ProcessDeliver(txCtx, tx.RawTx)
 
 		feeOk, feeResponse := handler.ProcessFee(txCtx, *tx, gas, storage.Gas(len(msg.Tx)))
@@ -233,6 +269,7 @@ func (app *App) txDeliverer() txDeliverer {
 			Codespace: "",
 		}
 		app.logger.Detail("Deliver Tx: ", result)
+		indexTx(app.Context.txIndex, tx, msg.Tx, result, app.header.Height, app.logger)
 
 		if !(ok && feeOk) {
 			app.Context.deliver.DiscardTxSession()
@@ -248,16 +285,29 @@ func (app *App) blockEnder() blockEnder {
 	return func(req RequestEndBlock) ResponseEndBlock {
 		defer app.handlePanic()
 
+		// gas used by the txs of this block, before the end block work adds to it
+		gasUsed := app.Context.deliver.ConsumedGas()
+
 		fee, err := app.Context.feePool.WithState(app.Context.deliver).Get([]byte(fees.POOL_KEY))
 		app.logger.Detail("endblock fee", fee, err)
+		// burn and take the treasury share of the block fees before the validators get the rest
+		events := doFeeSplit(app.Context.feePool, app.Context.supplies, req.Height, app.logger, app.Context.deliver)
 		updates := app.Context.validators.GetEndBlockUpdate(app.Context.ValidatorCtx(), req)
 		result := ResponseEndBlock{
 			ValidatorUpdates: updates,
+			Events:           events,
 			//Tags:             []kv.Pair(nil),
 		}
 		ethTrackerlog := log.NewLoggerWithPrefix(app.Context.logWriter, "ethtracker").WithLevel(log.Level(app.Context.cfg.Node.LogLevel))
 		doTransitions(app.Context.jobStore, app.Context.btcTrackers.WithState(app.Context.deliver), app.Context.validators)
 		doEthTransitions(app.Context.jobStore, app.Context.ethTrackers, app.Context.node.ValidatorAddress(), ethTrackerlog, app.Context.witnesses, app.Context.deliver)
+		doProposalTransitions(app.Context.proposalMaster, app.Context.validators, app.Context.feePool, app.Context.govern, req.Height, app.logger, app.Context.deliver)
+		app.applyConfigUpdates(req.Height)
+		doUnbondings(app.Context.unbondings, app.Context.balances, app.Context.currencies, req.Height, app.logger, app.Context.deliver)
+		doAuctions(app.Context.domains, app.Context.balances, app.Context.feePool, app.Context.currencies, req.Height, app.logger, app.Context.deliver)
+		doOfferExpiry(app.Context.domains, app.Context.balances, app.Context.currencies, req.Height, app.logger, app.Context.deliver)
+		result.Events = append(result.Events, doDomainRelease(app.Context.domains, app.Context.balances, app.Context.currencies, req.Height, app.logger, app.Context.deliver)...)
+		doBaseFeeUpdate(app.Context.feePool, gasUsed, blockGasLimit(app.genesisDoc.ConsensusParams), app.logger, app.Context.deliver)
 
 		app.logger.Detail("End Block: ", result, "height:", req.Height)
 
@@ -272,9 +322,21 @@ func (app *App) commitor() commitor {
 		hash, ver := app.Context.deliver.Commit()
 		app.logger.Detailf("Committed New Block height[%d], hash[%s], versions[%d]", app.header.Height, hex.EncodeToString(hash), ver)
 
+		err := app.syncCurrencies()
+		if err != nil {
+			app.logger.Error("failed to sync registered currencies", "height", ver, "err", err)
+		}
+
+		err = app.Context.txIndex.Commit()
+		if err != nil {
+			app.logger.Error("failed to commit the tx index", "height", ver, "err", err)
+		}
+
 		// update check state by deliver state
 		gc := getGasCalculator(app.genesisDoc.ConsensusParams)
 		app.Context.check = storage.NewState(app.Context.chainstate).WithGas(gc)
+		// the mempool recheck records the nonces of the txs still waiting
+		app.Context.pendingNonces.Reset()
 		result := ResponseCommit{
 			Data: hash,
 		}
@@ -307,17 +369,18 @@ func (app *App) handlePanic() {
 }
 
 func getGasCalculator(params *types.ConsensusParams) storage.GasCalculator {
+	return storage.NewGasCalculator(blockGasLimit(params))
+}
+
+func blockGasLimit(params *types.ConsensusParams) storage.Gas {
 	limit := int64(0)
 	if params != nil {
 		limit = params.Block.MaxGas
 	}
-	gas := storage.Gas(0)
 	if limit < 0 {
-		gas = math.MaxInt64
-	} else {
-		gas = storage.Gas(limit)
+		return math.MaxInt64
 	}
-	return storage.NewGasCalculator(gas)
+	return storage.Gas(limit)
 }
 
 func doTransitions(js *jobs.JobStore, ts *bitcoin.TrackerStore, validators *identity.ValidatorStore) {
@@ -398,6 +461,460 @@ func doEthTransitions(js *jobs.JobStore, ts *ethereum.TrackerStore, myValAddr ke
 
 }
 
+// doUnbondings pays back the unstaked funds whose unbonding period is over
+func doUnbondings(us *identity.UnbondingStore, balances *balance.Store, currencies *balance.CurrencySet, height int64,
+	logger *log.Logger, deliver *storage.State) {
+
+	currency, ok := currencies.GetCurrencyByName("VT")
+	if !ok {
+		logger.Error("stake token not registered")
+		return
+	}
+
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	err := us.WithState(deliver).Release(height, balances.WithState(deliver), currency)
+	if err != nil {
+		logger.Error("failed to release unbonded stake", "height", height, "err", err)
+		deliver.DiscardTxSession()
+		return
+	}
+	deliver.CommitTxSession()
+}
+
+// doAuctions settles the domain auctions whose reveal window ends in the block, refunding the losing bids
+func doAuctions(domains *ons.DomainStore, balances *balance.Store, feePool *fees.Store, currencies *balance.CurrencySet,
+	height int64, logger *log.Logger, deliver *storage.State) {
+
+	opt := domains.GetOptions()
+	currency, ok := currencies.GetCurrencyByName(opt.Currency)
+	if !ok {
+		logger.Error("domain currency not registered", opt.Currency)
+		return
+	}
+
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	err := domains.WithState(deliver).SettleAuctions(height, balances.WithState(deliver), feePool.WithState(deliver), currency)
+	if err != nil {
+		logger.Error("failed to settle domain auctions", "height", height, "err", err)
+		deliver.DiscardTxSession()
+		return
+	}
+	deliver.CommitTxSession()
+}
+
+// doOfferExpiry refunds the domain offers expiring in the block
+func doOfferExpiry(domains *ons.DomainStore, balances *balance.Store, currencies *balance.CurrencySet, height int64,
+	logger *log.Logger, deliver *storage.State) {
+
+	opt := domains.GetOptions()
+	currency, ok := currencies.GetCurrencyByName(opt.Currency)
+	if !ok {
+		logger.Error("domain currency not registered", opt.Currency)
+		return
+	}
+
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	err := domains.WithState(deliver).ExpireOffers(height, balances.WithState(deliver), currency)
+	if err != nil {
+		logger.Error("failed to refund expired domain offers", "height", height, "err", err)
+		deliver.DiscardTxSession()
+		return
+	}
+	deliver.CommitTxSession()
+}
+
+// doDomainRelease releases the domains whose grace period is over and returns an event for each of them and each of
+// their subdomains
+func doDomainRelease(domains *ons.DomainStore, balances *balance.Store, currencies *balance.CurrencySet, height int64,
+	logger *log.Logger, deliver *storage.State) []Event {
+
+	opt := domains.GetOptions()
+	currency, ok := currencies.GetCurrencyByName(opt.Currency)
+	if !ok {
+		logger.Error("domain currency not registered", opt.Currency)
+		return nil
+	}
+
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	released, err := domains.WithState(deliver).ReleaseExpired(height, balances.WithState(deliver), currency)
+	if err != nil {
+		logger.Error("failed to release expired domains", "height", height, "err", err)
+		deliver.DiscardTxSession()
+		return nil
+	}
+	deliver.CommitTxSession()
+
+	events := make([]Event, 0, len(released))
+	for _, d := range released {
+		events = append(events, action.GetEvent(d.ReleaseTags(height), "release_domain")...)
+	}
+	return events
+}
+
+// doFeeSplit shares out the fees collected in the block between burning, the treasury and the validators, and
+// returns the split as events of the block. The burnt fees leave the supply of the fee currency.
+func doFeeSplit(feePool *fees.Store, supplies *balance.SupplyStore, height int64, logger *log.Logger,
+	deliver *storage.State) []Event {
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	split, err := feePool.WithState(deliver).SplitBlockFees()
+	if err != nil {
+		logger.Error("failed to split block fees", "height", height, "err", err)
+		deliver.DiscardTxSession()
+		return nil
+	}
+	err = supplies.WithState(deliver).Remove(feePool.GetOpt().FeeCurrency, split.Burnt)
+	if err != nil {
+		logger.Error("failed to remove burnt fees from the supply", "height", height, "err", err)
+		deliver.DiscardTxSession()
+		return nil
+	}
+	deliver.CommitTxSession()
+	return action.GetEvent(split.Tags(height), "fee_split")
+}
+
+// indexTx adds a delivered tx to the local tx index, under its signers and the addresses in the tags of its events
+func indexTx(txIndex *txindex.Store, tx *action.SignedTx, raw []byte, result ResponseDeliverTx, height int64,
+	logger *log.Logger) {
+	addrs := make([]keys.Address, 0)
+	for _, sig := range tx.Signatures {
+		h, err := sig.Signer.GetHandler()
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, h.Address())
+	}
+	for _, event := range result.Events {
+		for _, attr := range event.Attributes {
+			for _, tag := range txindex.AddressTags {
+				if string(attr.Key) == tag {
+					addrs = append(addrs, attr.Value)
+				}
+			}
+		}
+	}
+
+	record := txindex.Record{
+		Hash:   utils.SHA2(raw),
+		Height: height,
+		Type:   tx.Type.String(),
+		Code:   result.Code,
+	}
+	err := txIndex.Add(record, addrs)
+	if err != nil {
+		logger.Error("failed to index tx", "hash", hex.EncodeToString(record.Hash), "err", err)
+	}
+}
+
+// doBaseFeeUpdate adjusts the base fee for the next block by how much gas this block used against its limit
+func doBaseFeeUpdate(feePool *fees.Store, used, limit storage.Gas, logger *log.Logger, deliver *storage.State) {
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	err := feePool.WithState(deliver).UpdateBaseFee(used, limit)
+	if err != nil {
+		logger.Error("failed to update base fee", "used", used, "limit", limit, "err", err)
+		deliver.DiscardTxSession()
+		return
+	}
+	deliver.CommitTxSession()
+}
+
+// doSlashing slashes and jails the validators with byzantine evidence or too many missed blocks
+func doSlashing(validators *identity.ValidatorStore, ctx *identity.ValidatorContext, req RequestBeginBlock,
+	logger *log.Logger, deliver *storage.State) {
+
+	// validators only leave consensus if their slashing is kept
+	validators.SetJailedInBlock(nil)
+
+	deliver.DiscardTxSession()
+	deliver.BeginTxSession()
+	jailed, err := validators.WithState(deliver).HandleSlashing(ctx, req)
+	if err != nil {
+		logger.Error("failed to slash validators", "height", req.Header.Height, "err", err)
+		deliver.DiscardTxSession()
+		return
+	}
+	deliver.CommitTxSession()
+	validators.SetJailedInBlock(jailed)
+}
+
+// doProposalTransitions moves active proposals from funding to voting, and settles the ones whose deadline passed
+// into the passed or failed store
+func doProposalTransitions(pms *governance.ProposalMasterStore, validators *identity.ValidatorStore, feePool *fees.Store,
+	govern *governance.Store, height int64, logger *log.Logger, deliver *storage.State) {
+
+	pms = pms.WithState(deliver)
+	validators = validators.WithState(deliver)
+	feePool = feePool.WithState(deliver)
+	govern = govern.WithState(deliver)
+
+	proposals := make([]*governance.Proposal, 0, 10)
+	pms.Proposal.WithPrefixType(governance.ProposalStateActive).Iterate(func(id governance.ProposalID, proposal *governance.Proposal) bool {
+		proposals = append(proposals, proposal)
+		return false
+	})
+
+	for _, proposal := range proposals {
+		deliver.DiscardTxSession()
+		deliver.BeginTxSession()
+
+		var err error
+		switch proposal.Status {
+		case governance.ProposalStatusFunding:
+			funds := pms.ProposalFund.GetCurrentFunds(proposal.ProposalID)
+			if funds.BigInt().Cmp(proposal.FundingGoal.BigInt()) >= 0 {
+				err = startVoting(pms, validators, proposal, height)
+			} else if height > proposal.FundingDeadline {
+				proposal.Status = governance.ProposalStatusCompleted
+				proposal.Outcome = governance.ProposalOutcomeInsufficientFunds
+				err = pms.Proposal.Transition(proposal, governance.ProposalStateActive, governance.ProposalStateFailed)
+			} else {
+				continue
+			}
+
+		case governance.ProposalStatusVoting:
+			if height <= proposal.VotingDeadline {
+				continue
+			}
+			err = finalizeVoting(pms, feePool, govern, proposal, height)
+
+		default:
+			continue
+		}
+
+		if err != nil {
+			logger.Error("failed to process proposal", proposal.ProposalID, err)
+			deliver.DiscardTxSession()
+			continue
+		}
+		deliver.CommitTxSession()
+	}
+}
+
+// startVoting records the voting power of the current validators and opens the proposal for voting. The voting
+// deadline is set from here, so a config update which would activate before the voting is over is cancelled and its
+// funds are left for the funders to withdraw.
+func startVoting(pms *governance.ProposalMasterStore, validators *identity.ValidatorStore, proposal *governance.Proposal,
+	height int64) error {
+
+	opt := pms.Proposal.GetOptions().GetOption(proposal.Type)
+	if opt == nil {
+		return errors.New("invalid proposal type")
+	}
+
+	votingDeadline := height + opt.VotingDeadline
+	if proposal.ConfigUpdate != nil && proposal.ConfigUpdate.ActivationHeight <= votingDeadline {
+		proposal.Status = governance.ProposalStatusCompleted
+		proposal.Outcome = governance.ProposalOutcomeCancelled
+		return pms.Proposal.Transition(proposal, governance.ProposalStateActive, governance.ProposalStateFailed)
+	}
+
+	var err error
+	validators.Iterate(func(addr keys.Address, validator *identity.Validator) bool {
+		if validator.Power <= 0 {
+			return false
+		}
+		err = pms.ProposalVote.Setup(proposal.ProposalID, governance.NewProposalVote(validator.Address, governance.OPIN_UNKNOWN, validator.Power))
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	proposal.Status = governance.ProposalStatusVoting
+	proposal.VotingDeadline = votingDeadline
+	return pms.Proposal.WithPrefixType(governance.ProposalStateActive).Set(proposal)
+}
+
+// finalizeVoting counts the votes of a proposal, a passed proposal has its funds moved to the fee pool while a failed
+// one keeps them for the funders to withdraw
+func finalizeVoting(pms *governance.ProposalMasterStore, feePool *fees.Store, govern *governance.Store,
+	proposal *governance.Proposal, height int64) error {
+	passed, err := pms.ProposalVote.IsPassed(proposal.ProposalID, proposal.PassPercentage)
+	if err != nil {
+		// no validator was recorded for this proposal
+		passed = false
+	}
+
+	proposal.Status = governance.ProposalStatusCompleted
+	if !passed {
+		proposal.Outcome = governance.ProposalOutcomeInsufficientVotes
+		return pms.Proposal.Transition(proposal, governance.ProposalStateActive, governance.ProposalStateFailed)
+	}
+
+	proposal.Outcome = governance.ProposalOutcomeCompleted
+	err = pms.Proposal.Transition(proposal, governance.ProposalStateActive, governance.ProposalStatePassed)
+	if err != nil {
+		return err
+	}
+
+	funds := pms.ProposalFund.GetCurrentFunds(proposal.ProposalID)
+	err = pms.ProposalFund.DeleteAllFunds(proposal.ProposalID)
+	if err != nil {
+		return err
+	}
+	coin := feePool.GetOpt().FeeCurrency.NewCoinFromAmount(*balance.NewAmountFromBigInt(funds.BigInt()))
+	err = feePool.AddToPool(coin)
+	if err != nil {
+		return err
+	}
+
+	if proposal.Type != governance.ProposalTypeConfigUpdate || proposal.ConfigUpdate == nil {
+		return nil
+	}
+
+	// startVoting keeps the activation height after the voting deadline, this only guards the schedule
+	activation := proposal.ConfigUpdate.ActivationHeight
+	if activation < height {
+		activation = height
+	}
+	return govern.ScheduleConfigUpdate(activation, *proposal.ConfigUpdate)
+}
+
+// applyConfigUpdates replaces the governance options with the ones from the config updates scheduled for this block
+func (app *App) applyConfigUpdates(height int64) {
+	govern := app.Context.govern.WithState(app.Context.deliver).WithHeight(height)
+
+	updates, err := govern.GetConfigUpdates(height)
+	if err != nil {
+		app.logger.Error("failed to get config updates", "height", height, "err", err)
+		return
+	}
+
+	for _, update := range updates {
+		// the options are checked again, they may no longer be valid since the proposal was created
+		err := update.Validate(app.Context.currencies)
+		if err != nil {
+			app.logger.Error("invalid config update", "height", height, "err", err)
+			continue
+		}
+
+		app.Context.deliver.DiscardTxSession()
+		app.Context.deliver.BeginTxSession()
+
+		err = app.applyConfigUpdate(govern, update)
+		if err != nil {
+			app.logger.Error("failed to apply config update", "height", height, "err", err)
+			app.Context.deliver.DiscardTxSession()
+			continue
+		}
+
+		// the power of every validator follows the new staking options, in the same session as the options
+		if update.StakingOptions != nil {
+			current := app.Context.validators.GetOptions()
+			app.Context.validators.SetOptions(update.StakingOptions)
+			err = app.Context.validators.WithState(app.Context.deliver).UpdatePowers()
+			if err != nil {
+				app.logger.Error("failed to update validator powers", "height", height, "err", err)
+				app.Context.validators.SetOptions(current)
+				app.Context.deliver.DiscardTxSession()
+				continue
+			}
+		}
+		app.Context.deliver.CommitTxSession()
+		app.setupConfigUpdate(update)
+	}
+
+	err = govern.ClearConfigUpdates(height)
+	if err != nil {
+		app.logger.Error("failed to clear config updates", "height", height, "err", err)
+	}
+}
+
+// applyConfigUpdate stores every option set in the update
+func (app *App) applyConfigUpdate(govern *governance.Store, update governance.ConfigUpdate) error {
+	if update.FeeOption != nil {
+		err := govern.SetFeeOption(*update.FeeOption)
+		if err != nil {
+			return err
+		}
+	}
+	if update.ONSOptions != nil {
+		err := govern.SetONSOptions(*update.ONSOptions)
+		if err != nil {
+			return err
+		}
+	}
+	if update.ETHCDOption != nil {
+		err := govern.SetETHChainDriverOption(*update.ETHCDOption)
+		if err != nil {
+			return err
+		}
+	}
+	if update.BTCCDOption != nil {
+		err := govern.SetBTCChainDriverOption(*update.BTCCDOption)
+		if err != nil {
+			return err
+		}
+	}
+	if update.PropOptions != nil {
+		err := govern.SetProposalOptions(*update.PropOptions)
+		if err != nil {
+			return err
+		}
+	}
+	if update.StakingOptions != nil {
+		err := govern.SetStakingOptions(*update.StakingOptions)
+		if err != nil {
+			return err
+		}
+	}
+	if update.IssuanceOptions != nil {
+		err := govern.SetIssuanceOptions(*update.IssuanceOptions)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setupConfigUpdate hands the updated options to the stores that use them
+func (app *App) setupConfigUpdate(update governance.ConfigUpdate) {
+	if update.FeeOption != nil {
+		app.Context.feePool.SetupOpt(update.FeeOption)
+	}
+	if update.ONSOptions != nil {
+		app.Context.domains.SetOptions(update.ONSOptions)
+	}
+	if update.ETHCDOption != nil {
+		app.Context.ethTrackers.SetupOption(update.ETHCDOption)
+	}
+	if update.BTCCDOption != nil {
+		app.Context.btcTrackers.SetConfig(bitcoin.NewBTCConfig(app.Context.cfg.ChainDriver, update.BTCCDOption.ChainType))
+		app.Context.btcTrackers.SetOption(*update.BTCCDOption)
+	}
+	if update.PropOptions != nil {
+		app.Context.proposalMaster.Proposal.SetOptions(update.PropOptions)
+	}
+	if update.StakingOptions != nil {
+		app.Context.validators.SetOptions(update.StakingOptions)
+	}
+	if update.IssuanceOptions != nil {
+		app.Context.supplies.SetOptions(update.IssuanceOptions)
+	}
+}
+
+// syncCurrencies registers the currencies issued by tx in the committed state, so they can be used from the next block
+func (app *App) syncCurrencies() error {
+	var err error
+	supplies := balance.NewSupplyStore("supply", storage.NewState(app.Context.chainstate))
+	supplies.Iterate(func(ts balance.TokenSupply) bool {
+		if !ts.Mintable {
+			return false
+		}
+		if _, ok := app.Context.currencies.GetCurrencyByName(ts.Currency.Name); ok {
+			return false
+		}
+		err = app.Context.currencies.Register(ts.Currency)
+		return err != nil
+	})
+	return err
+}
+
 func (app *App) VerifyCache(tx []byte) bool {
 	hash := utils.SHA2(tx)
 	return app.Context.internalService.ExistTx(hash)
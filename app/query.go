@@ -0,0 +1,157 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const (
+	// ABCI query paths look like /store/<name>/key or /store/<name>/subspace, the data of the query is the key
+	// inside the store, or the key prefix for a subspace
+	queryStorePath    = "store"
+	queryKeyPath      = "key"
+	querySubspacePath = "subspace"
+
+	// most items returned by a subspace query
+	maxQueryItems = 100
+
+	// proof op type of a subspace query, the data is the amino encoded iavl range proof
+	ProofOpIAVLRange = "iavl:range"
+)
+
+// queryStores maps the store names accepted in query paths to the prefixes the stores are kept under
+var queryStores = map[string]string{
+	"balances":         "b",
+	"domains":          "d",
+	"validators":       "v",
+	"delegations":      "dl",
+	"btctrackers":      "btct",
+	"ethtrackers":      "etht",
+	"proposals.active": "propActive",
+	"proposals.passed": "propPassed",
+	"proposals.failed": "propFailed",
+	"feegrants":        "fg",
+	"supplies":         "supply",
+}
+
+// QueryItem is an entry returned by a subspace query
+type QueryItem struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+var rangeProofCdc = amino.NewCodec()
+
+func (app *App) queryer() queryer {
+	return func(req RequestQuery) ResponseQuery {
+		defer app.handlePanic()
+
+		result, err := app.query(req)
+		if err != nil {
+			return ResponseQuery{
+				Code:   CodeNotOK.uint32(),
+				Log:    err.Error(),
+				Height: req.Height,
+			}
+		}
+		return result
+	}
+}
+
+// query reads a key or a subspace of a store from a committed version of the chain state
+func (app *App) query(req RequestQuery) (ResponseQuery, error) {
+	parts := strings.Split(strings.Trim(req.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != queryStorePath {
+		return ResponseQuery{}, fmt.Errorf("unknown query path: %s", req.Path)
+	}
+	prefix, ok := queryStores[parts[1]]
+	if !ok {
+		return ResponseQuery{}, fmt.Errorf("unknown store: %s", parts[1])
+	}
+
+	chainstate := app.Context.chainstate
+	height := req.Height
+	if height == 0 {
+		height = chainstate.Version
+	}
+	if height <= 0 || height > chainstate.Version {
+		return ResponseQuery{}, fmt.Errorf("height %d not committed, latest: %d", req.Height, chainstate.Version)
+	}
+
+	key := append(storage.Prefix(prefix), req.Data...)
+
+	switch parts[2] {
+	case queryKeyPath:
+		value, proof, err := chainstate.GetVersionedWithProof(key, height)
+		if err != nil {
+			return ResponseQuery{}, errors.Wrap(err, "failed to get key")
+		}
+		result := ResponseQuery{
+			Code:   CodeOK.uint32(),
+			Key:    key,
+			Value:  value,
+			Height: height,
+		}
+		if req.Prove {
+			var op merkle.ProofOp
+			if value != nil {
+				op = iavl.NewValueOp(key, proof).ProofOp()
+			} else {
+				op = iavl.NewAbsenceOp(key, proof).ProofOp()
+			}
+			result.Proof = &merkle.Proof{Ops: []merkle.ProofOp{op}}
+		}
+		return result, nil
+
+	case querySubspacePath:
+		keys, values, proof, err := chainstate.GetVersionedRangeWithProof(key, prefixEnd(key), maxQueryItems, height)
+		if err != nil {
+			return ResponseQuery{}, errors.Wrap(err, "failed to get subspace")
+		}
+		items := make([]QueryItem, 0, len(keys))
+		for i := range keys {
+			items = append(items, QueryItem{Key: keys[i], Value: values[i]})
+		}
+		value, err := serialize.GetSerializer(serialize.JSON).Serialize(items)
+		if err != nil {
+			return ResponseQuery{}, errors.Wrap(err, "failed to serialize subspace")
+		}
+		result := ResponseQuery{
+			Code:   CodeOK.uint32(),
+			Key:    key,
+			Value:  value,
+			Height: height,
+		}
+		if req.Prove {
+			data, err := rangeProofCdc.MarshalBinaryBare(proof)
+			if err != nil {
+				return ResponseQuery{}, errors.Wrap(err, "failed to serialize range proof")
+			}
+			result.Proof = &merkle.Proof{Ops: []merkle.ProofOp{{Type: ProofOpIAVLRange, Key: key, Data: data}}}
+		}
+		return result, nil
+	}
+
+	return ResponseQuery{}, fmt.Errorf("unknown query type: %s", parts[2])
+}
+
+// prefixEnd returns the first key after all the keys starting with the prefix
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
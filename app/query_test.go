@@ -0,0 +1,98 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func setupForQuery() *App {
+	chainstate := storage.NewChainState("chainstate", db.NewDB("test", db.MemDBBackend, ""))
+	state := storage.NewState(chainstate)
+	_ = state.Set(append(storage.Prefix("b"), []byte("alice")...), []byte("100"))
+	_ = state.Set(append(storage.Prefix("b"), []byte("bob")...), []byte("200"))
+	_ = state.Set(append(storage.Prefix("d"), []byte("alice.ol")...), []byte("domain"))
+	state.Commit()
+
+	// a later version changes the balance of alice
+	_ = state.Set(append(storage.Prefix("b"), []byte("alice")...), []byte("50"))
+	state.Commit()
+
+	return &App{Context: context{chainstate: chainstate}}
+}
+
+func TestApp_Query_Key(t *testing.T) {
+	app := setupForQuery()
+
+	resp, err := app.query(RequestQuery{Path: "/store/balances/key", Data: []byte("alice"), Prove: true})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, resp.Height)
+	assert.Equal(t, []byte("50"), resp.Value)
+
+	op, err := iavl.ValueOpDecoder(resp.Proof.Ops[0])
+	assert.NoError(t, err)
+	root, err := op.Run([][]byte{resp.Value})
+	assert.NoError(t, err)
+	assert.Equal(t, app.Context.chainstate.Hash, root[0])
+
+	// the balance as it was at the first version
+	resp, err = app.query(RequestQuery{Path: "/store/balances/key", Data: []byte("alice"), Height: 1, Prove: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("100"), resp.Value)
+	op, err = iavl.ValueOpDecoder(resp.Proof.Ops[0])
+	assert.NoError(t, err)
+	root, err = op.Run([][]byte{resp.Value})
+	assert.NoError(t, err)
+	assert.Equal(t, app.Context.chainstate.LastHash, root[0])
+
+	// missing keys come with a proof of absence
+	resp, err = app.query(RequestQuery{Path: "/store/balances/key", Data: []byte("carol"), Prove: true})
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Value)
+	absence, err := iavl.AbsenceOpDecoder(resp.Proof.Ops[0])
+	assert.NoError(t, err)
+	root, err = absence.Run(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, app.Context.chainstate.Hash, root[0])
+}
+
+func TestApp_Query_Subspace(t *testing.T) {
+	app := setupForQuery()
+
+	resp, err := app.query(RequestQuery{Path: "/store/balances/subspace", Prove: true})
+	assert.NoError(t, err)
+
+	items := make([]QueryItem, 0)
+	assert.NoError(t, serialize.GetSerializer(serialize.JSON).Deserialize(resp.Value, &items))
+	assert.Len(t, items, 2)
+	assert.Equal(t, append(storage.Prefix("b"), []byte("alice")...), items[0].Key)
+	assert.Equal(t, []byte("200"), items[1].Value)
+
+	assert.Equal(t, ProofOpIAVLRange, resp.Proof.Ops[0].Type)
+	proof := &iavl.RangeProof{}
+	assert.NoError(t, amino.NewCodec().UnmarshalBinaryBare(resp.Proof.Ops[0].Data, proof))
+	assert.NoError(t, proof.Verify(app.Context.chainstate.Hash))
+	for _, item := range items {
+		assert.NoError(t, proof.VerifyItem(item.Key, item.Value))
+	}
+}
+
+func TestApp_Query_Errors(t *testing.T) {
+	app := setupForQuery()
+
+	_, err := app.query(RequestQuery{Path: "/store/unknown/key", Data: []byte("alice")})
+	assert.Error(t, err)
+	_, err = app.query(RequestQuery{Path: "/balances", Data: []byte("alice")})
+	assert.Error(t, err)
+	_, err = app.query(RequestQuery{Path: "/store/balances/key", Data: []byte("alice"), Height: 3})
+	assert.Error(t, err)
+
+	resp := app.queryer()(RequestQuery{Path: "/store/balances/values"})
+	assert.Equal(t, CodeNotOK.uint32(), resp.Code)
+}
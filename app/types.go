@@ -34,4 +34,6 @@ type Header = abci.Header
 
 type Validator = abci.Validator
 
+type Event = abci.Event
+
 type ABCIApp = abci.Application
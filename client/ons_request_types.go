@@ -2,6 +2,8 @@ package client
 
 import (
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/data/ons"
 )
@@ -39,6 +41,74 @@ type ONSUpdateRequest struct {
 	Gas      int64         `json:"gas"`
 }
 
+type ONSSetRecordsRequest struct {
+	Owner    keys.Address  `json:"owner"`
+	Name     string        `json:"name"`
+	Records  []ons.Record  `json:"records"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type ONSSetPrimaryRequest struct {
+	Address  keys.Address  `json:"address"`
+	Name     string        `json:"name"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type ONSBidCommitRequest struct {
+	Bidder keys.Address `json:"bidder"`
+	Name   string       `json:"name"`
+	// hash of the sealed bid, made by ons.BidCommitment so the bid itself never leaves the bidder
+	Commitment []byte        `json:"commitment"`
+	Deposit    action.Amount `json:"deposit"`
+	GasPrice   action.Amount `json:"gasPrice"`
+	Gas        int64         `json:"gas"`
+}
+
+type ONSBidRevealRequest struct {
+	Bidder   keys.Address  `json:"bidder"`
+	Name     string        `json:"name"`
+	Amount   action.Amount `json:"amount"`
+	Salt     []byte        `json:"salt"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type ONSAuctionFinalizeRequest struct {
+	Winner   keys.Address  `json:"winner"`
+	Account  keys.Address  `json:"account"`
+	Name     string        `json:"name"`
+	Uri      string        `json:"uri"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type ONSMakeOfferRequest struct {
+	Bidder       keys.Address  `json:"bidder"`
+	Name         string        `json:"name"`
+	Amount       action.Amount `json:"amount"`
+	ExpireHeight int64         `json:"expireHeight"`
+	GasPrice     action.Amount `json:"gasPrice"`
+	Gas          int64         `json:"gas"`
+}
+
+type ONSCancelOfferRequest struct {
+	Bidder   keys.Address  `json:"bidder"`
+	Name     string        `json:"name"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type ONSAcceptOfferRequest struct {
+	Owner    keys.Address  `json:"owner"`
+	Name     string        `json:"name"`
+	Bidder   keys.Address  `json:"bidder"`
+	Amount   action.Amount `json:"amount"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
 type ONSRenewRequest struct {
 	Owner       keys.Address  `json:"owner"`
 	Account     keys.Address  `json:"account"`
@@ -82,15 +152,76 @@ type ONSDeleteSubRequest struct {
 }
 
 type ONSGetDomainsRequest struct {
+	PageRequest
 	Name        string       `json:"name"`
 	Owner       keys.Address `json:"owner"`
 	OnSale      bool         `json:"onSale"`
 	Beneficiary keys.Address `json:"beneficiary"`
+	// Optional only the domains expiring before this height
+	ExpireBefore int64 `json:"expireBefore,omitempty"`
+	// Optional only the domains on sale at this price or less
+	MaxPrice *balance.Amount `json:"maxPrice,omitempty"`
+	// Optional height to read the domains at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
 }
 
 type ONSGetDomainsReply struct {
 	Domains []ons.Domain `json:"domains"`
-	Height  int64        `json:"height"`
+	PageReply
+	Height int64 `json:"height"`
+}
+
+type ONSResolveRecordRequest struct {
+	Name string         `json:"name"`
+	Type ons.RecordType `json:"type"`
+	// chain of an address record, key of a text or data record
+	Chain chain.Type `json:"chain,omitempty"`
+	Key   string     `json:"key,omitempty"`
+	// Optional height to resolve the record at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+
+type ONSResolveRecordReply struct {
+	Record ons.Record `json:"record"`
+	Height int64      `json:"height"`
+}
+
+type ONSReverseLookupRequest struct {
+	Address keys.Address `json:"address"`
+	// Optional height to look the name up at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+
+type ONSReverseLookupReply struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+type ONSGetAuctionRequest struct {
+	Name string `json:"name"`
+	// Optional height to read the auction at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+
+type ONSGetAuctionReply struct {
+	Auction ons.Auction `json:"auction"`
+	Height  int64       `json:"height"`
+}
+
+type ONSGetOffersRequest struct {
+	// the start key is the bidder of the offers on a domain, or the domain of the offers by a bidder
+	PageRequest
+	// the offers made on the domain, or by the bidder
+	Name   string       `json:"name,omitempty"`
+	Bidder keys.Address `json:"bidder,omitempty"`
+	// Optional height to read the offers at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+
+type ONSGetOffersReply struct {
+	Offers []ons.Offer `json:"offers"`
+	PageReply
+	Height int64 `json:"height"`
 }
 
 type ONSGetOptionsReply struct {
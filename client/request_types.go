@@ -19,6 +19,7 @@ import (
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/txindex"
 	"github.com/Oneledger/protocol/identity"
 )
 
@@ -37,8 +38,28 @@ Services:
 */
 
 /* Blockchain service  */
+
+// PageRequest selects a page of a list, list requests embed it
+type PageRequest struct {
+	// Optional key of the first item of the page, the NextKey of the previous page. The list starts from its
+	// beginning if not set
+	StartKey string `json:"startKey,omitempty"`
+	// Optional most items in the page, 20 if not set and at most 100
+	Limit int `json:"limit,omitempty"`
+	// Optional walk the list from its end
+	Reverse bool `json:"reverse,omitempty"`
+}
+
+// PageReply is embedded in list replies
+type PageReply struct {
+	// The StartKey of the next page, empty on the last page
+	NextKey string `json:"nextKey,omitempty"`
+}
+
 type BalanceRequest struct {
 	Address keys.Address `json:"address"`
+	// Optional height to read the balance at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
 }
 type BalanceReply struct {
 	// The balance of the account. Returns an empty balance
@@ -48,6 +69,18 @@ type BalanceReply struct {
 	Height int64 `json:"height"`
 }
 
+type NonceRequest struct {
+	Address keys.Address `json:"address"`
+	// Optional height to read the nonce at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+type NonceReply struct {
+	// The nonce the next tx of the account must carry
+	Nonce uint64 `json:"nonce"`
+	// The height when this nonce was recorded
+	Height int64 `json:"height"`
+}
+
 /* Tx Service */
 
 type SendTxRequest struct {
@@ -56,6 +89,75 @@ type SendTxRequest struct {
 	Amount   action.Amount `json:"amount"`
 	GasPrice action.Amount `json:"gasPrice"`
 	Gas      int64         `json:"gas"`
+	// Optional account paying the fee out of the fee grant it gave to the sender
+	FeeGranter keys.Address `json:"feeGranter,omitempty"`
+}
+
+// MultiSendTransfer is an input or an output of a multi send
+type MultiSendTransfer struct {
+	Address keys.Address  `json:"address"`
+	Amount  action.Amount `json:"amount"`
+}
+
+type MultiSendRequest struct {
+	Inputs   []MultiSendTransfer `json:"inputs"`
+	Outputs  []MultiSendTransfer `json:"outputs"`
+	GasPrice action.Amount       `json:"gasPrice"`
+	Gas      int64               `json:"gas"`
+}
+
+type CreateVestingRequest struct {
+	From        keys.Address  `json:"from"`
+	To          keys.Address  `json:"to"`
+	Amount      action.Amount `json:"amount"`
+	StartHeight int64         `json:"startHeight"`
+	CliffHeight int64         `json:"cliffHeight"`
+	EndHeight   int64         `json:"endHeight"`
+	GasPrice    action.Amount `json:"gasPrice"`
+	Gas         int64         `json:"gas"`
+}
+
+type CurrencyRegisterRequest struct {
+	Issuer  keys.Address `json:"issuer"`
+	Name    string       `json:"name"`
+	Decimal int64        `json:"decimal"`
+	Unit    string       `json:"unit"`
+	// Optional most of the currency that can be minted
+	SupplyCap balance.Amount `json:"supplyCap"`
+	GasPrice  action.Amount  `json:"gasPrice"`
+	Gas       int64          `json:"gas"`
+}
+
+type MintRequest struct {
+	Issuer   keys.Address  `json:"issuer"`
+	To       keys.Address  `json:"to"`
+	Amount   action.Amount `json:"amount"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type BurnRequest struct {
+	Issuer   keys.Address  `json:"issuer"`
+	Amount   action.Amount `json:"amount"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type FeeGrantRequest struct {
+	Granter    keys.Address  `json:"granter"`
+	Grantee    keys.Address  `json:"grantee"`
+	SpendLimit action.Amount `json:"spendLimit"`
+	// Last height the grant can be used at, 0 for a grant which doesn't expire
+	Expiry   int64         `json:"expiry"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
+}
+
+type FeeRevokeRequest struct {
+	Granter  keys.Address  `json:"granter"`
+	Grantee  keys.Address  `json:"grantee"`
+	GasPrice action.Amount `json:"gasPrice"`
+	Gas      int64         `json:"gas"`
 }
 
 type CreateTxReply struct {
@@ -138,20 +240,31 @@ type ListAccountAddressesReply struct {
 	Addresses []string `json:"addresses"`
 }
 
-type ListValidatorsRequest struct{}
+type ListValidatorsRequest struct {
+	PageRequest
+	// Optional leave out the jailed validators
+	ExcludeJailed bool `json:"excludeJailed,omitempty"`
+	// Optional height to read the validators at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
 type ListValidatorsReply struct {
 	// The list of active validators
 	Validators []identity.Validator `json:"validators"`
+	PageReply
 	// Height at which this validator set was active
 	Height int64 `json:"height"`
 }
 
-type ListWitnessesRequest struct{
+type ListWitnessesRequest struct {
+	PageRequest
 	ChainType chain.Type `json:"chainType"`
+	// Optional height to read the witnesses at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
 }
 type ListWitnessesReply struct {
 	// The list of active witnesses
 	Witnesses []keys.Address `json:"witnesses"`
+	PageReply
 	// Height at which this witness set was active
 	Height int64 `json:"height"`
 }
@@ -165,6 +278,8 @@ type BroadcastRequest struct {
 	RawTx     []byte         `json:"rawTx"`
 	Signature []byte         `json:"signature"`
 	PublicKey keys.PublicKey `json:"publicKey"`
+	// Signatures, if given, replace the single signature above, e.g. for txs signed by the members of a multisig account
+	Signatures []action.Signature `json:"signatures,omitempty"`
 }
 
 type BroadcastReply struct {
@@ -266,6 +381,8 @@ type ETHLockRequest struct {
 type CurrencyBalanceRequest struct {
 	Currency string       `json:"currency"`
 	Address  keys.Address `json:"address"`
+	// Optional height to read the balance at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
 }
 type CurrencyBalanceReply struct {
 	Currency string `json:"currency"`
@@ -285,6 +402,68 @@ type FeeOptionsReply struct {
 	FeeOption fees.FeeOption `json:"feeOption"`
 }
 
+type BaseFeeRequest struct {
+	// Optional height to read the base fee at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+type BaseFeeReply struct {
+	// The minimal fee price of a tx for the next block
+	BaseFee balance.Coin `json:"baseFee"`
+	// The height when this base fee was recorded
+	Height int64 `json:"height"`
+}
+
+type TotalSupplyRequest struct {
+	Currency string `json:"currency"`
+	// Optional height to read the supply at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+type TotalSupplyReply struct {
+	Currency string `json:"currency"`
+	// Supply tracked as the currency is minted and burnt, everything below except the bridge locked amount if the
+	// currency is not tracked
+	Total balance.Amount `json:"total"`
+	// Held in the balances of the accounts
+	Balances balance.Amount `json:"balances"`
+	// Collected as fees and not withdrawn yet
+	FeePool balance.Amount `json:"feePool"`
+	// Staked or delegated to validators, including the stake being unbonded
+	Staked balance.Amount `json:"staked"`
+	// Raised by governance proposals and not paid out or refunded yet
+	ProposalFunds balance.Amount `json:"proposalFunds"`
+	// Amount of the coin on the bridged chain locked against this currency
+	BridgeLocked balance.Amount `json:"bridgeLocked"`
+	Height       int64          `json:"height"`
+}
+
+type TopHoldersRequest struct {
+	PageRequest
+	Currency string `json:"currency"`
+	// Optional height to read the holders at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+type Holder struct {
+	Address keys.Address   `json:"address"`
+	Balance balance.Amount `json:"balance"`
+}
+type TopHoldersReply struct {
+	Currency string   `json:"currency"`
+	Holders  []Holder `json:"holders"`
+	PageReply
+	Height int64 `json:"height"`
+}
+
+type FeeSplitRequest struct {
+	// Optional height to read the totals at, the latest committed state is read if not set
+	Height int64 `json:"height,omitempty"`
+}
+type FeeSplitReply struct {
+	// The fees burnt, paid to the treasury and left to validators so far
+	Totals fees.FeeSplit `json:"totals"`
+	// The height when these totals were recorded
+	Height int64 `json:"height"`
+}
+
 type ListTxTypesRequest struct{}
 type ListTxTypesReply struct {
 	TxTypes []action.TxTypeDescribe `json:"txTypes"`
@@ -298,3 +477,17 @@ type TxRequest struct {
 type TxResponse struct {
 	Result ctypes.ResultTx `json:"result"`
 }
+
+type TxSearchRequest struct {
+	PageRequest
+	// Optional filters, the txs signed by the address or naming it in their tags, of the type, and in the
+	// inclusive height range, a zero type matches every type
+	Address   keys.Address `json:"address,omitempty"`
+	Type      action.Type  `json:"type,omitempty"`
+	MinHeight int64        `json:"minHeight,omitempty"`
+	MaxHeight int64        `json:"maxHeight,omitempty"`
+}
+type TxSearchReply struct {
+	Txs []txindex.Record `json:"txs"`
+	PageReply
+}
@@ -23,16 +23,74 @@ func (c *ServiceClient) Balance(addr keys.Address) (out BalanceReply, err error)
 	/*if len(request) <= 20 {
 		return out, errors.New("address has insufficient length")
 	}*/
-	request := BalanceRequest{addr}
+	request := BalanceRequest{Address: addr}
 	err = c.Call("query.Balance", &request, &out)
 	return
 }
 
+// BalanceAt returns the balance of the address as it was at the given height
+func (c *ServiceClient) BalanceAt(addr keys.Address, height int64) (out BalanceReply, err error) {
+	request := BalanceRequest{Address: addr, Height: height}
+	err = c.Call("query.Balance", &request, &out)
+	return
+}
+
+// Nonce returns the nonce the next tx signed first by the address must carry
+func (c *ServiceClient) Nonce(addr keys.Address) (out NonceReply, err error) {
+	request := NonceRequest{Address: addr}
+	err = c.Call("query.Nonce", &request, &out)
+	return
+}
+
+// NonceAt returns the nonce of the address as it was at the given height
+func (c *ServiceClient) NonceAt(addr keys.Address, height int64) (out NonceReply, err error) {
+	request := NonceRequest{Address: addr, Height: height}
+	err = c.Call("query.Nonce", &request, &out)
+	return
+}
+
+func (c *ServiceClient) BaseFee() (out BaseFeeReply, err error) {
+	err = c.Call("query.BaseFee", BaseFeeRequest{}, &out)
+	return
+}
+
+// BaseFeeAt returns the base fee as it was at the given height
+func (c *ServiceClient) BaseFeeAt(height int64) (out BaseFeeReply, err error) {
+	err = c.Call("query.BaseFee", BaseFeeRequest{Height: height}, &out)
+	return
+}
+
+func (c *ServiceClient) FeeSplit() (out FeeSplitReply, err error) {
+	err = c.Call("query.FeeSplit", FeeSplitRequest{}, &out)
+	return
+}
+
+// FeeSplitAt returns the fee split totals as they were at the given height
+func (c *ServiceClient) FeeSplitAt(height int64) (out FeeSplitReply, err error) {
+	err = c.Call("query.FeeSplit", FeeSplitRequest{Height: height}, &out)
+	return
+}
+
+func (c *ServiceClient) TotalSupply(req TotalSupplyRequest) (out TotalSupplyReply, err error) {
+	err = c.Call("query.TotalSupply", req, &out)
+	return
+}
+
+func (c *ServiceClient) TopHolders(req TopHoldersRequest) (out TopHoldersReply, err error) {
+	err = c.Call("query.TopHolders", req, &out)
+	return
+}
+
+func (c *ServiceClient) TxSearch(req TxSearchRequest) (out TxSearchReply, err error) {
+	err = c.Call("query.TxSearch", req, &out)
+	return
+}
+
 func (c *ServiceClient) CurrBalance(addr keys.Address, currency string) (out CurrencyBalanceReply, err error) {
 	/*if len(request) <= 20 {
 		return out, errors.New("address has insufficient length")
 	}*/
-	request := CurrencyBalanceRequest{currency, addr}
+	request := CurrencyBalanceRequest{Currency: currency, Address: addr}
 	err = c.Call("query.CurrencyBalance", &request, &out)
 	return
 }
@@ -105,6 +163,38 @@ func (c *ServiceClient) ONS_CreateRawUpdate(req ONSUpdateRequest) (out CreateTxR
 	err = c.Call("tx.ONS_CreateRawUpdate", req, &out)
 	return
 }
+func (c *ServiceClient) ONS_CreateRawSetRecords(req ONSSetRecordsRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawSetRecords", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawSetPrimary(req ONSSetPrimaryRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawSetPrimary", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawBidCommit(req ONSBidCommitRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawBidCommit", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawBidReveal(req ONSBidRevealRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawBidReveal", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawAuctionFinalize(req ONSAuctionFinalizeRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawAuctionFinalize", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawMakeOffer(req ONSMakeOfferRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawMakeOffer", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawCancelOffer(req ONSCancelOfferRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawCancelOffer", req, &out)
+	return
+}
+func (c *ServiceClient) ONS_CreateRawAcceptOffer(req ONSAcceptOfferRequest) (out CreateTxReply, err error) {
+	err = c.Call("tx.ONS_CreateRawAcceptOffer", req, &out)
+	return
+}
 func (c *ServiceClient) ONS_CreateRawSale(req ONSSaleRequest) (out CreateTxReply, err error) {
 	err = c.Call("tx.ONS_CreateRawSale", req, &out)
 	return
@@ -124,8 +214,43 @@ func (c *ServiceClient) CreateRawSend(req SendTxRequest) (out *CreateTxReply, er
 	return
 }
 
-func (c *ServiceClient) ListValidators() (out ListValidatorsReply, err error) {
-	err = c.Call("query.ListValidators", struct{}{}, &out)
+func (c *ServiceClient) CreateRawMultiSend(req MultiSendRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawMultiSend", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawVesting(req CreateVestingRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawVesting", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawCurrencyRegister(req CurrencyRegisterRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawCurrencyRegister", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawMint(req MintRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawMint", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawBurn(req BurnRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawBurn", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawFeeGrant(req FeeGrantRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawFeeGrant", req, &out)
+	return
+}
+
+func (c *ServiceClient) CreateRawFeeRevoke(req FeeRevokeRequest) (out *CreateTxReply, err error) {
+	err = c.Call("tx.CreateRawFeeRevoke", req, &out)
+	return
+}
+
+func (c *ServiceClient) ListValidators(req ListValidatorsRequest) (out ListValidatorsReply, err error) {
+	err = c.Call("query.ListValidators", req, &out)
 	return
 }
 
@@ -59,7 +59,7 @@ func NewExtServiceContext(rpcAddress, sdkAddress string) (cliCtx ExtServiceConte
 	// try starting tmRPCClient client
 	err = tmRPCClient.Start()
 	if err != nil {
-		err = fmt.Errorf("rpcClient is unavailable", "address", rpcAddress, err)
+		err = fmt.Errorf("rpcClient is unavailable, address: %s, err: %v", rpcAddress, err)
 		return
 	}
 
@@ -31,6 +31,7 @@ type Balance struct {
 	accountName  string
 	accountKey   []byte
 	currencyName string
+	height       int64
 }
 
 var balArgs *Balance = &Balance{}
@@ -43,6 +44,7 @@ func init() {
 	balanceCmd.Flags().BytesHexVar(&balArgs.accountKey, "address", []byte{}, "account address")
 
 	balanceCmd.Flags().StringVar(&balArgs.currencyName, "currency", "", "currency name")
+	balanceCmd.Flags().Int64Var(&balArgs.height, "height", 0, "block height to read the balance at, latest if not set")
 
 }
 
@@ -63,7 +65,7 @@ func BalanceNode(cmd *cobra.Command, args []string) {
 
 	// assuming we have public key
 	if balArgs.currencyName == "" {
-		bal, err := fullnode.Balance(balArgs.accountKey)
+		bal, err := fullnode.BalanceAt(balArgs.accountKey, balArgs.height)
 		if err != nil {
 			logger.Fatal("error in getting balance", err)
 		}
@@ -0,0 +1,102 @@
+/*
+   ____             _              _                      _____           _                  _
+  / __ \           | |            | |                    |  __ \         | |                | |
+ | |  | |_ __   ___| |     ___  __| | __ _  ___ _ __     | |__) | __ ___ | |_ ___   ___ ___ | |
+ | |  | | '_ \ / _ \ |    / _ \/ _` |/ _` |/ _ \ '__|    |  ___/ '__/ _ \| __/ _ \ / __/ _ \| |
+ | |__| | | | |  __/ |___|  __/ (_| | (_| |  __/ |       | |   | | | (_) | || (_) | (_| (_) | |
+  \____/|_| |_|\___|______\___|\__,_|\__, |\___|_|       |_|   |_|  \___/ \__\___/ \___\___/|_|
+                                      __/ |
+                                     |___/
+
+
+Copyright 2017 - 2019 OneLedger
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/client"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the txs of an account, newest first",
+	Run:   History,
+}
+
+type HistoryArguments struct {
+	address   []byte
+	txType    string
+	minHeight int64
+	maxHeight int64
+	limit     int
+}
+
+var historyArgs = &HistoryArguments{}
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().BytesHexVar(&historyArgs.address, "address", []byte{}, "account address")
+	historyCmd.Flags().StringVar(&historyArgs.txType, "type", "", "tx type, e.g. SEND, all types if not set")
+	historyCmd.Flags().Int64Var(&historyArgs.minHeight, "from", 0, "lowest block height to list")
+	historyCmd.Flags().Int64Var(&historyArgs.maxHeight, "to", 0, "highest block height to list")
+	historyCmd.Flags().IntVar(&historyArgs.limit, "limit", 20, "most txs to list")
+}
+
+func History(cmd *cobra.Command, args []string) {
+	Ctx := NewContext()
+	fullnode := Ctx.clCtx.FullNodeClient()
+
+	req := client.TxSearchRequest{
+		PageRequest: client.PageRequest{Reverse: true},
+		Address:     historyArgs.address,
+		MinHeight:   historyArgs.minHeight,
+		MaxHeight:   historyArgs.maxHeight,
+	}
+	if historyArgs.txType != "" {
+		txType, ok := parseTxType(historyArgs.txType)
+		if !ok {
+			logger.Error("unknown tx type", historyArgs.txType)
+			return
+		}
+		req.Type = txType
+	}
+
+	left := historyArgs.limit
+	for left > 0 {
+		req.Limit = left
+		if req.Limit > 100 {
+			req.Limit = 100
+		}
+		out, err := fullnode.TxSearch(req)
+		if err != nil {
+			logger.Error("error in searching txs", err)
+			return
+		}
+
+		for _, tx := range out.Txs {
+			fmt.Printf("%d\t%s\t%s\tcode: %d\n", tx.Height, hex.EncodeToString(tx.Hash), tx.Type, tx.Code)
+		}
+		left -= len(out.Txs)
+		if len(out.NextKey) == 0 {
+			return
+		}
+		req.StartKey = out.NextKey
+	}
+}
+
+func parseTxType(name string) (action.Type, bool) {
+	for i := 1; i < int(action.EOF); i++ {
+		if action.Type(i).String() == name {
+			return action.Type(i), true
+		}
+	}
+	return 0, false
+}
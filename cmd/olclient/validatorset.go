@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 
+	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/identity"
 
 	"github.com/spf13/cobra"
@@ -37,16 +38,25 @@ func init() {
 func ListValidator(cmd *cobra.Command, args []string) {
 	Ctx := NewContext()
 	fullnode := Ctx.clCtx.FullNodeClient()
-	out, err := fullnode.ListValidators()
-	if err != nil {
-		logger.Error("error in getting all validators", err)
-		return
-	}
+	req := client.ListValidatorsRequest{}
+	for {
+		out, err := fullnode.ListValidators(req)
+		if err != nil {
+			logger.Error("error in getting all validators", err)
+			return
+		}
 
-	for _, v := range out.Validators {
-		printValidator(v)
+		for _, v := range out.Validators {
+			printValidator(v)
+		}
+		if len(out.NextKey) == 0 {
+			fmt.Println("Height", out.Height)
+			return
+		}
+		// the next pages are read at the height of the first one
+		req.StartKey = out.NextKey
+		req.Height = out.Height
 	}
-	fmt.Println("Height", out.Height)
 }
 
 func printValidator(v identity.Validator) {
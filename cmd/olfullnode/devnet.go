@@ -424,8 +424,11 @@ func initialState(args *testnetConfig, nodeList []node, option ethchain.ChainDri
 	ottc := balance.Currency{Id: 4, Name: "TTC", Chain: chain.TESTTOKEN, Decimal: 18, Unit: "testUnits"} //Tokens count by number ,Unit 1
 	currencies := []balance.Currency{olt, vt, obtc, oeth, ottc}
 	feeOpt := fees.FeeOption{
-		FeeCurrency:   olt,
-		MinFeeDecimal: 9,
+		FeeCurrency:              olt,
+		MinFeeDecimal:            9,
+		BaseFeeChangeDenominator: 8,
+		TargetBlockGas:           1000000,
+		BurnPercent:              10,
 	}
 	balances := make([]consensus.BalanceState, 0, len(nodeList))
 	staking := make([]consensus.Stake, 0, len(nodeList))
@@ -543,10 +546,12 @@ func initialState(args *testnetConfig, nodeList []node, option ethchain.ChainDri
 		Domains:    domains,
 		Fees:       fees_db,
 		Governance: consensus.GovernanceState{
-			FeeOption:   feeOpt,
-			ETHCDOption: option,
-			BTCCDOption: btcOption,
-			ONSOptions:  onsOption,
+			FeeOption:      feeOpt,
+			ETHCDOption:    option,
+			BTCCDOption:    btcOption,
+			ONSOptions:     onsOption,
+			PropOptions:    *getProposalOptions(),
+			StakingOptions: *getStakingOptions(vt),
 		},
 	}
 }
@@ -23,8 +23,10 @@ import (
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 )
 
@@ -347,6 +349,49 @@ func getOnsOpt() *ons.Options {
 	}
 }
 
+func getProposalOptions() *governance.ProposalOptions {
+
+	initialFunding, _ := balance.NewAmountFromString("1000000000000000000000", 10)
+	fundingGoal, _ := balance.NewAmountFromString("10000000000000000000000", 10)
+	return &governance.ProposalOptions{
+		ConfigUpdate: governance.ProposalOption{
+			InitialFunding:  initialFunding,
+			FundingGoal:     fundingGoal,
+			FundingDeadline: 75001,
+			VotingDeadline:  150000,
+			PassPercentage:  51,
+		},
+		CodeChange: governance.ProposalOption{
+			InitialFunding:  initialFunding,
+			FundingGoal:     fundingGoal,
+			FundingDeadline: 75001,
+			VotingDeadline:  150000,
+			PassPercentage:  67,
+		},
+		General: governance.ProposalOption{
+			InitialFunding:  initialFunding,
+			FundingGoal:     fundingGoal,
+			FundingDeadline: 75001,
+			VotingDeadline:  150000,
+			PassPercentage:  51,
+		},
+	}
+}
+
+func getStakingOptions(vt balance.Currency) *identity.Options {
+	return &identity.Options{
+		CommissionRate:         10,
+		UnbondingBlocks:        120960,
+		PowerReduction:         vt.NewCoinFromInt(1).Amount,
+		MaxPowerPercent:        33,
+		DoubleSignSlashPercent: 5,
+		DowntimeSlashPercent:   1,
+		SignedBlocksWindow:     10000,
+		MinSignedPercent:       50,
+		JailBlocks:             17280,
+	}
+}
+
 func getInitialState(args *genesisArgument, nodeList []node, option ethchain.ChainDriverOption, onsOption ons.Options,
 	btcOption bitcoin.ChainDriverOption, reservedDomains []reservedDomain, initialAddrs []keys.Address) consensus.AppState {
 	olt := balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
@@ -356,8 +401,11 @@ func getInitialState(args *genesisArgument, nodeList []node, option ethchain.Cha
 	ottc := balance.Currency{Id: 4, Name: "TTC", Chain: chain.TESTTOKEN, Decimal: 18, Unit: "testUnits"} //Tokens count by number ,Unit 1
 	currencies := []balance.Currency{olt, vt, obtc, oeth, ottc}
 	feeOpt := fees.FeeOption{
-		FeeCurrency:   olt,
-		MinFeeDecimal: 9,
+		FeeCurrency:              olt,
+		MinFeeDecimal:            9,
+		BaseFeeChangeDenominator: 8,
+		TargetBlockGas:           1000000,
+		BurnPercent:              10,
 	}
 	balances := make([]consensus.BalanceState, 0, len(nodeList))
 	staking := make([]consensus.Stake, 0, len(nodeList))
@@ -467,10 +515,12 @@ func getInitialState(args *genesisArgument, nodeList []node, option ethchain.Cha
 		Domains:    domains,
 		Fees:       fees_db,
 		Governance: consensus.GovernanceState{
-			FeeOption:   feeOpt,
-			ETHCDOption: option,
-			BTCCDOption: btcOption,
-			ONSOptions:  onsOption,
+			FeeOption:      feeOpt,
+			ETHCDOption:    option,
+			BTCCDOption:    btcOption,
+			ONSOptions:     onsOption,
+			PropOptions:    *getProposalOptions(),
+			StakingOptions: *getStakingOptions(vt),
 		},
 	}
 }
@@ -20,6 +20,8 @@ import (
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
@@ -209,6 +211,18 @@ func writeListWithTag(ctx app.StorageCtx, writer io.Writer, tag string) bool {
 		DumpBalanceToFile(ctx.Balances, writer, writeStruct)
 	case "staking":
 		DumpStakingToFile(ctx.Validators, writer, writeStruct)
+	case "delegations":
+		DumpDelegationsToFile(ctx.Delegations, writer, writeStruct)
+	case "unbondings":
+		DumpUnbondingsToFile(ctx.Unbondings, ctx.Version, writer, writeStruct)
+	case "multisigAccounts":
+		DumpMultiSigToFile(ctx.MultiSig, writer, writeStruct)
+	case "nonces":
+		DumpNoncesToFile(ctx.Nonces, writer, writeStruct)
+	case "feeGrants":
+		DumpFeeGrantsToFile(ctx.FeeGrants, writer, writeStruct)
+	case "supplies":
+		DumpSuppliesToFile(ctx.Supplies, writer, writeStruct)
 	case "domains":
 		DumpDomainToFile(ctx.Domains, ctx.Version, writer, writeStruct)
 	case "trackers":
@@ -297,6 +311,12 @@ func SaveChainState(application *app.App, filename string, directory string) err
 	writeStructWithTag(writer, appState.Chain, "state")
 	writeListWithTag(ctx, writer, "balances")
 	writeListWithTag(ctx, writer, "staking")
+	writeListWithTag(ctx, writer, "delegations")
+	writeListWithTag(ctx, writer, "unbondings")
+	writeListWithTag(ctx, writer, "multisigAccounts")
+	writeListWithTag(ctx, writer, "nonces")
+	writeListWithTag(ctx, writer, "feeGrants")
+	writeListWithTag(ctx, writer, "supplies")
 	writeListWithTag(ctx, writer, "domains")
 	writeListWithTag(ctx, writer, "trackers")
 	writeListWithTag(ctx, writer, "fees")
@@ -364,6 +384,127 @@ func DumpStakingToFile(vs *identity.ValidatorStore, writer io.Writer, fn func(wr
 }
 
 //Retrieves complete list of Balance records and writes them to an io stream.
+func DumpDelegationsToFile(ds *identity.DelegationStore, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	ds.Iterate(func(delegation *identity.Delegation) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		fn(writer, consensus.Delegation(*delegation))
+		iterator++
+		return false
+	})
+
+	return
+}
+
+func DumpUnbondingsToFile(us *identity.UnbondingStore, height int64, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	us.Iterate(func(unbonding *identity.Unbonding) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		// the new chain starts from height 0
+		unbondingState := consensus.Unbonding(*unbonding)
+		unbondingState.MatureHeight = unbonding.MatureHeight - height
+		if unbondingState.MatureHeight < 1 {
+			unbondingState.MatureHeight = 1
+		}
+
+		fn(writer, unbondingState)
+		iterator++
+		return false
+	})
+
+	return
+}
+
+func DumpMultiSigToFile(ms *multisig.Store, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	ms.Iterate(func(addr keys.Address, account *multisig.Account) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		fn(writer, *account)
+		iterator++
+		return false
+	})
+
+	return
+}
+
+func DumpNoncesToFile(ns *nonce.Store, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	ns.Iterate(func(addr keys.Address, n uint64) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		fn(writer, nonce.AccountNonce{Address: addr, Nonce: n})
+		iterator++
+		return false
+	})
+
+	return
+}
+
+func DumpFeeGrantsToFile(gs *fees.GrantStore, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	gs.Iterate(func(grant *fees.Grant) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		fn(writer, *grant)
+		iterator++
+		return false
+	})
+
+	return
+}
+
+func DumpSuppliesToFile(ss *balance.SupplyStore, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
+	iterator := 0
+	delimiter := ","
+	ss.Iterate(func(ts balance.TokenSupply) bool {
+		if iterator != 0 {
+			_, err := writer.Write([]byte(delimiter))
+			if err != nil {
+				return true
+			}
+		}
+
+		fn(writer, ts)
+		iterator++
+		return false
+	})
+
+	return
+}
+
 func DumpBalanceToFile(bs *balance.Store, writer io.Writer, fn func(writer io.Writer, obj interface{}) bool) {
 	iterator := 0
 	delimiter := ","
@@ -379,6 +520,18 @@ func DumpBalanceToFile(bs *balance.Store, writer io.Writer, fn func(writer io.Wr
 		balance.Amount = amt
 		balance.Currency = coin
 
+		vestings, err := bs.GetVestings(addr, coin)
+		if err == nil {
+			for _, vesting := range vestings {
+				balance.Vestings = append(balance.Vestings, consensus.VestingState{
+					Amount:      vesting.Amount,
+					StartHeight: vesting.StartHeight,
+					CliffHeight: vesting.CliffHeight,
+					EndHeight:   vesting.EndHeight,
+				})
+			}
+		}
+
 		fn(writer, balance)
 		iterator++
 		return false
@@ -487,11 +640,32 @@ func GetGovernance(gs *governance.Store) *consensus.GovernanceState {
 		return nil
 	}
 
+	propOption, err := gs.GetProposalOptions()
+	if err != nil {
+		fmt.Print("Error Reading Proposal options: ", err)
+		return nil
+	}
+
+	stakingOption, err := gs.GetStakingOptions()
+	if err != nil {
+		fmt.Print("Error Reading Staking options: ", err)
+		return nil
+	}
+
+	issuanceOption, err := gs.GetIssuanceOptions()
+	if err != nil {
+		fmt.Print("Error Reading Issuance options: ", err)
+		return nil
+	}
+
 	return &consensus.GovernanceState{
-		FeeOption:   *feeOption,
-		ETHCDOption: *ethOption,
-		BTCCDOption: *btcOption,
-		ONSOptions:  *onsOption,
+		FeeOption:       *feeOption,
+		ETHCDOption:     *ethOption,
+		BTCCDOption:     *btcOption,
+		ONSOptions:      *onsOption,
+		PropOptions:     *propOption,
+		StakingOptions:  *stakingOption,
+		IssuanceOptions: *issuanceOption,
 	}
 }
 
@@ -12,7 +12,10 @@ import (
 	"github.com/Oneledger/protocol/data/balance"
 	ethData "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/serialize"
@@ -38,16 +41,30 @@ func NewGenesisDoc(chainID string, states AppState) (*GenesisDoc, error) {
 }
 
 type GovernanceState struct {
-	FeeOption   fees.FeeOption             `json:"feeOption"`
-	ETHCDOption ethchain.ChainDriverOption `json:"ethchaindriverOption"`
-	BTCCDOption bitcoin.ChainDriverOption  `json:"bitcoinChainDriverOption"`
-	ONSOptions  ons.Options                `json:"onsOptions"`
+	FeeOption      fees.FeeOption             `json:"feeOption"`
+	ETHCDOption    ethchain.ChainDriverOption `json:"ethchaindriverOption"`
+	BTCCDOption    bitcoin.ChainDriverOption  `json:"bitcoinChainDriverOption"`
+	ONSOptions     ons.Options                `json:"onsOptions"`
+	PropOptions    governance.ProposalOptions `json:"propOptions"`
+	StakingOptions identity.Options           `json:"stakingOptions"`
+	// Optional, no currency can be issued without an issuer
+	IssuanceOptions balance.IssuanceOptions `json:"issuanceOptions"`
 }
 
 type BalanceState struct {
 	Address  keys.Address   `json:"address"`
 	Currency string         `json:"currency"`
 	Amount   balance.Amount `json:"amount"`
+	// Optional schedules locking part of the balance
+	Vestings []VestingState `json:"vestings,omitempty"`
+}
+
+// VestingState is a vesting schedule of an initial balance, see balance.Vesting
+type VestingState struct {
+	Amount      balance.Amount `json:"amount"`
+	StartHeight int64          `json:"startHeight"`
+	CliffHeight int64          `json:"cliffHeight"`
+	EndHeight   int64          `json:"endHeight"`
 }
 
 type DomainState struct {
@@ -63,7 +80,7 @@ type DomainState struct {
 	SalePrice        *balance.Amount `json:"salePrice"`
 }
 
-//TODO: Create More Generic Struct to contain all tracker types.
+// TODO: Create More Generic Struct to contain all tracker types.
 type Tracker struct {
 	Type          ethData.ProcessType  `json:"type"`
 	State         ethData.TrackerState `json:"state"`
@@ -82,6 +99,10 @@ type ChainState struct {
 
 type Stake identity.Stake
 
+type Delegation identity.Delegation
+
+type Unbonding identity.Unbonding
+
 type AppState struct {
 	Currencies balance.Currencies `json:"currencies"`
 	Governance GovernanceState    `json:"governance"`
@@ -91,6 +112,15 @@ type AppState struct {
 	Domains    []DomainState      `json:"domains"`
 	Trackers   []Tracker          `json:"trackers"`
 	Fees       []BalanceState     `json:"fees"`
+
+	Delegations []Delegation `json:"delegations"`
+	Unbondings  []Unbonding  `json:"unbondings"`
+
+	MultiSigAccounts []multisig.Account   `json:"multisigAccounts"`
+	Nonces           []nonce.AccountNonce `json:"nonces"`
+	FeeGrants        []fees.Grant         `json:"feeGrants"`
+	// Supplies of the currencies, computed from the initial balances if they are not given
+	Supplies []balance.TokenSupply `json:"supplies"`
 }
 
 func NewAppState(currencies balance.Currencies,
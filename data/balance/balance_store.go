@@ -15,6 +15,7 @@ Copyright 2017 - 2019 OneLedger
 package balance
 
 import (
+	"bytes"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -27,12 +28,20 @@ import (
 type Store struct {
 	State  *storage.State
 	prefix []byte
+	// vesting schedules are kept next to the balances, under their own prefix
+	vestingPrefix []byte
+	// total held of every currency, and the holders of every currency ordered by balance
+	holdingsPrefix []byte
+	richPrefix     []byte
 }
 
 func NewStore(prefix string, state *storage.State) *Store {
 	return &Store{
-		State:  state,
-		prefix: storage.Prefix(prefix),
+		State:          state,
+		prefix:         storage.Prefix(prefix),
+		vestingPrefix:  storage.Prefix(prefix + "v"),
+		holdingsPrefix: storage.Prefix(prefix + "s"),
+		richPrefix:     storage.Prefix(prefix + "r"),
 	}
 }
 
@@ -69,6 +78,10 @@ func (st *Store) iterate(addr keys.Address, fn func(c string, amt Amount) bool)
 		storage.Rangefix(string(append(st.prefix, addr...))),
 		true,
 		func(key, value []byte) bool {
+			// the range also covers other stores whose prefix starts with the same letter
+			if !bytes.HasPrefix(key, st.prefix) {
+				return false
+			}
 			amt := NewAmount(0)
 			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, amt)
 			if err != nil {
@@ -86,6 +99,9 @@ func (st *Store) IterateAll(fn func(addr keys.Address, c string, amt Amount) boo
 		storage.Rangefix(string(st.prefix)),
 		true,
 		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, st.prefix) {
+				return false
+			}
 			amt := NewAmount(0)
 			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, amt)
 			if err != nil {
@@ -118,10 +134,39 @@ func (st *Store) AddToAddress(addr keys.Address, coin Coin) error {
 	//fmt.Println("add to", addr.String())
 	newCoin := base.Plus(coin)
 
+	err = st.track(addr, coin.Currency.Name, *amt, *newCoin.Amount)
+	if err != nil {
+		return err
+	}
 	return st.set(key, *newCoin.Amount)
 }
 
+// MinusFromAddress takes the coin from the spendable balance of the address, coins locked by a vesting schedule
+// can't be spent
 func (st *Store) MinusFromAddress(addr keys.Address, coin Coin) error {
+	return st.minus(addr, coin, true)
+}
+
+// MinusStakeFromAddress takes the coin from the balance of the address to stake it, staking may use coins which
+// are still locked by a vesting schedule. The staked locked coins keep counting toward the lock.
+func (st *Store) MinusStakeFromAddress(addr keys.Address, coin Coin) error {
+	err := st.minus(addr, coin, false)
+	if err != nil {
+		return err
+	}
+	return st.delegateVesting(addr, coin)
+}
+
+// AddStakeToAddress pays stake back to the balance of the address, see MinusStakeFromAddress
+func (st *Store) AddStakeToAddress(addr keys.Address, coin Coin) error {
+	err := st.AddToAddress(addr, coin)
+	if err != nil {
+		return err
+	}
+	return st.undelegateVesting(addr, coin)
+}
+
+func (st *Store) minus(addr keys.Address, coin Coin, checkLocked bool) error {
 	key := storage.StoreKey(string(addr) + storage.DB_PREFIX + coin.Currency.Name)
 
 	amt, err := st.get(key)
@@ -136,11 +181,31 @@ func (st *Store) MinusFromAddress(addr keys.Address, coin Coin) error {
 	if err != nil {
 		return errors.Wrapf(err, "minus from address: %s, balance: %s, coin: %s", addr.String(), base.String(), coin.String())
 	}
+	if checkLocked {
+		err = st.checkLocked(addr, newCoin)
+		if err != nil {
+			return err
+		}
+	}
 
+	err = st.track(addr, coin.Currency.Name, *amt, *newCoin.Amount)
+	if err != nil {
+		return err
+	}
 	return st.set(key, *newCoin.Amount)
 }
 
+// CheckBalanceFromAddress checks the spendable balance of the address covers the coin
 func (st *Store) CheckBalanceFromAddress(addr keys.Address, coin Coin) error {
+	return st.check(addr, coin, true)
+}
+
+// CheckStakeFromAddress checks the balance of the address, including locked coins, covers the stake
+func (st *Store) CheckStakeFromAddress(addr keys.Address, coin Coin) error {
+	return st.check(addr, coin, false)
+}
+
+func (st *Store) check(addr keys.Address, coin Coin, checkLocked bool) error {
 	key := storage.StoreKey(string(addr) + storage.DB_PREFIX + coin.Currency.Name)
 
 	amt, err := st.get(key)
@@ -150,10 +215,13 @@ func (st *Store) CheckBalanceFromAddress(addr keys.Address, coin Coin) error {
 
 	base := coin.Currency.NewCoinFromAmount(*amt)
 	//fmt.Println("check balance", addr.String())
-	_, err = base.Minus(coin)
+	left, err := base.Minus(coin)
 	if err != nil {
 		return errors.Wrap(err, "minus from address")
 	}
+	if checkLocked {
+		return st.checkLocked(addr, left)
+	}
 
 	return nil
 }
@@ -69,5 +69,6 @@ func TestNewStore(t *testing.T) {
 		cnt++
 		return false
 	})
-	assert.Equal(t, 1, cnt)
+	// the balance, the total held of the currency and the entry in the rich list
+	assert.Equal(t, 3, cnt)
 }
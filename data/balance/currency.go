@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"math"
 	"math/big"
+	"sync"
 
 	"github.com/Oneledger/protocol/utils"
 
@@ -125,13 +126,18 @@ func (c Currency) NewCoinFromBytes(amount []byte) Coin {
 type CurrencySet struct {
 	nameMap map[string]Currency
 	idMap   map[int64]Currency
+	// currencies registered by tx are added while the set is read by the services
+	lock *sync.RWMutex
 }
 
 func NewCurrencySet() *CurrencySet {
-	return &CurrencySet{nameMap: make(map[string]Currency), idMap: make(map[int64]Currency)}
+	return &CurrencySet{nameMap: make(map[string]Currency), idMap: make(map[int64]Currency), lock: &sync.RWMutex{}}
 }
 
 func (cl *CurrencySet) Register(c Currency) error {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+
 	_, ok := cl.nameMap[c.Name]
 	if ok { // If the currency is already registered, return a duplicate error
 		return ErrDuplicateCurrency
@@ -142,22 +148,34 @@ func (cl *CurrencySet) Register(c Currency) error {
 }
 
 func (cl *CurrencySet) GetCurrencyByName(name string) (Currency, bool) {
+	cl.lock.RLock()
+	defer cl.lock.RUnlock()
+
 	c, ok := cl.nameMap[name]
 	return c, ok
 }
 
 func (cl *CurrencySet) GetCurrencyById(id int64) (Currency, bool) {
+	cl.lock.RLock()
+	defer cl.lock.RUnlock()
+
 	c, ok := cl.idMap[id]
 	return c, ok
 }
 
 func (cl CurrencySet) Len() int {
+	cl.lock.RLock()
+	defer cl.lock.RUnlock()
+
 	return len(cl.nameMap)
 }
 
 type Currencies []Currency
 
 func (c CurrencySet) GetCurrencies() Currencies {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	result := make([]Currency, len(c.nameMap))
 	i := 0
 	for _, v := range c.nameMap {
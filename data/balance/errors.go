@@ -23,4 +23,6 @@ var (
 	ErrMismatchingCurrency = errors.New("mismatching currencies")
 
 	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrBalanceLocked       = errors.New("balance is locked by vesting")
+	ErrSupplyCapExceeded   = errors.New("supply cap of the currency exceeded")
 )
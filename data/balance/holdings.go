@@ -0,0 +1,103 @@
+package balance
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// width of the balances in the keys of the rich list, zero padded so the keys sort by balance
+const richAmountWidth = 80
+
+// track follows a change of the balance of the address in the total held of the currency and in the rich list
+func (st *Store) track(addr keys.Address, currency string, old, new Amount) error {
+	total, err := st.GetHoldings(currency)
+	if err != nil {
+		return err
+	}
+	sum := big.NewInt(0).Sub(total.BigInt(), old.BigInt())
+	sum.Add(sum, new.BigInt())
+
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(NewAmountFromBigInt(sum))
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize holdings")
+	}
+	err = st.State.Set(append(st.holdingsPrefix, storage.StoreKey(currency)...), dat)
+	if err != nil {
+		return err
+	}
+
+	if old.BigInt().Sign() > 0 {
+		_, err = st.State.Delete(st.richKey(currency, old, addr))
+		if err != nil {
+			return errors.Wrap(err, "failed to remove holder")
+		}
+	}
+	if new.BigInt().Sign() > 0 {
+		err = st.State.Set(st.richKey(currency, new, addr), addr)
+		if err != nil {
+			return errors.Wrap(err, "failed to add holder")
+		}
+	}
+	return nil
+}
+
+func (st *Store) richCurrencyPrefix(currency string) []byte {
+	return storage.StoreKey(string(st.richPrefix) + currency + storage.DB_PREFIX)
+}
+
+func (st *Store) richKey(currency string, amt Amount, addr keys.Address) storage.StoreKey {
+	return append(st.richCurrencyPrefix(currency), HolderKey(amt, addr)...)
+}
+
+// HolderKey returns the position of the holder in the rich list of a currency
+func HolderKey(amt Amount, addr keys.Address) []byte {
+	padded := fmt.Sprintf("%0*s", richAmountWidth, amt.BigInt().String())
+	return []byte(padded + storage.DB_PREFIX + string(addr))
+}
+
+// GetHoldings returns the total of the currency held in all the balances
+func (st *Store) GetHoldings(currency string) (*Amount, error) {
+	dat, err := st.State.Get(append(st.holdingsPrefix, storage.StoreKey(currency)...))
+	if err != nil {
+		return nil, err
+	}
+	amt := NewAmount(0)
+	if len(dat) == 0 {
+		return amt, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, amt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize holdings")
+	}
+	return amt, nil
+}
+
+// IterateHolders goes through the addresses holding the currency from the holder key start on, from the largest
+// balance down, or from the smallest up when reversed. An empty start begins at the top of the list.
+func (st *Store) IterateHolders(currency string, start []byte, reverse bool, fn func(addr keys.Address, amt Amount) bool) bool {
+	prefix := st.richCurrencyPrefix(currency)
+	from, to := storage.PageRange(prefix, start, reverse)
+	return st.State.IterateRange(
+		from,
+		to,
+		reverse,
+		func(key, value []byte) bool {
+			// the range also covers currencies whose name starts with this one
+			if !bytes.HasPrefix(key, prefix) || len(key) < len(prefix)+richAmountWidth {
+				return false
+			}
+			amt, ok := big.NewInt(0).SetString(string(key[len(prefix):len(prefix)+richAmountWidth]), 10)
+			if !ok {
+				return false
+			}
+			return fn(keys.Address(value), *NewAmountFromBigInt(amt))
+		},
+	)
+}
@@ -0,0 +1,67 @@
+package balance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestStore_Holdings(t *testing.T) {
+	olt := Currency{Id: 0, Name: "OLT", Chain: 0, Decimal: 18, Unit: "nue"}
+	oltx := Currency{Id: 1, Name: "OLTX", Chain: 0, Decimal: 18, Unit: "x"}
+	cs := storage.NewState(storage.NewChainState("holdings", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("b", cs)
+	a := keys.Address([]byte("holder-address-a0000"))
+	b := keys.Address([]byte("holder-address-b0000"))
+	c := keys.Address([]byte("holder-address-c0000"))
+
+	assert.NoError(t, store.AddToAddress(a, olt.NewCoinFromAmount(*NewAmount(100))))
+	assert.NoError(t, store.AddToAddress(b, olt.NewCoinFromAmount(*NewAmount(300))))
+	assert.NoError(t, store.AddToAddress(c, olt.NewCoinFromAmount(*NewAmount(50))))
+	assert.NoError(t, store.AddToAddress(c, oltx.NewCoinFromAmount(*NewAmount(1000))))
+	assert.NoError(t, store.MinusFromAddress(b, olt.NewCoinFromAmount(*NewAmount(100))))
+	assert.NoError(t, store.AddToAddress(a, olt.NewCoinFromAmount(*NewAmount(150))))
+	assert.NoError(t, store.MinusFromAddress(c, olt.NewCoinFromAmount(*NewAmount(50))))
+	cs.Commit()
+
+	total, err := store.GetHoldings("OLT")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 450, total.BigInt().Int64())
+
+	holders := make([]keys.Address, 0)
+	amounts := make([]int64, 0)
+	store.IterateHolders("OLT", nil, false, func(addr keys.Address, amt Amount) bool {
+		holders = append(holders, addr)
+		amounts = append(amounts, amt.BigInt().Int64())
+		return false
+	})
+	// an emptied balance leaves the rich list, other currencies are not in it
+	assert.Equal(t, []keys.Address{a, b}, holders)
+	assert.Equal(t, []int64{250, 200}, amounts)
+
+	// walking up the list from the top holder, and down from the second one
+	holders = holders[:0]
+	store.IterateHolders("OLT", HolderKey(*NewAmount(250), a), true, func(addr keys.Address, amt Amount) bool {
+		holders = append(holders, addr)
+		return false
+	})
+	assert.Equal(t, []keys.Address{a}, holders)
+	holders = holders[:0]
+	store.IterateHolders("OLT", HolderKey(*NewAmount(200), b), false, func(addr keys.Address, amt Amount) bool {
+		holders = append(holders, addr)
+		return false
+	})
+	assert.Equal(t, []keys.Address{b}, holders)
+
+	// the holdings are not part of the balances
+	cnt := 0
+	store.IterateAll(func(addr keys.Address, c string, amt Amount) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 4, cnt)
+}
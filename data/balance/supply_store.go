@@ -0,0 +1,195 @@
+package balance
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const MaxCurrencyDecimal = 18
+
+// IssuanceOptions names the address allowed to register, mint and burn currencies, it is set by governance
+type IssuanceOptions struct {
+	Issuer keys.Address `json:"issuer"`
+}
+
+// TokenSupply is the amount of a currency in circulation
+type TokenSupply struct {
+	Currency Currency `json:"currency"`
+	Supply   Amount   `json:"supply"`
+	// most of the currency that can be in circulation, 0 for no cap
+	Cap Amount `json:"cap"`
+	// currencies registered by the issuer can be minted and burnt by it
+	Mintable bool `json:"mintable"`
+}
+
+func (ts TokenSupply) canAdd(amt Amount) bool {
+	if ts.Cap.BigInt().Sign() == 0 {
+		return true
+	}
+	total := big.NewInt(0).Add(ts.Supply.BigInt(), amt.BigInt())
+	return total.Cmp(ts.Cap.BigInt()) <= 0
+}
+
+// ValidateCurrency checks a currency registered by tx is well formed
+func ValidateCurrency(c Currency) error {
+	if len(c.Name) == 0 || len(c.Unit) == 0 {
+		return errors.New("currency needs a name and a unit")
+	}
+	if strings.ContainsAny(c.Name, storage.DB_PREFIX+storage.DB_RANGEFIX) {
+		return errors.New("invalid currency name")
+	}
+	if c.Decimal < 0 || c.Decimal > MaxCurrencyDecimal {
+		return errors.Errorf("currency decimal must be between 0 and %d", MaxCurrencyDecimal)
+	}
+	return nil
+}
+
+type SupplyStore struct {
+	state  *storage.State
+	prefix []byte
+	// key of the counter the ids of new currencies come from
+	idKey   []byte
+	options *IssuanceOptions
+}
+
+func NewSupplyStore(prefix string, state *storage.State) *SupplyStore {
+	return &SupplyStore{
+		state:   state,
+		prefix:  storage.Prefix(prefix),
+		idKey:   storage.Prefix(prefix + "id"),
+		options: &IssuanceOptions{},
+	}
+}
+
+func (ss *SupplyStore) WithState(state *storage.State) *SupplyStore {
+	ss.state = state
+	return ss
+}
+
+func (ss *SupplyStore) SetOptions(opt *IssuanceOptions) {
+	ss.options = opt
+}
+
+func (ss *SupplyStore) GetOptions() *IssuanceOptions {
+	return ss.options
+}
+
+// Get returns the supply of the currency, nil if it isn't tracked
+func (ss *SupplyStore) Get(currency string) (*TokenSupply, error) {
+	dat, err := ss.state.Get(append(ss.prefix, storage.StoreKey(currency)...))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return nil, nil
+	}
+	ts := &TokenSupply{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, ts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize token supply")
+	}
+	return ts, nil
+}
+
+func (ss *SupplyStore) Set(ts TokenSupply) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(ts)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize token supply")
+	}
+	return ss.state.Set(append(ss.prefix, storage.StoreKey(ts.Currency.Name)...), dat)
+}
+
+// Register starts tracking the supply of a new mintable currency
+func (ss *SupplyStore) Register(c Currency, cap Amount) error {
+	existing, err := ss.Get(c.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrDuplicateCurrency
+	}
+	return ss.Set(TokenSupply{Currency: c, Supply: *NewAmount(0), Cap: cap, Mintable: true})
+}
+
+// Add puts more of the currency in circulation, the supply is tracked from here if it wasn't yet
+func (ss *SupplyStore) Add(c Currency, amt Amount) error {
+	ts, err := ss.Get(c.Name)
+	if err != nil {
+		return err
+	}
+	if ts == nil {
+		ts = &TokenSupply{Currency: c, Supply: *NewAmount(0), Cap: *NewAmount(0)}
+	}
+	if !ts.canAdd(amt) {
+		return ErrSupplyCapExceeded
+	}
+	ts.Supply = *NewAmountFromBigInt(big.NewInt(0).Add(ts.Supply.BigInt(), amt.BigInt()))
+	return ss.Set(*ts)
+}
+
+// Remove takes the amount of the currency out of circulation, nothing happens if its supply isn't tracked
+func (ss *SupplyStore) Remove(c Currency, amt Amount) error {
+	ts, err := ss.Get(c.Name)
+	if err != nil || ts == nil {
+		return err
+	}
+	left := big.NewInt(0).Sub(ts.Supply.BigInt(), amt.BigInt())
+	if left.Sign() < 0 {
+		return ErrInsufficientBalance
+	}
+	ts.Supply = *NewAmountFromBigInt(left)
+	return ss.Set(*ts)
+}
+
+// AssignCurrencyId returns an id for a new currency, larger than the ids of all the currencies in the set and of the
+// ones assigned before
+func (ss *SupplyStore) AssignCurrencyId(set *CurrencySet) (int64, error) {
+	next := int64(0)
+	dat, err := ss.state.Get(ss.idKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(dat) > 0 {
+		err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, &next)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to deserialize currency id")
+		}
+	}
+	for _, c := range set.GetCurrencies() {
+		if c.Id >= next {
+			next = c.Id + 1
+		}
+	}
+
+	dat, err = serialize.GetSerializer(serialize.PERSISTENT).Serialize(next + 1)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to serialize currency id")
+	}
+	return next, ss.state.Set(ss.idKey, dat)
+}
+
+func (ss *SupplyStore) Iterate(fn func(ts TokenSupply) bool) bool {
+	return ss.state.IterateRange(
+		ss.prefix,
+		storage.Rangefix(string(ss.prefix)),
+		true,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, ss.prefix) {
+				return false
+			}
+			ts := TokenSupply{}
+			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, &ts)
+			if err != nil {
+				return true
+			}
+			return fn(ts)
+		},
+	)
+}
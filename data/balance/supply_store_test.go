@@ -0,0 +1,70 @@
+package balance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestSupplyStore(t *testing.T) {
+	olt := Currency{Id: 0, Name: "OLT", Chain: 0, Decimal: 18, Unit: "nue"}
+	tok := Currency{Id: 5, Name: "TOK", Chain: 0, Decimal: 6, Unit: "utok"}
+	cs := storage.NewState(storage.NewChainState("supply", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewSupplyStore("supply", cs)
+
+	// supplies of the genesis currencies are tracked without a cap
+	assert.NoError(t, store.Add(olt, *NewAmount(1000)))
+	assert.NoError(t, store.Remove(olt, *NewAmount(100)))
+	assert.Error(t, store.Remove(olt, *NewAmount(1000)))
+	assert.NoError(t, store.Remove(tok, *NewAmount(100)))
+
+	assert.NoError(t, store.Register(tok, *NewAmount(500)))
+	assert.Equal(t, ErrDuplicateCurrency, store.Register(tok, *NewAmount(500)))
+	assert.NoError(t, store.Add(tok, *NewAmount(500)))
+	assert.Equal(t, ErrSupplyCapExceeded, store.Add(tok, *NewAmount(1)))
+	cs.Commit()
+
+	ts, err := store.Get("OLT")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 900, ts.Supply.BigInt().Int64())
+	assert.False(t, ts.Mintable)
+
+	ts, err = store.Get("TOK")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, ts.Supply.BigInt().Int64())
+	assert.True(t, ts.Mintable)
+
+	ts, err = store.Get("BTC")
+	assert.NoError(t, err)
+	assert.Nil(t, ts)
+
+	supplies := make([]string, 0)
+	store.Iterate(func(ts TokenSupply) bool {
+		supplies = append(supplies, ts.Currency.Name)
+		return false
+	})
+	assert.Equal(t, []string{"OLT", "TOK"}, supplies)
+}
+
+func TestSupplyStore_AssignCurrencyId(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("supply", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewSupplyStore("supply", cs)
+	set := NewCurrencySet()
+	assert.NoError(t, set.Register(Currency{Id: 2, Name: "VT", Chain: 0, Unit: "vt"}))
+
+	id, err := store.AssignCurrencyId(set)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, id)
+
+	// the currency isn't in the set until the block is committed, the next one still gets a new id
+	id, err = store.AssignCurrencyId(set)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, id)
+
+	assert.Error(t, ValidateCurrency(Currency{Name: "A_B", Unit: "ab"}))
+	assert.Error(t, ValidateCurrency(Currency{Name: "AB", Unit: "ab", Decimal: 19}))
+	assert.NoError(t, ValidateCurrency(Currency{Name: "AB", Unit: "ab", Decimal: 8}))
+}
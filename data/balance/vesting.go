@@ -0,0 +1,232 @@
+package balance
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// Vesting locks an amount of a currency in the balance of an address. Nothing unlocks before the cliff height, from
+// there the amount unlocks linearly from the start height on, and all of it is spendable at the end height. A pure
+// cliff schedule has the cliff at the end height.
+type Vesting struct {
+	Address     keys.Address `json:"address"`
+	Currency    string       `json:"currency"`
+	Amount      Amount       `json:"amount"`
+	StartHeight int64        `json:"startHeight"`
+	CliffHeight int64        `json:"cliffHeight"`
+	EndHeight   int64        `json:"endHeight"`
+}
+
+func (v Vesting) Validate() error {
+	if v.Address.Err() != nil {
+		return errors.Wrap(v.Address.Err(), "invalid vesting address")
+	}
+	if v.Amount.BigInt().Sign() <= 0 {
+		return errors.New("vesting amount must be positive")
+	}
+	if v.StartHeight < 0 || v.CliffHeight < v.StartHeight || v.EndHeight < v.CliffHeight || v.EndHeight <= v.StartHeight {
+		return errors.New("vesting heights must be start <= cliff <= end, with start < end")
+	}
+	return nil
+}
+
+// Locked returns the part of the amount which is still locked at the height
+func (v Vesting) Locked(height int64) *big.Int {
+	switch {
+	case height < v.CliffHeight:
+		return big.NewInt(0).Set(v.Amount.BigInt())
+	case height >= v.EndHeight:
+		return big.NewInt(0)
+	}
+	locked := big.NewInt(0).Mul(v.Amount.BigInt(), big.NewInt(v.EndHeight-height))
+	return locked.Quo(locked, big.NewInt(v.EndHeight-v.StartHeight))
+}
+
+// VestingAccount holds the vesting schedules of an address in a currency. Anyone can send coins under a schedule, so
+// schedules are kept side by side and the locked amount is their sum. Locked coins can be staked, like in cosmos
+// vesting accounts DelegatedVesting is the part of the stake taken from locked coins and DelegatedFree the part taken
+// from spendable ones.
+type VestingAccount struct {
+	Schedules        []Vesting `json:"schedules"`
+	DelegatedVesting Amount    `json:"delegatedVesting"`
+	DelegatedFree    Amount    `json:"delegatedFree"`
+}
+
+// Locked returns the sum of what the schedules still lock at the height
+func (va *VestingAccount) Locked(height int64) *big.Int {
+	locked := big.NewInt(0)
+	for _, v := range va.Schedules {
+		locked.Add(locked, v.Locked(height))
+	}
+	return locked
+}
+
+func (st *Store) vestingKey(addr keys.Address, currency string) storage.StoreKey {
+	return append(append([]byte{}, st.vestingPrefix...), storage.StoreKey(string(addr)+storage.DB_PREFIX+currency)...)
+}
+
+func (st *Store) getVestingAccount(addr keys.Address, currency string) (*VestingAccount, error) {
+	va := &VestingAccount{
+		Schedules:        make([]Vesting, 0),
+		DelegatedVesting: *NewAmount(0),
+		DelegatedFree:    *NewAmount(0),
+	}
+	dat, err := st.State.Get(st.vestingKey(addr, currency))
+	if err != nil {
+		return nil, err
+	}
+	if len(dat) == 0 {
+		return va, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, va)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize vesting")
+	}
+	return va, nil
+}
+
+func (st *Store) setVestingAccount(addr keys.Address, currency string, va *VestingAccount) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(va)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize vesting")
+	}
+	return st.State.Set(st.vestingKey(addr, currency), dat)
+}
+
+// GetVestings returns the vesting schedules of the address in the currency
+func (st *Store) GetVestings(addr keys.Address, currency string) ([]Vesting, error) {
+	va, err := st.getVestingAccount(addr, currency)
+	if err != nil {
+		return nil, err
+	}
+	return va.Schedules, nil
+}
+
+// AddVesting locks part of the balance of the address under the schedule, on top of the schedules it already has.
+// Schedules which have fully vested are dropped.
+func (st *Store) AddVesting(v Vesting) error {
+	err := v.Validate()
+	if err != nil {
+		return err
+	}
+	va, err := st.getVestingAccount(v.Address, v.Currency)
+	if err != nil {
+		return err
+	}
+
+	schedules := make([]Vesting, 0, len(va.Schedules)+1)
+	for _, schedule := range va.Schedules {
+		if schedule.Locked(st.height()).Sign() > 0 {
+			schedules = append(schedules, schedule)
+		}
+	}
+	va.Schedules = append(schedules, v)
+	return st.setVestingAccount(v.Address, v.Currency, va)
+}
+
+// IterateVestings goes through the vesting schedules of all addresses
+func (st *Store) IterateVestings(fn func(v Vesting) bool) bool {
+	return st.State.IterateRange(
+		st.vestingPrefix,
+		storage.Rangefix(string(st.vestingPrefix)),
+		true,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, st.vestingPrefix) {
+				return false
+			}
+			va := &VestingAccount{}
+			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, va)
+			if err != nil {
+				return true
+			}
+			for _, v := range va.Schedules {
+				if fn(v) {
+					return true
+				}
+			}
+			return false
+		},
+	)
+}
+
+// GetLocked returns the coins of the address in the currency which are locked at the current height and not staked
+func (st *Store) GetLocked(addr keys.Address, curr *Currency) (Coin, error) {
+	va, err := st.getVestingAccount(addr, curr.Name)
+	if err != nil {
+		return Coin{}, err
+	}
+	locked := va.Locked(st.height())
+	locked.Sub(locked, va.DelegatedVesting.BigInt())
+	if locked.Sign() < 0 {
+		locked.SetInt64(0)
+	}
+	return curr.NewCoinFromAmount(*NewAmountFromBigInt(locked)), nil
+}
+
+// checkLocked checks the balance left after spending still covers the locked coins
+func (st *Store) checkLocked(addr keys.Address, left Coin) error {
+	locked, err := st.GetLocked(addr, &left.Currency)
+	if err != nil {
+		return err
+	}
+	if left.Amount.BigInt().Cmp(locked.Amount.BigInt()) < 0 {
+		return errors.Wrapf(ErrBalanceLocked, "address: %s, locked: %s", addr.String(), locked.String())
+	}
+	return nil
+}
+
+// delegateVesting records a stake taken from the balance, locked coins are staked first
+func (st *Store) delegateVesting(addr keys.Address, coin Coin) error {
+	va, err := st.getVestingAccount(addr, coin.Currency.Name)
+	if err != nil || len(va.Schedules) == 0 {
+		return err
+	}
+
+	lockedLeft := va.Locked(st.height())
+	lockedLeft.Sub(lockedLeft, va.DelegatedVesting.BigInt())
+	if lockedLeft.Sign() < 0 {
+		lockedLeft.SetInt64(0)
+	}
+	vesting := minInt(coin.Amount.BigInt(), lockedLeft)
+	free := big.NewInt(0).Sub(coin.Amount.BigInt(), vesting)
+
+	va.DelegatedVesting = *NewAmountFromBigInt(big.NewInt(0).Add(va.DelegatedVesting.BigInt(), vesting))
+	va.DelegatedFree = *NewAmountFromBigInt(big.NewInt(0).Add(va.DelegatedFree.BigInt(), free))
+	return st.setVestingAccount(addr, coin.Currency.Name, va)
+}
+
+// undelegateVesting records a stake paid back to the balance, spendable coins come back first
+func (st *Store) undelegateVesting(addr keys.Address, coin Coin) error {
+	va, err := st.getVestingAccount(addr, coin.Currency.Name)
+	if err != nil {
+		return err
+	}
+	if va.DelegatedFree.BigInt().Sign() == 0 && va.DelegatedVesting.BigInt().Sign() == 0 {
+		return nil
+	}
+
+	free := minInt(coin.Amount.BigInt(), va.DelegatedFree.BigInt())
+	vesting := minInt(big.NewInt(0).Sub(coin.Amount.BigInt(), free), va.DelegatedVesting.BigInt())
+
+	va.DelegatedFree = *NewAmountFromBigInt(big.NewInt(0).Sub(va.DelegatedFree.BigInt(), free))
+	va.DelegatedVesting = *NewAmountFromBigInt(big.NewInt(0).Sub(va.DelegatedVesting.BigInt(), vesting))
+	return st.setVestingAccount(addr, coin.Currency.Name, va)
+}
+
+func minInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return big.NewInt(0).Set(a)
+	}
+	return big.NewInt(0).Set(b)
+}
+
+// height is the height of the block being run on the state, one more than the last committed version
+func (st *Store) height() int64 {
+	return st.State.Version() + 1
+}
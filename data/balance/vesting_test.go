@@ -0,0 +1,96 @@
+package balance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestVesting_Locked(t *testing.T) {
+	v := Vesting{Amount: *NewAmount(1000), StartHeight: 100, CliffHeight: 150, EndHeight: 200}
+
+	assert.EqualValues(t, 1000, v.Locked(10).Int64())
+	assert.EqualValues(t, 1000, v.Locked(149).Int64())
+	assert.EqualValues(t, 500, v.Locked(150).Int64())
+	assert.EqualValues(t, 10, v.Locked(199).Int64())
+	assert.EqualValues(t, 0, v.Locked(200).Int64())
+
+	// a pure cliff
+	v.CliffHeight = 200
+	assert.EqualValues(t, 1000, v.Locked(199).Int64())
+	assert.EqualValues(t, 0, v.Locked(200).Int64())
+
+	v.CliffHeight = 50
+	assert.Error(t, v.Validate())
+}
+
+func TestStore_Vesting(t *testing.T) {
+	olt := Currency{Id: 0, Name: "OLT", Chain: 0, Decimal: 18, Unit: "nue"}
+	cs := storage.NewState(storage.NewChainState("vesting", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("b", cs)
+	addr := keys.Address([]byte("vesting-address-0000"))
+
+	assert.NoError(t, store.AddToAddress(addr, olt.NewCoinFromAmount(*NewAmount(1500))))
+	// the version is 0 so the next block has height 1, the vesting unlocks between height 2 and 12
+	assert.NoError(t, store.AddVesting(Vesting{Address: addr, Currency: "OLT", Amount: *NewAmount(1000),
+		StartHeight: 2, CliffHeight: 2, EndHeight: 12}))
+	cs.Commit()
+
+	// anyone can add a schedule, it locks on top of the first one
+	assert.NoError(t, store.AddVesting(Vesting{Address: addr, Currency: "OLT", Amount: *NewAmount(10),
+		StartHeight: 2, CliffHeight: 2, EndHeight: 12}))
+	vestings, err := store.GetVestings(addr, "OLT")
+	assert.NoError(t, err)
+	assert.Len(t, vestings, 2)
+
+	// only 490 is spendable
+	assert.Error(t, store.CheckBalanceFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(491))))
+	assert.Error(t, store.MinusFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(491))))
+	assert.NoError(t, store.MinusFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(490))))
+
+	// the locked coins can still be staked, and keep counting toward the lock
+	assert.NoError(t, store.CheckStakeFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(600))))
+	assert.NoError(t, store.MinusStakeFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(600))))
+	locked, err := store.GetLocked(addr, &olt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 410, locked.Amount.BigInt().Int64())
+	assert.Error(t, store.MinusFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(1))))
+
+	// coins received later stay spendable
+	assert.NoError(t, store.AddToAddress(addr, olt.NewCoinFromAmount(*NewAmount(200))))
+	assert.NoError(t, store.MinusFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(200))))
+
+	// the stake paid back is locked again
+	assert.NoError(t, store.AddStakeToAddress(addr, olt.NewCoinFromAmount(*NewAmount(600))))
+	assert.Error(t, store.MinusFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(1))))
+	assert.NoError(t, store.MinusStakeFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(600))))
+	cs.Commit()
+
+	// at height 8 404 is still locked, less than what is staked
+	for i := 0; i < 5; i++ {
+		cs.Commit()
+	}
+	assert.NoError(t, store.MinusFromAddress(addr, olt.NewCoinFromAmount(*NewAmount(410))))
+	cs.Commit()
+
+	cnt := 0
+	store.IterateVestings(func(v Vesting) bool {
+		assert.Equal(t, addr, v.Address)
+		cnt++
+		return false
+	})
+	assert.Equal(t, 2, cnt)
+
+	// vesting schedules don't show up as balances
+	cnt = 0
+	store.IterateAll(func(a keys.Address, c string, amt Amount) bool {
+		assert.Equal(t, "OLT", c)
+		cnt++
+		return false
+	})
+	assert.Equal(t, 1, cnt)
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
 )
 
 const (
@@ -15,6 +16,17 @@ type FeeOption struct {
 	FeeCurrency   balance.Currency `json:"feeCurrency"`
 	MinFeeDecimal int64            `json:"minFeeDecimal"`
 
+	// the base fee moves by at most 1/BaseFeeChangeDenominator per block, the base fee stays at the minimal fee
+	// if it is 0
+	BaseFeeChangeDenominator int64 `json:"baseFeeChangeDenominator"`
+	// gas used by a block at which the base fee doesn't change, half of the block gas limit if it is 0
+	TargetBlockGas int64 `json:"targetBlockGas"`
+
+	// percentages of the fees of every block which are burnt and paid to the treasury, validators get the rest
+	BurnPercent     int64        `json:"burnPercent"`
+	TreasuryPercent int64        `json:"treasuryPercent"`
+	TreasuryAddress keys.Address `json:"treasuryAddress"`
+
 	minimalFee *balance.Coin
 }
 
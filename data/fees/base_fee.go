@@ -0,0 +1,102 @@
+package fees
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const BASE_FEE_KEY = "basefee"
+
+// GetBaseFee returns the minimal gas price of a tx in the current block, it is never below the minimal fee
+func (st *Store) GetBaseFee() (balance.Coin, error) {
+	minFee := st.feeOpt.MinFee()
+
+	dat, err := st.state.Get(append(st.prefix, storage.StoreKey(BASE_FEE_KEY)...))
+	if err != nil {
+		return balance.Coin{}, errors.Wrap(err, "failed to get base fee")
+	}
+	if len(dat) == 0 {
+		return minFee, nil
+	}
+	amt := balance.NewAmount(0)
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, amt)
+	if err != nil {
+		return balance.Coin{}, errors.Wrap(err, "failed to deserialize base fee")
+	}
+	if amt.BigInt().Cmp(minFee.Amount.BigInt()) < 0 {
+		return minFee, nil
+	}
+	return st.feeOpt.FeeCurrency.NewCoinFromAmount(*amt), nil
+}
+
+func (st *Store) SetBaseFee(amt balance.Amount) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(&amt)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize base fee")
+	}
+	return st.state.Set(append(st.prefix, storage.StoreKey(BASE_FEE_KEY)...), dat)
+}
+
+// UpdateBaseFee moves the base fee towards the price at which blocks use the target gas, from the gas used by the
+// last block and its gas limit, like the base fee of EIP-1559
+func (st *Store) UpdateBaseFee(used, limit storage.Gas) error {
+	next, ok, err := st.nextBaseFee(used, limit)
+	if err != nil || !ok {
+		return err
+	}
+	return st.SetBaseFee(next)
+}
+
+func (st *Store) nextBaseFee(used, limit storage.Gas) (balance.Amount, bool, error) {
+	denominator := st.feeOpt.BaseFeeChangeDenominator
+	if denominator <= 0 {
+		return balance.Amount{}, false, nil
+	}
+	target := st.feeOpt.TargetBlockGas
+	if target <= 0 {
+		// a block without gas limit has no target to aim at
+		if limit <= 0 || limit == math.MaxInt64 {
+			return balance.Amount{}, false, nil
+		}
+		target = int64(limit) / 2
+		if target == 0 {
+			return balance.Amount{}, false, nil
+		}
+	}
+
+	baseFee, err := st.GetBaseFee()
+	if err != nil {
+		return balance.Amount{}, false, err
+	}
+	current := baseFee.Amount.BigInt()
+	diff := big.NewInt(int64(used) - target)
+
+	// current * |used - target| / target / denominator
+	delta := big.NewInt(0).Mul(current, big.NewInt(0).Abs(diff))
+	delta.Quo(delta, big.NewInt(target))
+	delta.Quo(delta, big.NewInt(denominator))
+
+	next := big.NewInt(0)
+	switch diff.Sign() {
+	case 0:
+		next.Set(current)
+	case 1:
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		next.Add(current, delta)
+	default:
+		next.Sub(current, delta)
+	}
+
+	if minFee := st.feeOpt.MinFee().Amount.BigInt(); next.Cmp(minFee) < 0 {
+		next.Set(minFee)
+	}
+	return *balance.NewAmountFromBigInt(next), true, nil
+}
@@ -0,0 +1,61 @@
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestStore_UpdateBaseFee(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("basefee", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("f", state)
+	store.SetupOpt(&FeeOption{
+		FeeCurrency:              balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"},
+		MinFeeDecimal:            9,
+		BaseFeeChangeDenominator: 8,
+	})
+	minFee := store.GetOpt().MinFee().Amount.BigInt().Int64()
+	assert.EqualValues(t, minFee, baseFee(t, store))
+
+	// no gas limit, nothing to aim at
+	assert.NoError(t, store.UpdateBaseFee(1000, math.MaxInt64))
+	assert.EqualValues(t, minFee, baseFee(t, store))
+
+	// a full block raises the base fee by 1/8
+	assert.NoError(t, store.UpdateBaseFee(1000, 1000))
+	state.Commit()
+	assert.EqualValues(t, minFee+minFee/8, baseFee(t, store))
+
+	// a block at the target keeps it
+	assert.NoError(t, store.UpdateBaseFee(500, 1000))
+	state.Commit()
+	assert.EqualValues(t, minFee+minFee/8, baseFee(t, store))
+
+	// empty blocks bring it down, but never below the minimal fee
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, store.UpdateBaseFee(0, 1000))
+		state.Commit()
+	}
+	assert.EqualValues(t, minFee, baseFee(t, store))
+
+	// fee pool balances are not affected by the base fee
+	cnt := 0
+	store.Iterate(func(_ keys.Address, _ balance.Coin) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 0, cnt)
+}
+
+func baseFee(t *testing.T, store *Store) int64 {
+	fee, err := store.GetBaseFee()
+	assert.NoError(t, err)
+	return fee.Amount.BigInt().Int64()
+}
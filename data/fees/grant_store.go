@@ -0,0 +1,132 @@
+package fees
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var (
+	ErrGrantNotFound = errors.New("fee grant not found")
+	ErrGrantExpired  = errors.New("fee grant expired")
+	ErrGrantExceeded = errors.New("fee exceeds the grant spend limit")
+)
+
+// Grant lets the granter pay the fees of the grantee's txs, until the spend limit is used up or the grant expires
+type Grant struct {
+	Granter keys.Address `json:"granter"`
+	Grantee keys.Address `json:"grantee"`
+	// fee amount the grantee can still spend, in the fee currency
+	SpendLimit balance.Amount `json:"spendLimit"`
+	// the grant can't be used after this height, 0 means it never expires
+	Expiry int64 `json:"expiry"`
+}
+
+func (g *Grant) IsExpired(height int64) bool {
+	return g.Expiry > 0 && height > g.Expiry
+}
+
+// GrantStore keeps the fee grants by granter and grantee
+type GrantStore struct {
+	state  *storage.State
+	prefix []byte
+	szlr   serialize.Serializer
+}
+
+func NewGrantStore(prefix string, state *storage.State) *GrantStore {
+	return &GrantStore{
+		state:  state,
+		prefix: storage.Prefix(prefix),
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+	}
+}
+
+func (st *GrantStore) WithState(state *storage.State) *GrantStore {
+	st.state = state
+	return st
+}
+
+func (st *GrantStore) key(granter, grantee keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(st.prefix) + granter.String() + storage.DB_PREFIX + grantee.String())
+}
+
+func (st *GrantStore) Get(granter, grantee keys.Address) (*Grant, error) {
+	value, err := st.state.Get(st.key(granter, grantee))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get fee grant")
+	}
+	if len(value) == 0 {
+		return nil, ErrGrantNotFound
+	}
+
+	grant := &Grant{}
+	err = st.szlr.Deserialize(value, grant)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize fee grant")
+	}
+	return grant, nil
+}
+
+// Set adds the grant, replacing any earlier grant between the same addresses
+func (st *GrantStore) Set(grant Grant) error {
+	value, err := st.szlr.Serialize(grant)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize fee grant")
+	}
+	return st.state.Set(st.key(grant.Granter, grant.Grantee), value)
+}
+
+func (st *GrantStore) Delete(granter, grantee keys.Address) error {
+	ok, err := st.state.Delete(st.key(granter, grantee))
+	if err != nil {
+		return errors.Wrap(err, "failed to delete fee grant")
+	}
+	if !ok {
+		return ErrGrantNotFound
+	}
+	return nil
+}
+
+// UseAllowance takes the fee out of the spend limit of the grant at the height, the grant is removed once it is
+// used up
+func (st *GrantStore) UseAllowance(granter, grantee keys.Address, fee balance.Amount, height int64) error {
+	grant, err := st.Get(granter, grantee)
+	if err != nil {
+		return err
+	}
+	if grant.IsExpired(height) {
+		return ErrGrantExpired
+	}
+
+	left := big.NewInt(0).Sub(grant.SpendLimit.BigInt(), fee.BigInt())
+	if left.Sign() < 0 {
+		return ErrGrantExceeded
+	}
+	if left.Sign() == 0 {
+		return st.Delete(granter, grantee)
+	}
+
+	grant.SpendLimit = *balance.NewAmountFromBigInt(left)
+	return st.Set(*grant)
+}
+
+func (st *GrantStore) Iterate(fn func(grant *Grant) bool) (stopped bool) {
+	return st.state.IterateRange(
+		st.prefix,
+		storage.Rangefix(string(st.prefix)),
+		true,
+		func(key, value []byte) bool {
+			grant := &Grant{}
+			err := st.szlr.Deserialize(value, grant)
+			if err != nil {
+				return false
+			}
+			return fn(grant)
+		},
+	)
+}
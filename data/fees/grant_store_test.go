@@ -0,0 +1,39 @@
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestGrantStore_UseAllowance(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("grants", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewGrantStore("fg", state)
+
+	granter := keys.Address([]byte("granter-address-0000"))
+	grantee := keys.Address([]byte("grantee-address-0000"))
+
+	assert.Equal(t, ErrGrantNotFound, store.UseAllowance(granter, grantee, *balance.NewAmount(1), 1))
+
+	assert.NoError(t, store.Set(Grant{Granter: granter, Grantee: grantee, SpendLimit: *balance.NewAmount(100), Expiry: 10}))
+	state.Commit()
+
+	assert.NoError(t, store.UseAllowance(granter, grantee, *balance.NewAmount(60), 5))
+	assert.Equal(t, ErrGrantExceeded, store.UseAllowance(granter, grantee, *balance.NewAmount(50), 5))
+	assert.Equal(t, ErrGrantExpired, store.UseAllowance(granter, grantee, *balance.NewAmount(10), 11))
+
+	grant, err := store.Get(granter, grantee)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 40, grant.SpendLimit.BigInt().Int64())
+
+	// the grant is gone once it is used up
+	assert.NoError(t, store.UseAllowance(granter, grantee, *balance.NewAmount(40), 10))
+	state.Commit()
+	_, err = store.Get(granter, grantee)
+	assert.Equal(t, ErrGrantNotFound, err)
+}
@@ -0,0 +1,137 @@
+package fees
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+const SPLIT_TOTAL_KEY = "feesplit"
+
+// FeeSplit is how the fees of a block, or all the blocks so far, were shared out
+type FeeSplit struct {
+	Burnt      balance.Amount `json:"burnt"`
+	Treasury   balance.Amount `json:"treasury"`
+	Validators balance.Amount `json:"validators"`
+}
+
+func (fs FeeSplit) Tags(height int64) kv.Pairs {
+	return kv.Pairs{
+		{Key: []byte("fees.height"), Value: []byte(strconv.FormatInt(height, 10))},
+		{Key: []byte("fees.burnt"), Value: []byte(fs.Burnt.String())},
+		{Key: []byte("fees.treasury"), Value: []byte(fs.Treasury.String())},
+		{Key: []byte("fees.validators"), Value: []byte(fs.Validators.String())},
+	}
+}
+
+// SplitBlockFees burns and pays the treasury its share of the fees collected in the current block, the validators'
+// share is left in the pool for the validator set to distribute. The fees of the block are what the pool gained
+// since the last commit.
+func (st *Store) SplitBlockFees() (FeeSplit, error) {
+	split := FeeSplit{
+		Burnt:      *balance.NewAmount(0),
+		Treasury:   *balance.NewAmount(0),
+		Validators: *balance.NewAmount(0),
+	}
+	burnPercent, treasuryPercent := st.feeOpt.BurnPercent, st.feeOpt.TreasuryPercent
+	if len(st.feeOpt.TreasuryAddress) == 0 {
+		treasuryPercent = 0
+	}
+	if burnPercent < 0 || treasuryPercent < 0 || burnPercent+treasuryPercent > 100 {
+		return split, errors.New("invalid fee split percentages")
+	}
+
+	pool, err := st.Get([]byte(POOL_KEY))
+	if err != nil {
+		return split, err
+	}
+	previous := st.committedPool()
+	collected := big.NewInt(0).Sub(pool.Amount.BigInt(), previous.BigInt())
+	if collected.Sign() <= 0 {
+		return split, nil
+	}
+
+	burnt := big.NewInt(0).Mul(collected, big.NewInt(burnPercent))
+	burnt.Quo(burnt, big.NewInt(100))
+	treasury := big.NewInt(0).Mul(collected, big.NewInt(treasuryPercent))
+	treasury.Quo(treasury, big.NewInt(100))
+
+	split.Burnt = *balance.NewAmountFromBigInt(burnt)
+	split.Treasury = *balance.NewAmountFromBigInt(treasury)
+	split.Validators = *balance.NewAmountFromBigInt(big.NewInt(0).Sub(collected, big.NewInt(0).Add(burnt, treasury)))
+
+	if burnt.Sign() > 0 {
+		err = st.MinusFromPool(st.feeOpt.FeeCurrency.NewCoinFromAmount(split.Burnt))
+		if err != nil {
+			return split, errors.Wrap(err, "failed to burn fees")
+		}
+	}
+	if treasury.Sign() > 0 {
+		coin := st.feeOpt.FeeCurrency.NewCoinFromAmount(split.Treasury)
+		err = st.MinusFromPool(coin)
+		if err != nil {
+			return split, errors.Wrap(err, "failed to take treasury fees")
+		}
+		err = st.AddToAddress(st.feeOpt.TreasuryAddress, coin)
+		if err != nil {
+			return split, errors.Wrap(err, "failed to pay treasury fees")
+		}
+	}
+
+	totals := st.GetSplitTotals()
+	totals.Burnt = addAmount(totals.Burnt, split.Burnt)
+	totals.Treasury = addAmount(totals.Treasury, split.Treasury)
+	totals.Validators = addAmount(totals.Validators, split.Validators)
+	err = st.setSplitTotals(totals)
+	if err != nil {
+		return split, err
+	}
+	return split, nil
+}
+
+// GetSplitTotals returns the fees burnt, paid to the treasury and left to validators over all the blocks
+func (st *Store) GetSplitTotals() FeeSplit {
+	totals := FeeSplit{
+		Burnt:      *balance.NewAmount(0),
+		Treasury:   *balance.NewAmount(0),
+		Validators: *balance.NewAmount(0),
+	}
+	dat, _ := st.state.Get(append(st.prefix, storage.StoreKey(SPLIT_TOTAL_KEY)...))
+	if len(dat) == 0 {
+		return totals
+	}
+	_ = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, &totals)
+	return totals
+}
+
+func (st *Store) setSplitTotals(totals FeeSplit) error {
+	dat, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(totals)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize fee split totals")
+	}
+	return st.state.Set(append(st.prefix, storage.StoreKey(SPLIT_TOTAL_KEY)...), dat)
+}
+
+func (st *Store) committedPool() balance.Amount {
+	key := append(st.prefix, storage.StoreKey(POOL_KEY)...)
+	amt := balance.NewAmount(0)
+	dat := st.state.GetVersioned(st.state.Version(), key)
+	if len(dat) == 0 {
+		return *amt
+	}
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(dat, amt)
+	if err != nil {
+		return *balance.NewAmount(0)
+	}
+	return *amt
+}
+
+func addAmount(a, b balance.Amount) balance.Amount {
+	return *balance.NewAmountFromBigInt(big.NewInt(0).Add(a.BigInt(), b.BigInt()))
+}
@@ -0,0 +1,77 @@
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestStore_SplitBlockFees(t *testing.T) {
+	state := storage.NewState(storage.NewChainState("feesplit", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("f", state)
+	treasury := keys.Address([]byte("treasury-address-000"))
+	olt := balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+	store.SetupOpt(&FeeOption{
+		FeeCurrency:     olt,
+		MinFeeDecimal:   9,
+		BurnPercent:     10,
+		TreasuryPercent: 20,
+		TreasuryAddress: treasury,
+	})
+
+	// the pool carried over from earlier blocks isn't split again
+	assert.NoError(t, store.AddToPool(olt.NewCoinFromAmount(*balance.NewAmount(50))))
+	state.Commit()
+
+	assert.NoError(t, store.AddToPool(olt.NewCoinFromAmount(*balance.NewAmount(1000))))
+	split, err := store.SplitBlockFees()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 100, split.Burnt.BigInt().Int64())
+	assert.EqualValues(t, 200, split.Treasury.BigInt().Int64())
+	assert.EqualValues(t, 700, split.Validators.BigInt().Int64())
+	state.Commit()
+
+	pool, err := store.Get([]byte(POOL_KEY))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 750, pool.Amount.BigInt().Int64())
+	paid, err := store.Get(treasury)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, paid.Amount.BigInt().Int64())
+
+	// no fees in this block
+	split, err = store.SplitBlockFees()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, split.Burnt.BigInt().Int64())
+	state.Commit()
+
+	assert.NoError(t, store.AddToPool(olt.NewCoinFromAmount(*balance.NewAmount(10))))
+	_, err = store.SplitBlockFees()
+	assert.NoError(t, err)
+	state.Commit()
+
+	totals := store.GetSplitTotals()
+	assert.EqualValues(t, 101, totals.Burnt.BigInt().Int64())
+	assert.EqualValues(t, 202, totals.Treasury.BigInt().Int64())
+	assert.EqualValues(t, 707, totals.Validators.BigInt().Int64())
+
+	// the totals are kept with the store, but are not a balance of the fee pool
+	dat, err := state.Get(append(store.prefix, storage.StoreKey(SPLIT_TOTAL_KEY)...))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, dat)
+	cnt := 0
+	store.Iterate(func(_ keys.Address, _ balance.Coin) bool {
+		cnt++
+		return false
+	})
+	assert.Equal(t, 2, cnt)
+
+	store.GetOpt().BurnPercent = 90
+	_, err = store.SplitBlockFees()
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package fees
 
 import (
+	"bytes"
+
 	"github.com/pkg/errors"
 
 	"github.com/Oneledger/protocol/data/balance"
@@ -74,6 +76,15 @@ func (st *Store) Iterate(fn func(addr keys.Address, coin balance.Coin) (stop boo
 		storage.Rangefix(string(st.prefix)),
 		true,
 		func(key, value []byte) bool {
+			// the range also covers other stores whose prefix starts with the same letter
+			if !bytes.HasPrefix(key, st.prefix) {
+				return false
+			}
+			addr := key[len(st.prefix):]
+			// the base fee and the fee split totals are kept under the prefix as well
+			if keys.Address(addr).Err() != nil {
+				return false
+			}
 			amt := &balance.Amount{}
 			coin := balance.Coin{}
 			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, amt)
@@ -83,7 +94,6 @@ func (st *Store) Iterate(fn func(addr keys.Address, coin balance.Coin) (stop boo
 			if st.feeOpt != nil {
 				coin = st.feeOpt.FeeCurrency.NewCoinFromAmount(*amt)
 			}
-			addr := key[len(st.prefix):]
 			return fn(addr, coin)
 		},
 	)
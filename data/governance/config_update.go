@@ -0,0 +1,152 @@
+package governance
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/chains/bitcoin"
+	ethchain "github.com/Oneledger/protocol/chains/ethereum"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/identity"
+)
+
+// ConfigUpdate is the payload carried by a ConfigUpdate proposal, every option that is set replaces the current one
+// once the chain reaches the activation height
+type ConfigUpdate struct {
+	ActivationHeight int64                       `json:"activationHeight"`
+	FeeOption        *fees.FeeOption             `json:"feeOption,omitempty"`
+	ONSOptions       *ons.Options                `json:"onsOptions,omitempty"`
+	ETHCDOption      *ethchain.ChainDriverOption `json:"ethchaindriverOption,omitempty"`
+	BTCCDOption      *bitcoin.ChainDriverOption  `json:"bitcoinChainDriverOption,omitempty"`
+	PropOptions      *ProposalOptions            `json:"propOptions,omitempty"`
+	StakingOptions   *identity.Options           `json:"stakingOptions,omitempty"`
+	IssuanceOptions  *balance.IssuanceOptions    `json:"issuanceOptions,omitempty"`
+}
+
+// IsEmpty returns true if the update doesn't change any option
+func (cu *ConfigUpdate) IsEmpty() bool {
+	return cu.FeeOption == nil && cu.ONSOptions == nil && cu.ETHCDOption == nil && cu.BTCCDOption == nil &&
+		cu.PropOptions == nil && cu.StakingOptions == nil && cu.IssuanceOptions == nil
+}
+
+var firstLevelDomain = regexp.MustCompile(`^[a-zA-Z]{2,11}$`)
+
+// Validate checks every option set in the update, an update that passes is safe to install at the activation height
+func (cu *ConfigUpdate) Validate(currencies *balance.CurrencySet) error {
+	if cu.FeeOption != nil {
+		if err := validateFeeOption(cu.FeeOption, currencies); err != nil {
+			return errors.Wrap(err, "fee option")
+		}
+	}
+	if cu.ONSOptions != nil {
+		if err := validateONSOptions(cu.ONSOptions, currencies); err != nil {
+			return errors.Wrap(err, "ons options")
+		}
+	}
+	if cu.PropOptions != nil {
+		for _, opt := range []ProposalOption{cu.PropOptions.ConfigUpdate, cu.PropOptions.CodeChange, cu.PropOptions.General} {
+			if err := validateProposalOption(opt); err != nil {
+				return errors.Wrap(err, "proposal options")
+			}
+		}
+	}
+	if cu.StakingOptions != nil {
+		if err := validateStakingOptions(cu.StakingOptions); err != nil {
+			return errors.Wrap(err, "staking options")
+		}
+	}
+	if cu.IssuanceOptions != nil {
+		if err := cu.IssuanceOptions.Issuer.Err(); err != nil {
+			return errors.Wrap(err, "issuance options")
+		}
+	}
+	return nil
+}
+
+func validateFeeOption(opt *fees.FeeOption, currencies *balance.CurrencySet) error {
+	currency, ok := currencies.GetCurrencyByName(opt.FeeCurrency.Name)
+	if !ok {
+		return errors.Errorf("currency %s is not registered", opt.FeeCurrency.Name)
+	}
+	if opt.MinFeeDecimal < 0 || opt.MinFeeDecimal > currency.Decimal {
+		return errors.New("minimal fee decimal is out of range")
+	}
+	if opt.BaseFeeChangeDenominator < 0 || opt.TargetBlockGas < 0 {
+		return errors.New("base fee parameters can't be negative")
+	}
+	if !isPercent(opt.BurnPercent) || !isPercent(opt.TreasuryPercent) || opt.BurnPercent+opt.TreasuryPercent > 100 {
+		return errors.New("fee split percentages are out of range")
+	}
+	if opt.TreasuryPercent > 0 && opt.TreasuryAddress.Err() != nil {
+		return errors.Wrap(opt.TreasuryAddress.Err(), "treasury address")
+	}
+	return nil
+}
+
+func validateONSOptions(opt *ons.Options, currencies *balance.CurrencySet) error {
+	if _, ok := currencies.GetCurrencyByName(opt.Currency); !ok {
+		return errors.Errorf("currency %s is not registered", opt.Currency)
+	}
+	if opt.PerBlockFees.BigInt().Sign() <= 0 || opt.BaseDomainPrice.BigInt().Sign() <= 0 {
+		return errors.New("domain prices must be positive")
+	}
+	if opt.MinAuctionBid != nil && opt.MinAuctionBid.BigInt().Sign() < 0 {
+		return errors.New("minimal auction bid can't be negative")
+	}
+	if len(opt.FirstLevelDomains) == 0 {
+		return errors.New("no first level domains")
+	}
+	for _, domain := range opt.FirstLevelDomains {
+		if !firstLevelDomain.MatchString(domain) {
+			return errors.Errorf("invalid first level domain %s", domain)
+		}
+	}
+	if opt.MaxRecords < 0 || opt.MaxRecordSize < 0 || opt.AuctionCommitBlocks < 0 || opt.AuctionRevealBlocks < 0 ||
+		opt.AuctionFinalizeBlocks < 0 || opt.GracePeriodBlocks < 0 {
+		return errors.New("limits and windows can't be negative")
+	}
+	return nil
+}
+
+func validateProposalOption(opt ProposalOption) error {
+	if opt.InitialFunding == nil || opt.FundingGoal == nil {
+		return errors.New("funding amounts are not set")
+	}
+	if opt.InitialFunding.BigInt().Sign() < 0 || opt.FundingGoal.BigInt().Sign() <= 0 {
+		return errors.New("funding amounts are out of range")
+	}
+	if opt.FundingDeadline <= 0 || opt.VotingDeadline <= 0 {
+		return errors.New("deadlines must be positive")
+	}
+	if opt.PassPercentage <= 0 || opt.PassPercentage > 100 {
+		return errors.New("pass percentage is out of range")
+	}
+	return nil
+}
+
+func validateStakingOptions(opt *identity.Options) error {
+	if !isPercent(opt.CommissionRate) || !isPercent(opt.MaxPowerPercent) || !isPercent(opt.DoubleSignSlashPercent) ||
+		!isPercent(opt.DowntimeSlashPercent) || !isPercent(opt.MinSignedPercent) {
+		return errors.New("percentages are out of range")
+	}
+	if opt.UnbondingBlocks <= 0 {
+		return errors.New("unbonding blocks must be positive")
+	}
+	if opt.SignedBlocksWindow < 0 || opt.JailBlocks < 0 {
+		return errors.New("windows can't be negative")
+	}
+	if opt.PowerReduction != nil && opt.PowerReduction.BigInt().Sign() <= 0 {
+		return errors.New("power reduction must be positive")
+	}
+	if len(opt.SlashRecipient) > 0 && opt.SlashRecipient.Err() != nil {
+		return errors.Wrap(opt.SlashRecipient.Err(), "slash recipient")
+	}
+	return nil
+}
+
+func isPercent(p int64) bool {
+	return p >= 0 && p <= 100
+}
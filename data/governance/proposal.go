@@ -1,44 +1,60 @@
 package governance
 
 import (
-	"crypto/md5"
 	"encoding/hex"
+
+	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/keys"
-	"time"
 )
 
 const EmptyStr = ""
 
-type options struct {
-	InitialFunding  int64
-	FundingDeadline int64
-	FundingGoal     int64
-	VotingDeadline  int64
+type ProposalOption struct {
+	InitialFunding  *balance.Amount `json:"initialFunding"`
+	FundingGoal     *balance.Amount `json:"fundingGoal"`
+	FundingDeadline int64           `json:"fundingDeadline"`
+	VotingDeadline  int64           `json:"votingDeadline"`
+	PassPercentage  int64           `json:"passPercentage"`
 }
 
 type ProposalOptions struct {
-	ConfigUpdate options
-	CodeChange   options
-	General      options
+	ConfigUpdate ProposalOption `json:"configUpdate"`
+	CodeChange   ProposalOption `json:"codeChange"`
+	General      ProposalOption `json:"general"`
+}
+
+// GetOption returns the option set for the given proposal type, nil if the type is unknown
+func (po *ProposalOptions) GetOption(propType ProposalType) *ProposalOption {
+	switch propType {
+	case ProposalTypeConfigUpdate:
+		return &po.ConfigUpdate
+	case ProposalTypeCodeChange:
+		return &po.CodeChange
+	case ProposalTypeGeneral:
+		return &po.General
+	}
+	return nil
 }
 
 type Proposal struct {
-	ProposalID      ProposalID
-	Type            ProposalType
-	Status          ProposalStatus
-	Outcome         ProposalOutcome
-	Description     string
-	Proposer        keys.Address
-	FundingDeadline int64
-	FundingGoal     int64
-	VotingDeadline  int64
+	ProposalID      ProposalID      `json:"proposalId"`
+	Type            ProposalType    `json:"proposalType"`
+	Status          ProposalStatus  `json:"status"`
+	Outcome         ProposalOutcome `json:"outcome"`
+	Description     string          `json:"descr"`
+	Proposer        keys.Address    `json:"proposer"`
+	FundingDeadline int64           `json:"fundingDeadline"`
+	FundingGoal     *balance.Amount `json:"fundingGoal"`
+	VotingDeadline  int64           `json:"votingDeadline"`
+	PassPercentage  int64           `json:"passPercentage"`
+	ConfigUpdate    *ConfigUpdate   `json:"configUpdate,omitempty"`
 }
 
-func NewProposal(propType ProposalType, desc string, proposer keys.Address, fundingDeadline int64, fundingGoal int64,
-	votingDeadline int64) *Proposal {
+func NewProposal(proposalID ProposalID, propType ProposalType, desc string, proposer keys.Address, fundingDeadline int64,
+	fundingGoal *balance.Amount, votingDeadline int64, passPercentage int64) *Proposal {
 
 	return &Proposal{
-		ProposalID:      generateProposalID(proposer.String()),
+		ProposalID:      proposalID,
 		Type:            propType,
 		Status:          ProposalStatusFunding,
 		Outcome:         ProposalOutcomeInProgress,
@@ -47,15 +63,12 @@ func NewProposal(propType ProposalType, desc string, proposer keys.Address, fund
 		FundingDeadline: fundingDeadline,
 		FundingGoal:     fundingGoal,
 		VotingDeadline:  votingDeadline,
+		PassPercentage:  passPercentage,
 	}
 }
 
-func generateProposalID(key string) ProposalID {
-	uniqueKey := key + time.Now().String()
-	hashHandler := md5.New()
-	_, err := hashHandler.Write([]byte(uniqueKey))
-	if err != nil {
-		return EmptyStr
-	}
-	return ProposalID(hex.EncodeToString(hashHandler.Sum(nil)))
+// NewProposalID derives the id of a proposal from the hash of the transaction creating it, so every validator
+// computes the same id
+func NewProposalID(txHash []byte) ProposalID {
+	return ProposalID(hex.EncodeToString(txHash))
 }
@@ -1,8 +1,6 @@
 package governance
 
 import (
-	"bytes"
-	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -63,12 +61,17 @@ func (pf *ProposalFundStore) iterate(fn func(proposalID ProposalID, addr keys.Ad
 			amt := NewAmount(0)
 			err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(value, amt)
 			if err != nil {
-				fmt.Println("err", err)
-				return true
+				// skip records removed in the current block
+				return false
 			}
 			arr := strings.Split(string(key), storage.DB_PREFIX)
 			proposalID := arr[1]
-			fundingAddress := keys.Address(arr[len(arr)-1])
+			fundingAddress := keys.Address{}
+			err = fundingAddress.UnmarshalText([]byte(arr[len(arr)-1]))
+			if err != nil {
+				logger.Error("failed to decode funding address", err)
+				return false
+			}
 			return fn(ProposalID(proposalID), fundingAddress, amt)
 		},
 	)
@@ -82,6 +85,11 @@ func NewProposalFundStore(prefix string, state *storage.State) *ProposalFundStor
 	}
 }
 
+func (pf *ProposalFundStore) WithState(state *storage.State) *ProposalFundStore {
+	pf.State = state
+	return pf
+}
+
 func (pf *ProposalFundStore) GetFundersForProposalID(id ProposalID, fn func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) ProposalFund) []ProposalFund {
 	var foundProposals []ProposalFund
 	pf.iterate(func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) bool {
@@ -96,7 +104,7 @@ func (pf *ProposalFundStore) GetFundersForProposalID(id ProposalID, fn func(prop
 func (pf *ProposalFundStore) GetProposalsForFunder(funderAddress keys.Address, fn func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) ProposalFund) []ProposalFund {
 	var foundProposals []ProposalFund
 	pf.iterate(func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) bool {
-		if bytes.Equal(keys.Address(funderAddress.String()), fundingAddr) {
+		if fundingAddr.Equal(funderAddress) {
 			foundProposals = append(foundProposals, fn(proposalID, fundingAddr, amt))
 		}
 		return false
@@ -125,3 +133,44 @@ func (pf *ProposalFundStore) DeleteFunds(proposalId ProposalID, fundingAddress k
 	}
 	return ok, nil
 }
+
+// Get the amount an address has contributed to a proposal
+func (pf *ProposalFundStore) GetFundsForProposalByFunder(proposalId ProposalID, fundingAddress keys.Address) (*ProposalAmount, error) {
+	key := storage.StoreKey(string(proposalId) + storage.DB_PREFIX + fundingAddress.String())
+	return pf.get(key)
+}
+
+// Get the total amount raised by a proposal
+func (pf *ProposalFundStore) GetCurrentFunds(id ProposalID) *ProposalAmount {
+	totalFunds := NewAmount(0)
+	pf.iterate(func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) bool {
+		if proposalID == id {
+			totalFunds = totalFunds.Plus(amt)
+		}
+		return false
+	})
+	return totalFunds
+}
+
+// Get the total amount held for all proposals
+func (pf *ProposalFundStore) GetTotalFunds() *ProposalAmount {
+	totalFunds := NewAmount(0)
+	pf.iterate(func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) bool {
+		totalFunds = totalFunds.Plus(amt)
+		return false
+	})
+	return totalFunds
+}
+
+// Remove every contribution made to a proposal
+func (pf *ProposalFundStore) DeleteAllFunds(id ProposalID) error {
+	var err error
+	pf.iterate(func(proposalID ProposalID, fundingAddr keys.Address, amt *ProposalAmount) bool {
+		if proposalID != id {
+			return false
+		}
+		_, err = pf.DeleteFunds(proposalID, fundingAddr)
+		return err != nil
+	})
+	return err
+}
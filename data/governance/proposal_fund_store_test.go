@@ -9,6 +9,7 @@ import (
 
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/utils"
 )
 
 var (
@@ -32,8 +33,8 @@ func init() {
 	pub2, _, _ := keys.NewKeyPairFromTendermint()
 	h2, _ := pub2.GetHandler()
 	address2 = h2.Address()
-	ID1 = generateProposalID("Test")
-	ID2 = generateProposalID("Test")
+	ID1 = NewProposalID(utils.SHA2([]byte("Test1")))
+	ID2 = NewProposalID(utils.SHA2([]byte("Test2")))
 }
 
 func TestProposalFundStore_AddFunds(t *testing.T) {
@@ -107,3 +108,26 @@ func TestProposalFundStore_GetProposalForFunder(t *testing.T) {
 	}
 	assert.EqualValues(t, 2, len(funds), "")
 }
+
+func TestProposalFundStore_GetCurrentFunds(t *testing.T) {
+	fmt.Println("Get Current Funds for ID :  ", ID2)
+	funds := store.GetCurrentFunds(ID2)
+	assert.EqualValues(t, 220, funds.BigInt().Int64(), "")
+
+	amt, err := store.GetFundsForProposalByFunder(ID2, address2)
+	assert.NoError(t, err, "")
+	assert.EqualValues(t, 120, amt.BigInt().Int64(), "")
+}
+
+func TestProposalFundStore_GetTotalFunds(t *testing.T) {
+	total := store.GetCurrentFunds(ID1).Plus(store.GetCurrentFunds(ID2))
+	assert.EqualValues(t, total.BigInt().Int64(), store.GetTotalFunds().BigInt().Int64(), "")
+}
+
+func TestProposalFundStore_DeleteAllFunds(t *testing.T) {
+	fmt.Println("Deleting all funds for ID :  ", ID2)
+	err := store.DeleteAllFunds(ID2)
+	assert.NoError(t, err, "")
+	cs.Commit()
+	assert.EqualValues(t, 0, store.GetCurrentFunds(ID2).BigInt().Int64(), "")
+}
@@ -0,0 +1,25 @@
+package governance
+
+import "github.com/Oneledger/protocol/storage"
+
+// ProposalMasterStore groups the stores that together hold the state of on-chain proposals
+type ProposalMasterStore struct {
+	Proposal     *ProposalStore
+	ProposalFund *ProposalFundStore
+	ProposalVote *ProposalVoteStore
+}
+
+func NewProposalMasterStore(p *ProposalStore, pf *ProposalFundStore, pv *ProposalVoteStore) *ProposalMasterStore {
+	return &ProposalMasterStore{
+		Proposal:     p,
+		ProposalFund: pf,
+		ProposalVote: pv,
+	}
+}
+
+func (p *ProposalMasterStore) WithState(state *storage.State) *ProposalMasterStore {
+	p.Proposal.WithState(state)
+	p.ProposalFund.WithState(state)
+	p.ProposalVote.WithState(state)
+	return p
+}
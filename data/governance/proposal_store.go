@@ -139,6 +139,16 @@ func (ps *ProposalStore) QueryAllStores(key ProposalID) (*Proposal, ProposalStat
 	return nil, ProposalStateError, errors.Wrap(err, errorGettingRecord)
 }
 
+// Move a proposal from one state prefix to another
+func (ps *ProposalStore) Transition(proposal *Proposal, from ProposalState, to ProposalState) error {
+	err := ps.WithPrefixType(to).Set(proposal)
+	if err != nil {
+		return err
+	}
+	_, err = ps.WithPrefixType(from).Delete(proposal.ProposalID)
+	return err
+}
+
 func (ps *ProposalStore) SetOptions(pOpt *ProposalOptions) {
 	ps.proposalOptions = pOpt
 }
@@ -151,10 +161,10 @@ func NewProposalStore(prefixActive string, prefixPassed string, prefixFailed str
 	return &ProposalStore{
 		state:           state,
 		szlr:            serialize.GetSerializer(serialize.PERSISTENT),
-		prefix:          []byte(prefixActive),
-		prefixActive:    []byte(prefixActive),
-		prefixPassed:    []byte(prefixPassed),
-		prefixFailed:    []byte(prefixFailed),
+		prefix:          storage.Prefix(prefixActive),
+		prefixActive:    storage.Prefix(prefixActive),
+		prefixPassed:    storage.Prefix(prefixPassed),
+		prefixFailed:    storage.Prefix(prefixFailed),
 		proposalOptions: &ProposalOptions{},
 	}
 }
@@ -2,8 +2,10 @@ package governance
 
 import (
 	"fmt"
+	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/storage"
+	"github.com/Oneledger/protocol/utils"
 	"github.com/stretchr/testify/assert"
 	db "github.com/tendermint/tm-db"
 	"testing"
@@ -38,25 +40,28 @@ func init() {
 	}
 
 	//Create new proposal options
-	proposalOpt.CodeChange = options{
-		InitialFunding:  codeChange,
+	proposalOpt.CodeChange = ProposalOption{
+		InitialFunding:  balance.NewAmount(codeChange),
 		FundingDeadline: codeChange,
-		FundingGoal:     codeChange,
+		FundingGoal:     balance.NewAmount(codeChange),
 		VotingDeadline:  codeChange,
+		PassPercentage:  codeChange,
 	}
 
-	proposalOpt.ConfigUpdate = options{
-		InitialFunding:  configUpdate,
+	proposalOpt.ConfigUpdate = ProposalOption{
+		InitialFunding:  balance.NewAmount(configUpdate),
 		FundingDeadline: configUpdate,
-		FundingGoal:     configUpdate,
+		FundingGoal:     balance.NewAmount(configUpdate),
 		VotingDeadline:  configUpdate,
+		PassPercentage:  configUpdate,
 	}
 
-	proposalOpt.General = options{
-		InitialFunding:  general,
+	proposalOpt.General = ProposalOption{
+		InitialFunding:  balance.NewAmount(general),
 		FundingDeadline: general,
-		FundingGoal:     general,
+		FundingGoal:     balance.NewAmount(general),
 		VotingDeadline:  general,
+		PassPercentage:  general,
 	}
 
 	//Create new proposals
@@ -66,7 +71,7 @@ func init() {
 
 		proposer := addrList[j]
 
-		var opt options
+		var opt ProposalOption
 		switch ProposalType(k) {
 		case ProposalTypeConfigUpdate:
 			opt = proposalOpt.ConfigUpdate
@@ -78,8 +83,9 @@ func init() {
 			opt = proposalOpt.General
 		}
 
-		proposals = append(proposals, NewProposal(ProposalType(k), "Test Proposal", proposer,
-			opt.FundingDeadline, opt.FundingGoal, opt.VotingDeadline))
+		id := NewProposalID(utils.SHA2([]byte(fmt.Sprint("Test Proposal", i))))
+		proposals = append(proposals, NewProposal(id, ProposalType(k), "Test Proposal", proposer,
+			opt.FundingDeadline, opt.FundingGoal, opt.VotingDeadline, opt.PassPercentage))
 	}
 
 	//Create Test DB
@@ -2,6 +2,9 @@ package governance
 
 import (
 	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
 )
@@ -27,11 +31,17 @@ const (
 	ADMIN_ONS_OPTION             string = "onsopt"
 
 	ADMIN_PROPOSAL_OPTION string = "proposal"
+	ADMIN_STAKING_OPTION  string = "stakingopt"
+	ADMIN_ISSUANCE_OPTION string = "issueopt"
+
+	ADMIN_OPTION_HISTORY string = "history"
+	ADMIN_CONFIG_UPDATE  string = "cfgupdate"
 )
 
 type Store struct {
 	state  *storage.State
 	prefix []byte
+	height int64
 }
 
 func NewStore(prefix string, state *storage.State) *Store {
@@ -46,6 +56,12 @@ func (st *Store) WithState(state *storage.State) *Store {
 	return st
 }
 
+// WithHeight sets the height recorded in the history of the options set afterwards
+func (st *Store) WithHeight(height int64) *Store {
+	st.height = height
+	return st
+}
+
 func (st *Store) Get(key []byte) ([]byte, error) {
 	prefixKey := append(st.prefix, storage.StoreKey(key)...)
 
@@ -106,7 +122,7 @@ func (st *Store) SetFeeOption(feeOpt fees.FeeOption) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize FeeOption")
 	}
-	err = st.Set([]byte(ADMIN_FEE_OPTION_KEY), bytes)
+	err = st.setOption(ADMIN_FEE_OPTION_KEY, bytes)
 	if err != nil {
 		return errors.Wrap(err, "failed to set the FeeOption")
 	}
@@ -175,7 +191,7 @@ func (st *Store) SetETHChainDriverOption(opt ethchain.ChainDriverOption) error {
 		return errors.Wrap(err, "failed to serialize eth chaindriver option")
 	}
 
-	err = st.Set([]byte(ADMIN_ETH_CHAINDRIVER_OPTION), bytes)
+	err = st.setOption(ADMIN_ETH_CHAINDRIVER_OPTION, bytes)
 	if err != nil {
 		return errors.Wrap(err, "failed to set the eth chaindriver option")
 	}
@@ -206,7 +222,7 @@ func (st *Store) SetBTCChainDriverOption(opt bitcoin.ChainDriverOption) error {
 		return errors.Wrap(err, "failed to serialize btc chaindriver option")
 	}
 
-	err = st.Set([]byte(ADMIN_BTC_CHAINDRIVER_OPTION), bytes)
+	err = st.setOption(ADMIN_BTC_CHAINDRIVER_OPTION, bytes)
 	if err != nil {
 		return errors.Wrap(err, "failed to set the btc chaindriver option")
 	}
@@ -219,7 +235,7 @@ func (st *Store) SetONSOptions(onsOpt ons.Options) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize ons options")
 	}
-	err = st.Set([]byte(ADMIN_ONS_OPTION), bytes)
+	err = st.setOption(ADMIN_ONS_OPTION, bytes)
 	if err != nil {
 		return errors.Wrap(err, "failed to set the ons options")
 	}
@@ -244,7 +260,7 @@ func (st *Store) SetProposalOptions(propOpt ProposalOptions) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize proposal options")
 	}
-	err = st.Set([]byte(ADMIN_PROPOSAL_OPTION), bytes)
+	err = st.setOption(ADMIN_PROPOSAL_OPTION, bytes)
 	if err != nil {
 		return errors.Wrap(err, "failed to set the proposal options")
 	}
@@ -263,3 +279,161 @@ func (st *Store) GetProposalOptions() (*ProposalOptions, error) {
 	}
 	return propOpt, nil
 }
+
+func (st *Store) SetStakingOptions(stakingOpt identity.Options) error {
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(stakingOpt)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize staking options")
+	}
+	err = st.setOption(ADMIN_STAKING_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the staking options")
+	}
+	return nil
+}
+
+// GetStakingOptions returns the staking options, chains started before they existed have no slashing or unbonding
+// period
+func (st *Store) GetStakingOptions() (*identity.Options, error) {
+	bytes, err := st.Get([]byte(ADMIN_STAKING_OPTION))
+	if err != nil {
+		return nil, err
+	}
+	stakingOpt := &identity.Options{}
+	if len(bytes) == 0 {
+		return stakingOpt, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, stakingOpt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize staking options")
+	}
+	return stakingOpt, nil
+}
+
+func (st *Store) SetIssuanceOptions(issueOpt balance.IssuanceOptions) error {
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(issueOpt)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize issuance options")
+	}
+	err = st.setOption(ADMIN_ISSUANCE_OPTION, bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the issuance options")
+	}
+	return nil
+}
+
+// GetIssuanceOptions returns the issuance options, chains started before they existed have no issuer
+func (st *Store) GetIssuanceOptions() (*balance.IssuanceOptions, error) {
+	bytes, err := st.Get([]byte(ADMIN_ISSUANCE_OPTION))
+	if err != nil {
+		return nil, err
+	}
+	issueOpt := &balance.IssuanceOptions{}
+	if len(bytes) == 0 {
+		return issueOpt, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, issueOpt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize issuance options")
+	}
+	return issueOpt, nil
+}
+
+// setOption stores the current value of an option and keeps a copy of it in the option history
+func (st *Store) setOption(key string, value []byte) error {
+	err := st.Set([]byte(key), value)
+	if err != nil {
+		return err
+	}
+	return st.Set(historyKey(key, st.height), value)
+}
+
+func historyPrefix(key string) string {
+	return ADMIN_OPTION_HISTORY + storage.DB_PREFIX + key + storage.DB_PREFIX
+}
+
+func historyKey(key string, height int64) []byte {
+	// heights are zero padded so the versions of an option are iterated in order
+	return []byte(historyPrefix(key) + fmt.Sprintf("%020d", height))
+}
+
+// IterateOptionHistory goes through every version of an option, ordered by the height it was set at
+func (st *Store) IterateOptionHistory(key string, fn func(height int64, value []byte) bool) bool {
+	prefix := append(st.prefix, historyPrefix(key)...)
+	return st.state.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(k, value []byte) bool {
+			arr := strings.Split(string(k), storage.DB_PREFIX)
+			height, err := strconv.ParseInt(arr[len(arr)-1], 10, 64)
+			if err != nil {
+				return false
+			}
+			return fn(height, value)
+		},
+	)
+}
+
+// GetOptionAtHeight returns the version of an option that was in use at the given height
+func (st *Store) GetOptionAtHeight(key string, height int64) ([]byte, error) {
+	var result []byte
+	st.IterateOptionHistory(key, func(h int64, value []byte) bool {
+		if h > height {
+			return true
+		}
+		result = value
+		return false
+	})
+	if result == nil {
+		return nil, errors.New(fmt.Sprintf("no version of %s found at height %d", key, height))
+	}
+	return result, nil
+}
+
+// ScheduleConfigUpdate queues a config update to be applied at the end of the given block
+func (st *Store) ScheduleConfigUpdate(height int64, update ConfigUpdate) error {
+	updates, err := st.GetConfigUpdates(height)
+	if err != nil {
+		return err
+	}
+	updates = append(updates, update)
+
+	bytes, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(updates)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize config updates")
+	}
+	err = st.Set(configUpdateKey(height), bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the config updates")
+	}
+	return nil
+}
+
+// GetConfigUpdates returns the config updates scheduled for the given block
+func (st *Store) GetConfigUpdates(height int64) ([]ConfigUpdate, error) {
+	updates := make([]ConfigUpdate, 0)
+	bytes, err := st.Get(configUpdateKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) == 0 {
+		return updates, nil
+	}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, &updates)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize config updates")
+	}
+	return updates, nil
+}
+
+// ClearConfigUpdates removes the config updates scheduled for the given block
+func (st *Store) ClearConfigUpdates(height int64) error {
+	prefixKey := append(st.prefix, storage.StoreKey(configUpdateKey(height))...)
+	_, err := st.state.Delete(prefixKey)
+	return err
+}
+
+func configUpdateKey(height int64) []byte {
+	return []byte(ADMIN_CONFIG_UPDATE + storage.DB_PREFIX + strconv.FormatInt(height, 10))
+}
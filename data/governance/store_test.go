@@ -0,0 +1,117 @@
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/identity"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func setupGovernanceStore() (*Store, *storage.State) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	state := storage.NewState(storage.NewChainState("chainstate", db))
+	return NewStore("g", state), state
+}
+
+func TestStore_OptionHistory(t *testing.T) {
+	st, state := setupGovernanceStore()
+
+	for i, height := range []int64{0, 10, 25} {
+		err := st.WithHeight(height).SetFeeOption(fees.FeeOption{MinFeeDecimal: int64(i + 1)})
+		assert.NoError(t, err)
+		state.Commit()
+	}
+
+	heights := make([]int64, 0)
+	st.IterateOptionHistory(ADMIN_FEE_OPTION_KEY, func(height int64, value []byte) bool {
+		heights = append(heights, height)
+		return false
+	})
+	assert.Equal(t, []int64{0, 10, 25}, heights)
+
+	feeOpt, err := st.GetFeeOption()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, feeOpt.MinFeeDecimal)
+
+	bytes, err := st.GetOptionAtHeight(ADMIN_FEE_OPTION_KEY, 24)
+	assert.NoError(t, err)
+	feeOpt = &fees.FeeOption{}
+	err = serialize.GetSerializer(serialize.PERSISTENT).Deserialize(bytes, feeOpt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, feeOpt.MinFeeDecimal)
+
+	_, err = st.GetOptionAtHeight(ADMIN_ONS_OPTION, 24)
+	assert.Error(t, err)
+}
+
+func TestStore_ConfigUpdates(t *testing.T) {
+	st, state := setupGovernanceStore()
+
+	updates, err := st.GetConfigUpdates(100)
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+
+	err = st.ScheduleConfigUpdate(100, ConfigUpdate{ActivationHeight: 100, FeeOption: &fees.FeeOption{MinFeeDecimal: 9}})
+	assert.NoError(t, err)
+	err = st.ScheduleConfigUpdate(100, ConfigUpdate{ActivationHeight: 90, PropOptions: &ProposalOptions{
+		General: ProposalOption{InitialFunding: balance.NewAmount(1), FundingGoal: balance.NewAmount(2)},
+	}})
+	assert.NoError(t, err)
+	state.Commit()
+
+	updates, err = st.GetConfigUpdates(100)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+	assert.EqualValues(t, 9, updates[0].FeeOption.MinFeeDecimal)
+	assert.Nil(t, updates[0].PropOptions)
+	assert.NotNil(t, updates[1].PropOptions)
+	assert.False(t, updates[1].IsEmpty())
+
+	err = st.ClearConfigUpdates(100)
+	assert.NoError(t, err)
+	state.Commit()
+
+	updates, err = st.GetConfigUpdates(100)
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+}
+
+func TestConfigUpdate_Validate(t *testing.T) {
+	currencies := balance.NewCurrencySet()
+	olt := balance.Currency{Id: 1, Name: "OLT", Chain: 0, Decimal: 18, Unit: "nue"}
+	assert.NoError(t, currencies.Register(olt))
+
+	update := ConfigUpdate{ActivationHeight: 100, FeeOption: &fees.FeeOption{FeeCurrency: olt, MinFeeDecimal: 9}}
+	assert.NoError(t, update.Validate(currencies))
+
+	update.FeeOption.BurnPercent = 60
+	update.FeeOption.TreasuryPercent = 50
+	assert.Error(t, update.Validate(currencies))
+
+	update.FeeOption.TreasuryPercent = 0
+	update.FeeOption.FeeCurrency.Name = "BTC"
+	assert.Error(t, update.Validate(currencies))
+
+	update = ConfigUpdate{ActivationHeight: 100, StakingOptions: &identity.Options{UnbondingBlocks: 0}}
+	assert.Error(t, update.Validate(currencies))
+
+	update.StakingOptions.UnbondingBlocks = 10
+	assert.NoError(t, update.Validate(currencies))
+
+	update.StakingOptions.DoubleSignSlashPercent = 120
+	assert.Error(t, update.Validate(currencies))
+}
+
+func TestStore_GetStakingOptions_NotSet(t *testing.T) {
+	st, _ := setupGovernanceStore()
+
+	opt, err := st.GetStakingOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, &identity.Options{}, opt)
+}
@@ -55,6 +55,10 @@ func NewAmountFromInt(x int64) *ProposalAmount {
 	return (*ProposalAmount)(big.NewInt(x))
 }
 
+func NewAmountFromBigInt(x *big.Int) *ProposalAmount {
+	return (*ProposalAmount)(big.NewInt(0).Set(x))
+}
+
 func (opinion VoteOpinion) String() string {
 	switch opinion {
 	case OPIN_UNKNOWN:
@@ -69,3 +73,29 @@ func (opinion VoteOpinion) String() string {
 		return "Invalid opinion"
 	}
 }
+
+func (t ProposalType) String() string {
+	switch t {
+	case ProposalTypeConfigUpdate:
+		return "ConfigUpdate"
+	case ProposalTypeCodeChange:
+		return "CodeChange"
+	case ProposalTypeGeneral:
+		return "General"
+	default:
+		return "Invalid type"
+	}
+}
+
+func (state ProposalState) String() string {
+	switch state {
+	case ProposalStateActive:
+		return "Active"
+	case ProposalStatePassed:
+		return "Passed"
+	case ProposalStateFailed:
+		return "Failed"
+	default:
+		return "Invalid state"
+	}
+}
@@ -0,0 +1,99 @@
+package multisig
+
+import (
+	"errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/utils"
+)
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be between 1 and the number of keys")
+	ErrDuplicateKey     = errors.New("duplicate key in multisig account")
+)
+
+// Account is an on chain account controlled by a set of keys, any threshold of them can sign for it
+type Account struct {
+	Threshold int              `json:"threshold"`
+	PubKeys   []keys.PublicKey `json:"pubKeys"`
+}
+
+// NewAccount creates a multisig account, the order of the keys is part of the account and changes its address
+func NewAccount(threshold int, pubKeys []keys.PublicKey) (*Account, error) {
+	account := &Account{
+		Threshold: threshold,
+		PubKeys:   pubKeys,
+	}
+	if err := account.Validate(); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Validate checks the threshold and the keys of the account
+func (a *Account) Validate() error {
+	if a.Threshold <= 0 || a.Threshold > len(a.PubKeys) {
+		return ErrInvalidThreshold
+	}
+	signers, err := a.Signers()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, signer := range signers {
+		if seen[signer.String()] {
+			return ErrDuplicateKey
+		}
+		seen[signer.String()] = true
+	}
+	return nil
+}
+
+// Signers returns the addresses of the account keys, in order
+func (a *Account) Signers() ([]keys.Address, error) {
+	signers := make([]keys.Address, 0, len(a.PubKeys))
+	for _, pubKey := range a.PubKeys {
+		h, err := pubKey.GetHandler()
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, h.Address())
+	}
+	return signers, nil
+}
+
+// Address of the account, derived from the threshold and the ordered keys
+func (a *Account) Address() keys.Address {
+	return utils.Hash(append([]byte("multisig"), a.Bytes()...))
+}
+
+// NewMultiSig prepares the collection of signatures from the account keys over a message
+func (a *Account) NewMultiSig(msg []byte) (*keys.MultiSig, error) {
+	signers, err := a.Signers()
+	if err != nil {
+		return nil, err
+	}
+	ms := &keys.MultiSig{}
+	err = ms.Init(msg, a.Threshold, signers)
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (a *Account) Bytes() []byte {
+	value, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(a)
+	if err != nil {
+		return []byte{}
+	}
+	return value
+}
+
+func (a *Account) FromBytes(msg []byte) (*Account, error) {
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(msg, a)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
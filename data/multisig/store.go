@@ -0,0 +1,70 @@
+package multisig
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// Store keeps the registered multisig accounts by their address
+type Store struct {
+	prefix []byte
+	store  *storage.State
+}
+
+func NewStore(prefix string, state *storage.State) *Store {
+	return &Store{
+		prefix: storage.Prefix(prefix),
+		store:  state,
+	}
+}
+
+func (st *Store) WithState(state *storage.State) *Store {
+	st.store = state
+	return st
+}
+
+func (st *Store) key(addr keys.Address) storage.StoreKey {
+	return append(append([]byte{}, st.prefix...), addr.String()...)
+}
+
+func (st *Store) Get(addr keys.Address) (*Account, error) {
+	value, err := st.store.Get(st.key(addr))
+	if err != nil || len(value) == 0 {
+		return nil, errors.New("multisig account not found: " + addr.String())
+	}
+	account, err := (&Account{}).FromBytes(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deserialize multisig account")
+	}
+	return account, nil
+}
+
+func (st *Store) Exists(addr keys.Address) bool {
+	return st.store.Exists(st.key(addr))
+}
+
+// Set registers the account under its address
+func (st *Store) Set(account Account) error {
+	err := st.store.Set(st.key(account.Address()), account.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set multisig account")
+	}
+	return nil
+}
+
+func (st *Store) Iterate(fn func(addr keys.Address, account *Account) bool) (stopped bool) {
+	return st.store.IterateRange(
+		st.prefix,
+		storage.Rangefix(string(st.prefix)),
+		true,
+		func(key, value []byte) bool {
+			account, err := (&Account{}).FromBytes(value)
+			if err != nil {
+				return false
+			}
+			return fn(account.Address(), account)
+		},
+	)
+}
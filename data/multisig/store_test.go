@@ -0,0 +1,67 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func newPubKeys(t *testing.T, n int) []keys.PublicKey {
+	pubKeys := make([]keys.PublicKey, 0, n)
+	for i := 0; i < n; i++ {
+		pub, _, err := keys.NewKeyPairFromTendermint()
+		assert.NoError(t, err)
+		pubKeys = append(pubKeys, pub)
+	}
+	return pubKeys
+}
+
+func TestNewAccount(t *testing.T) {
+	pubKeys := newPubKeys(t, 3)
+
+	_, err := NewAccount(0, pubKeys)
+	assert.Equal(t, ErrInvalidThreshold, err)
+	_, err = NewAccount(4, pubKeys)
+	assert.Equal(t, ErrInvalidThreshold, err)
+	_, err = NewAccount(2, []keys.PublicKey{pubKeys[0], pubKeys[1], pubKeys[0]})
+	assert.Equal(t, ErrDuplicateKey, err)
+
+	a, err := NewAccount(2, pubKeys)
+	assert.NoError(t, err)
+	b, err := NewAccount(2, pubKeys)
+	assert.NoError(t, err)
+	c, err := NewAccount(3, pubKeys)
+	assert.NoError(t, err)
+	assert.Equal(t, a.Address(), b.Address())
+	assert.NotEqual(t, a.Address(), c.Address())
+}
+
+func TestStore(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("multisig", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("msig", cs)
+
+	account, err := NewAccount(2, newPubKeys(t, 3))
+	assert.NoError(t, err)
+	assert.False(t, store.Exists(account.Address()))
+
+	assert.NoError(t, store.Set(*account))
+	cs.Commit()
+	assert.True(t, store.Exists(account.Address()))
+
+	got, err := store.Get(account.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address(), got.Address())
+	assert.Equal(t, 2, got.Threshold)
+
+	count := 0
+	store.Iterate(func(addr keys.Address, a *Account) bool {
+		assert.Equal(t, account.Address(), addr)
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,40 @@
+package nonce
+
+import (
+	"sync"
+
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// Pending keeps the next nonce of the accounts with txs waiting in the mempool, which are ahead of the committed
+// state. It is filled by CheckTx and cleared at each commit, the mempool recheck then fills it again.
+type Pending struct {
+	sync.RWMutex
+	nonces map[string]uint64
+}
+
+func NewPending() *Pending {
+	return &Pending{nonces: make(map[string]uint64)}
+}
+
+// Set records the nonce following a tx of the account accepted in the mempool
+func (p *Pending) Set(addr keys.Address, next uint64) {
+	p.Lock()
+	defer p.Unlock()
+	if next > p.nonces[addr.String()] {
+		p.nonces[addr.String()] = next
+	}
+}
+
+func (p *Pending) Get(addr keys.Address) (uint64, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	next, ok := p.nonces[addr.String()]
+	return next, ok
+}
+
+func (p *Pending) Reset() {
+	p.Lock()
+	defer p.Unlock()
+	p.nonces = make(map[string]uint64)
+}
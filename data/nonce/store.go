@@ -0,0 +1,117 @@
+package nonce
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// AccountNonce is the next nonce of an account, as kept in the genesis state
+type AccountNonce struct {
+	Address keys.Address `json:"address"`
+	Nonce   uint64       `json:"nonce"`
+}
+
+// Store keeps the next nonce of every account which has sent a transaction. An account never seen before starts at 0,
+// and each delivered transaction it signs first moves its nonce by one.
+type Store struct {
+	prefix  []byte
+	store   *storage.State
+	szlr    serialize.Serializer
+	pending *Pending
+}
+
+func NewStore(prefix string, state *storage.State) *Store {
+	return &Store{
+		prefix: storage.Prefix(prefix),
+		store:  state,
+		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
+	}
+}
+
+func (st *Store) WithState(state *storage.State) *Store {
+	st.store = state
+	return st
+}
+
+// WithPending makes the store aware of the txs waiting in the mempool, see Next
+func (st *Store) WithPending(pending *Pending) *Store {
+	st.pending = pending
+	return st
+}
+
+func (st *Store) key(addr keys.Address) storage.StoreKey {
+	return append(append([]byte{}, st.prefix...), addr.String()...)
+}
+
+// Get returns the nonce the next transaction of the account must carry
+func (st *Store) Get(addr keys.Address) (uint64, error) {
+	value, err := st.store.Get(st.key(addr))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get nonce")
+	}
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	var nonce uint64
+	err = st.szlr.Deserialize(value, &nonce)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to deserialize nonce")
+	}
+	return nonce, nil
+}
+
+// Next returns the nonce a new transaction of the account must carry, past the ones waiting in the mempool
+func (st *Store) Next(addr keys.Address) (uint64, error) {
+	nonce, err := st.Get(addr)
+	if err != nil {
+		return 0, err
+	}
+	if st.pending != nil {
+		if next, ok := st.pending.Get(addr); ok && next > nonce {
+			return next, nil
+		}
+	}
+	return nonce, nil
+}
+
+// Increment moves the nonce of the account past the one just used
+func (st *Store) Increment(addr keys.Address) error {
+	nonce, err := st.Get(addr)
+	if err != nil {
+		return err
+	}
+	return st.Set(addr, nonce+1)
+}
+
+func (st *Store) Set(addr keys.Address, nonce uint64) error {
+	value, err := st.szlr.Serialize(nonce)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize nonce")
+	}
+	return st.store.Set(st.key(addr), value)
+}
+
+func (st *Store) Iterate(fn func(addr keys.Address, nonce uint64) bool) (stopped bool) {
+	return st.store.IterateRange(
+		st.prefix,
+		storage.Rangefix(string(st.prefix)),
+		true,
+		func(key, value []byte) bool {
+			addr := keys.Address{}
+			err := addr.UnmarshalText(key[len(st.prefix):])
+			if err != nil {
+				return false
+			}
+			var nonce uint64
+			err = st.szlr.Deserialize(value, &nonce)
+			if err != nil {
+				return false
+			}
+			return fn(addr, nonce)
+		},
+	)
+}
@@ -0,0 +1,45 @@
+package nonce
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestStore_Increment(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("nonce", db.NewDB("test", db.MemDBBackend, "")))
+	store := NewStore("nonce", cs)
+
+	addr := keys.Address([]byte("0123456789abcdefghij"))
+	other := keys.Address([]byte("jihgfedcba9876543210"))
+
+	nonce, err := store.Get(addr)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, nonce)
+
+	assert.NoError(t, store.Increment(addr))
+	assert.NoError(t, store.Increment(addr))
+	cs.Commit()
+
+	nonce, err = store.Get(addr)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, nonce)
+
+	nonce, err = store.Get(other)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, nonce)
+
+	assert.NoError(t, store.Set(other, 7))
+	cs.Commit()
+
+	got := make(map[string]uint64)
+	store.Iterate(func(addr keys.Address, nonce uint64) bool {
+		got[addr.String()] = nonce
+		return false
+	})
+	assert.Equal(t, map[string]uint64{addr.String(): 2, other.String(): 7}, got)
+}
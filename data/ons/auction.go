@@ -0,0 +1,271 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// AuctionEscrow is the module account holding the bid deposits of the auctions, no one has its key
+var AuctionEscrow = keys.Address(hash([]byte("ons.auction.escrow"))[:20])
+
+var (
+	ErrAuctionNotFound = errors.New("auction doesn't exist")
+	ErrAuctionClosed   = errors.New("auction is not open for this")
+	ErrBidNotFound     = errors.New("bid doesn't exist")
+)
+
+// Bid is a sealed bid of an auction. The deposit is escrowed when the bid is committed and hides the real amount,
+// which is only known once revealed.
+type Bid struct {
+	Bidder     keys.Address   `json:"bidder"`
+	Commitment []byte         `json:"commitment"`
+	Deposit    balance.Amount `json:"deposit"`
+	Revealed   bool           `json:"revealed"`
+	Amount     balance.Amount `json:"amount"`
+}
+
+// Auction is a Vickrey auction of a top level name. Bids are committed until CommitEnd and revealed until
+// RevealEnd, then the highest bid wins the name at the price of the second highest one. The winner has until
+// FinalizeEnd to pay for it.
+type Auction struct {
+	Name        Name  `json:"name"`
+	CommitEnd   int64 `json:"commitEnd"`
+	RevealEnd   int64 `json:"revealEnd"`
+	FinalizeEnd int64 `json:"finalizeEnd"`
+	Bids        []Bid `json:"bids"`
+
+	// set once the auction is settled, the winner then finalizes it to get the domain
+	Settled bool           `json:"settled"`
+	Winner  keys.Address   `json:"winner,omitempty"`
+	Price   balance.Amount `json:"price"`
+}
+
+// NewAuction opens an auction of the name at the height
+func NewAuction(name Name, height int64, opt *Options) *Auction {
+	revealEnd := height + opt.AuctionCommitBlocks + opt.AuctionRevealBlocks
+	return &Auction{
+		Name:        name,
+		CommitEnd:   height + opt.AuctionCommitBlocks,
+		RevealEnd:   revealEnd,
+		FinalizeEnd: revealEnd + opt.GetAuctionFinalizeBlocks(),
+		Bids:        make([]Bid, 0),
+		Price:       *balance.NewAmount(0),
+	}
+}
+
+// BidCommitment is the hash a bidder commits to, the salt keeps the amount from being guessed
+func BidCommitment(name Name, bidder keys.Address, amount balance.Amount, salt []byte) []byte {
+	data := fmt.Sprintf("%s:%s:%s:", name, bidder.String(), amount.String())
+	return hash(append([]byte(data), salt...))
+}
+
+func (a *Auction) GetBid(bidder keys.Address) (*Bid, bool) {
+	for i := range a.Bids {
+		if bytes.Equal(a.Bids[i].Bidder, bidder) {
+			return &a.Bids[i], true
+		}
+	}
+	return nil, false
+}
+
+// winner returns the highest revealed bid, the earliest committed one on ties, and the price it pays
+func (a *Auction) winner(minBid balance.Amount) (*Bid, balance.Amount) {
+	var first, second *Bid
+	for i := range a.Bids {
+		bid := &a.Bids[i]
+		if !bid.Revealed {
+			continue
+		}
+		switch {
+		case first == nil || bid.Amount.BigInt().Cmp(first.Amount.BigInt()) > 0:
+			first, second = bid, first
+		case second == nil || bid.Amount.BigInt().Cmp(second.Amount.BigInt()) > 0:
+			second = bid
+		}
+	}
+	if second == nil {
+		return first, minBid
+	}
+	return first, second.Amount
+}
+
+// GetAuction returns the auction of the name
+func (ds *DomainStore) GetAuction(name Name) (*Auction, error) {
+	data, err := ds.State.Get(ds.auctionKey(name))
+	if err != nil || len(data) == 0 {
+		return nil, ErrAuctionNotFound
+	}
+
+	auction := &Auction{}
+	err = ds.szlr.Deserialize(data, auction)
+	if err != nil {
+		return nil, errors.Wrap(err, "error de-serializing auction")
+	}
+	return auction, nil
+}
+
+// SetAuction stores the auction, a new auction is also queued for settling at the end of its reveal window
+func (ds *DomainStore) SetAuction(auction *Auction) error {
+	if !ds.State.Exists(ds.auctionKey(auction.Name)) {
+		err := ds.State.Set(ds.endingKey(auction.RevealEnd, auction.Name), []byte(auction.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := ds.szlr.Serialize(auction)
+	if err != nil {
+		return err
+	}
+	return ds.State.Set(ds.auctionKey(auction.Name), data)
+}
+
+// DeleteAuction removes a finalized auction
+func (ds *DomainStore) DeleteAuction(name Name) error {
+	_, err := ds.State.Delete(ds.auctionKey(name))
+	return err
+}
+
+// IterateAuctions goes through the open and settled auctions by name
+func (ds *DomainStore) IterateAuctions(fn func(auction *Auction) bool) (stopped bool) {
+	return ds.State.IterateRange(
+		ds.auctionPrefix,
+		storage.Rangefix(string(ds.auctionPrefix)),
+		true,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, ds.auctionPrefix) {
+				return false
+			}
+			auction := &Auction{}
+			err := ds.szlr.Deserialize(value, auction)
+			if err != nil {
+				return false
+			}
+			return fn(auction)
+		},
+	)
+}
+
+// SettleAuctions settles the auctions whose reveal window ends at the height. Losing bids are refunded, the deposits
+// of unrevealed bids are forfeited to the fee pool, and the winning deposit stays escrowed until the winner
+// finalizes the auction. Auctions without any revealed bid are dropped, which releases the name again. Settled
+// auctions the winner didn't finalize by the end of the finalize window are dropped too, and the winning deposit is
+// forfeited to the fee pool.
+func (ds *DomainStore) SettleAuctions(height int64, balances *balance.Store, feePool *fees.Store,
+	currency balance.Currency) error {
+
+	names := make([]Name, 0)
+	endings := make([][]byte, 0)
+	ds.State.IterateRange(
+		ds.endingPrefix,
+		[]byte(fmt.Sprintf("%s%020d", ds.endingPrefix, height+1)),
+		true,
+		func(key, value []byte) bool {
+			names = append(names, Name(value))
+			endings = append(endings, key)
+			return false
+		},
+	)
+
+	for i, name := range names {
+		_, err := ds.State.Delete(endings[i])
+		if err != nil {
+			return err
+		}
+		auction, err := ds.GetAuction(name)
+		if err != nil {
+			continue
+		}
+
+		if auction.Settled {
+			if auction.FinalizeEnd > height {
+				continue
+			}
+			for _, bid := range auction.Bids {
+				coin := currency.NewCoinFromAmount(bid.Deposit)
+				err = balances.MinusFromAddress(AuctionEscrow, coin)
+				if err != nil {
+					return errors.Wrap(err, "failed to release bid deposit")
+				}
+				err = feePool.AddToPool(coin)
+				if err != nil {
+					return errors.Wrap(err, "failed to forfeit bid deposit")
+				}
+			}
+			err = ds.DeleteAuction(name)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		winner, price := auction.winner(ds.opt.GetMinAuctionBid())
+		for j := range auction.Bids {
+			bid := &auction.Bids[j]
+			if bid == winner {
+				continue
+			}
+			coin := currency.NewCoinFromAmount(bid.Deposit)
+			err = balances.MinusFromAddress(AuctionEscrow, coin)
+			if err != nil {
+				return errors.Wrap(err, "failed to release bid deposit")
+			}
+			if bid.Revealed {
+				err = balances.AddToAddress(bid.Bidder, coin)
+			} else {
+				err = feePool.AddToPool(coin)
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to release bid deposit")
+			}
+		}
+
+		if winner == nil {
+			err = ds.DeleteAuction(name)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		auction.Settled = true
+		auction.Winner = winner.Bidder
+		auction.Price = price
+		auction.Bids = []Bid{*winner}
+		err = ds.SetAuction(auction)
+		if err != nil {
+			return err
+		}
+		// comes back at the end of the finalize window, in case the winner doesn't finalize
+		err = ds.State.Set(ds.endingKey(auction.FinalizeEnd, auction.Name), []byte(auction.Name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ds *DomainStore) auctionKey(name Name) storage.StoreKey {
+	return storage.StoreKey(string(ds.auctionPrefix) + name.String())
+}
+
+func (ds *DomainStore) endingKey(height int64, name Name) storage.StoreKey {
+	return storage.StoreKey(fmt.Sprintf("%s%020d%s%s", ds.endingPrefix, height, storage.DB_PREFIX, name))
+}
+
+func hash(data []byte) []byte {
+	h := sha256.Sum256(data)
+	return h[:]
+}
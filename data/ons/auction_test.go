@@ -0,0 +1,142 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+var olt = balance.Currency{Id: 0, Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18, Unit: "nue"}
+
+func setupAuction(t *testing.T) (*DomainStore, *balance.Store, *fees.Store, *storage.State) {
+	cs := storage.NewState(storage.NewChainState("auction", db.NewDB("test", db.MemDBBackend, "")))
+
+	ds := NewDomainStore("d", cs)
+	ds.SetOptions(&Options{
+		Currency:            "OLT",
+		BaseDomainPrice:     *balance.NewAmount(100),
+		PerBlockFees:        *balance.NewAmount(1),
+		FirstLevelDomains:   []string{"ol"},
+		AuctionCommitBlocks: 10,
+		AuctionRevealBlocks: 10,
+	})
+
+	feePool := fees.NewStore("f", cs)
+	feePool.SetupOpt(&fees.FeeOption{FeeCurrency: olt})
+
+	return ds, balance.NewStore("b", cs), feePool, cs
+}
+
+// bid escrows the deposit of the bidder and reveals the amount if it's not nil
+func bid(t *testing.T, auction *Auction, balances *balance.Store, bidder keys.Address, deposit int64, amount *int64) {
+	coin := olt.NewCoinFromAmount(*balance.NewAmount(deposit))
+	assert.NoError(t, balances.AddToAddress(AuctionEscrow, coin))
+
+	b := Bid{Bidder: bidder, Deposit: *balance.NewAmount(deposit)}
+	if amount != nil {
+		b.Revealed = true
+		b.Amount = *balance.NewAmount(*amount)
+	}
+	auction.Bids = append(auction.Bids, b)
+}
+
+func held(t *testing.T, balances *balance.Store, addr keys.Address) int64 {
+	coin, err := balances.GetBalanceForCurr(addr, &olt)
+	assert.NoError(t, err)
+	return coin.Amount.BigInt().Int64()
+}
+
+func TestDomainStore_SettleAuctions(t *testing.T) {
+	ds, balances, feePool, cs := setupAuction(t)
+
+	alice, bob, carol := keys.Address("alice"), keys.Address("bob"), keys.Address("carol")
+	high, low := int64(500), int64(300)
+
+	auction := NewAuction("short.ol", 1, ds.GetOptions())
+	assert.EqualValues(t, 21, auction.RevealEnd)
+	bid(t, auction, balances, alice, 300, &low)
+	bid(t, auction, balances, bob, 600, &high)
+	bid(t, auction, balances, carol, 1000, nil)
+	assert.NoError(t, ds.SetAuction(auction))
+
+	empty := NewAuction("empty.ol", 2, ds.GetOptions())
+	bid(t, empty, balances, carol, 200, nil)
+	assert.NoError(t, ds.SetAuction(empty))
+	cs.Commit()
+
+	// nothing ends before the reveal window is over
+	assert.NoError(t, ds.SettleAuctions(20, balances, feePool, olt))
+	cs.Commit()
+	auction, err := ds.GetAuction("short.ol")
+	assert.NoError(t, err)
+	assert.False(t, auction.Settled)
+
+	assert.NoError(t, ds.SettleAuctions(22, balances, feePool, olt))
+	cs.Commit()
+
+	// bob wins at alice's price, alice is refunded and carol loses her unrevealed deposits
+	auction, err = ds.GetAuction("short.ol")
+	assert.NoError(t, err)
+	assert.True(t, auction.Settled)
+	assert.Equal(t, bob, auction.Winner)
+	assert.EqualValues(t, 300, auction.Price.BigInt().Int64())
+	assert.Len(t, auction.Bids, 1)
+
+	assert.EqualValues(t, 300, held(t, balances, alice))
+	assert.EqualValues(t, 0, held(t, balances, carol))
+	assert.EqualValues(t, 600, held(t, balances, AuctionEscrow))
+	pool, err := feePool.Get([]byte(fees.POOL_KEY))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1200, pool.Amount.BigInt().Int64())
+
+	// an auction without revealed bids frees the name
+	_, err = ds.GetAuction("empty.ol")
+	assert.Equal(t, ErrAuctionNotFound, err)
+
+	// the winner has until the end of the finalize window, then the deposit is forfeited and the name freed
+	assert.EqualValues(t, 31, auction.FinalizeEnd)
+	assert.NoError(t, ds.SettleAuctions(30, balances, feePool, olt))
+	cs.Commit()
+	_, err = ds.GetAuction("short.ol")
+	assert.NoError(t, err)
+
+	assert.NoError(t, ds.SettleAuctions(31, balances, feePool, olt))
+	cs.Commit()
+	_, err = ds.GetAuction("short.ol")
+	assert.Equal(t, ErrAuctionNotFound, err)
+	assert.EqualValues(t, 0, held(t, balances, AuctionEscrow))
+	pool, err = feePool.Get([]byte(fees.POOL_KEY))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1800, pool.Amount.BigInt().Int64())
+}
+
+func TestAuction_Winner(t *testing.T) {
+	amount := int64(150)
+	auction := NewAuction("single.ol", 1, &Options{AuctionCommitBlocks: 1, AuctionRevealBlocks: 1})
+	auction.Bids = append(auction.Bids, Bid{Bidder: keys.Address("alice"), Revealed: true, Amount: *balance.NewAmount(amount)})
+
+	// a single bid pays the minimum
+	winner, price := auction.winner(*balance.NewAmount(100))
+	assert.Equal(t, keys.Address("alice"), winner.Bidder)
+	assert.EqualValues(t, 100, price.BigInt().Int64())
+
+	// the earliest bid wins ties at its own price
+	auction.Bids = append(auction.Bids, Bid{Bidder: keys.Address("bob"), Revealed: true, Amount: *balance.NewAmount(amount)})
+	winner, price = auction.winner(*balance.NewAmount(100))
+	assert.Equal(t, keys.Address("alice"), winner.Bidder)
+	assert.EqualValues(t, 150, price.BigInt().Int64())
+
+	commitment := BidCommitment("single.ol", keys.Address("alice"), *balance.NewAmount(amount), []byte("salt"))
+	assert.NotEqual(t, commitment, BidCommitment("single.ol", keys.Address("alice"), *balance.NewAmount(amount), []byte("other")))
+}
@@ -37,6 +37,9 @@ type Domain struct {
 	URI        string `json:"uri"`
 	// the asking price in OLT set by the owner
 	SalePrice *balance.Amount `json:"salePrice"`
+
+	// resolver records of the domain, addresses on other chains, text records and the like
+	Records []Record `json:"records,omitempty"`
 }
 
 func NewDomain(ownerAddress, accountAddress keys.Address,
@@ -124,6 +127,11 @@ func (d Domain) IsExpired(height int64) bool {
 	return d.ExpireHeight < height
 }
 
+// IsInGracePeriod tells whether the domain is expired but can still be renewed by its owner
+func (d Domain) IsInGracePeriod(height, gracePeriod int64) bool {
+	return d.IsExpired(height) && height <= d.ExpireHeight+gracePeriod
+}
+
 func (d *Domain) ResetAfterSale(buyer, account keys.Address, nBlocks, currentHeight int64) {
 	newExpiry := currentHeight
 	if d.ExpireHeight > currentHeight {
@@ -136,5 +144,6 @@ func (d *Domain) ResetAfterSale(buyer, account keys.Address, nBlocks, currentHei
 	d.LastUpdateHeight = currentHeight
 	d.ActiveFlag = true
 	d.URI = ""
+	d.Records = nil
 	d.OnSaleFlag = false
 }
@@ -23,6 +23,7 @@ type domainData struct {
 	OnSaleFlag       bool         `json:"h"`
 	SalePriceData    []byte       `json:"i"`
 	URI              string       `json:"k"`
+	Records          []Record     `json:"l,omitempty"`
 }
 
 func (d *Domain) NewDataInstance() serialize.Data {
@@ -41,6 +42,7 @@ func (d *Domain) Data() serialize.Data {
 		OnSaleFlag:       d.OnSaleFlag,
 		SalePriceData:    nil,
 		URI:              d.URI,
+		Records:          d.Records,
 	}
 	if d.SalePrice != nil {
 		dd.SalePriceData, _ = d.SalePrice.MarshalJSON()
@@ -63,6 +65,7 @@ func (d *Domain) SetData(a interface{}) error {
 	d.ActiveFlag = cd.ActiveFlag
 	d.OnSaleFlag = cd.OnSaleFlag
 	d.URI = cd.URI
+	d.Records = cd.Records
 
 	if cd.SalePriceData != nil {
 		amt := &balance.Amount{}
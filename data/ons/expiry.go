@@ -0,0 +1,122 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// ReleaseTags are the tags of the event announcing the release of an expired domain
+func (d *Domain) ReleaseTags(height int64) kv.Pairs {
+	return kv.Pairs{
+		{Key: []byte("ons.name"), Value: []byte(d.Name.String())},
+		{Key: []byte("ons.owner"), Value: []byte(d.Owner.String())},
+		{Key: []byte("ons.expireHeight"), Value: []byte(strconv.FormatInt(d.ExpireHeight, 10))},
+		{Key: []byte("ons.height"), Value: []byte(strconv.FormatInt(height, 10))},
+	}
+}
+
+// ReleaseExpired deletes the top level domains whose grace period is over at the height, with their subdomains,
+// and refunds the offers made on them. The released domains are returned followed by their subdomains, their names
+// can be registered again.
+func (ds *DomainStore) ReleaseExpired(height int64, balances *balance.Store, currency balance.Currency) ([]*Domain, error) {
+	released := make([]*Domain, 0)
+
+	end := height - ds.opt.GracePeriodBlocks
+	if end <= 0 {
+		return released, nil
+	}
+
+	names := make([]Name, 0)
+	expiries := make([][]byte, 0)
+	ds.State.IterateRange(
+		ds.expiryPrefix,
+		[]byte(fmt.Sprintf("%s%020d", ds.expiryPrefix, end)),
+		true,
+		func(key, value []byte) bool {
+			names = append(names, Name(value))
+			expiries = append(expiries, key)
+			return false
+		},
+	)
+
+	for i, name := range names {
+		_, err := ds.State.Delete(expiries[i])
+		if err != nil {
+			return nil, err
+		}
+		d, err := ds.Get(name)
+		if err != nil {
+			continue
+		}
+		// renewed or sold in the block, the domain is indexed at its new expiry already
+		if !d.IsExpired(height) || d.IsInGracePeriod(height, ds.opt.GracePeriodBlocks) {
+			continue
+		}
+
+		offers := make([]*Offer, 0)
+		ds.IterateOffers(name, func(offer *Offer) bool {
+			offers = append(offers, offer)
+			return false
+		})
+		for _, offer := range offers {
+			err = ds.RefundOffer(offer, balances, currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		subdomains := make([]*Domain, 0)
+		ds.IterateSubDomain(name, func(_ Name, sub *Domain) bool {
+			subdomains = append(subdomains, sub)
+			return false
+		})
+		err = ds.DeleteAllSubdomains(name)
+		if err != nil {
+			return nil, err
+		}
+		err = ds.clearPrimary(d)
+		if err != nil {
+			return nil, err
+		}
+		_, err = ds.State.Delete(append(ds.prefix, name.toKey()...))
+		if err != nil {
+			return nil, err
+		}
+		released = append(released, d)
+		released = append(released, subdomains...)
+	}
+	return released, nil
+}
+
+// IndexExpiries adds the top level domains missing from the expiry index, which were stored before the index existed,
+// and returns how many were added. Nothing is written when the index is complete.
+func (ds *DomainStore) IndexExpiries() (int, error) {
+	missing := make([]*Domain, 0)
+	ds.Iterate(func(name Name, domain *Domain) bool {
+		if !name.IsSub() && !ds.State.Exists(ds.expiryKey(domain)) {
+			missing = append(missing, domain)
+		}
+		return false
+	})
+
+	for _, d := range missing {
+		err := ds.State.Set(ds.expiryKey(d), []byte(d.Name))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(missing), nil
+}
+
+func (ds *DomainStore) expiryKey(d *Domain) storage.StoreKey {
+	return storage.StoreKey(fmt.Sprintf("%s%020d%s%s", ds.expiryPrefix, d.ExpireHeight, storage.DB_PREFIX, d.Name))
+}
@@ -0,0 +1,101 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+func TestDomainStore_ReleaseExpired(t *testing.T) {
+	ds, balances, _, cs := setupAuction(t)
+	ds.GetOptions().GracePeriodBlocks = 5
+
+	alice, bob := keys.Address("alice"), keys.Address("bob")
+	for _, name := range []string{"short.ol", "sub.short.ol", "renewed.ol"} {
+		d, err := NewDomain(alice, alice, name, 1, "", 10)
+		assert.NoError(t, err)
+		assert.NoError(t, ds.Set(d))
+	}
+	assert.NoError(t, ds.SetPrimary(alice, "sub.short.ol"))
+	offer(t, ds, balances, "short.ol", bob, 100, 100)
+	cs.Commit()
+
+	d, err := ds.Get("short.ol")
+	assert.NoError(t, err)
+	assert.True(t, d.IsExpired(11))
+	assert.True(t, d.IsInGracePeriod(15, 5))
+	assert.False(t, d.IsInGracePeriod(16, 5))
+
+	// nothing is released during the grace period
+	released, err := ds.ReleaseExpired(15, balances, olt)
+	assert.NoError(t, err)
+	assert.Len(t, released, 0)
+
+	// the owner renews in the grace period, which moves the domain in the expiry index
+	d, err = ds.Get("renewed.ol")
+	assert.NoError(t, err)
+	d.AddToExpire(20)
+	assert.NoError(t, ds.Set(d))
+	cs.Commit()
+
+	released, err = ds.ReleaseExpired(16, balances, olt)
+	assert.NoError(t, err)
+	cs.Commit()
+	assert.Len(t, released, 2)
+	assert.Equal(t, Name("short.ol"), released[0].Name)
+	assert.Equal(t, Name("sub.short.ol"), released[1].Name)
+
+	// the domain goes with its subdomains, primary names and offers
+	assert.False(t, ds.Exists("short.ol"))
+	assert.False(t, ds.Exists("sub.short.ol"))
+	_, ok := ds.GetPrimary(alice)
+	assert.False(t, ok)
+	assert.EqualValues(t, 100, held(t, balances, bob))
+	assert.True(t, ds.Exists("renewed.ol"))
+
+	released, err = ds.ReleaseExpired(36, balances, olt)
+	assert.NoError(t, err)
+	cs.Commit()
+	assert.Len(t, released, 1)
+	assert.False(t, ds.Exists("renewed.ol"))
+}
+
+func TestDomainStore_IndexExpiries(t *testing.T) {
+	ds, balances, _, cs := setupAuction(t)
+
+	// domains stored before the expiry index existed
+	alice := keys.Address("alice")
+	for _, name := range []string{"old.ol", "sub.old.ol"} {
+		d, err := NewDomain(alice, alice, name, 1, "", 10)
+		assert.NoError(t, err)
+		data, err := ds.szlr.Serialize(d)
+		assert.NoError(t, err)
+		assert.NoError(t, ds.State.Set(append(ds.prefix, d.Name.toKey()...), data))
+	}
+	cs.Commit()
+
+	released, err := ds.ReleaseExpired(20, balances, olt)
+	assert.NoError(t, err)
+	assert.Len(t, released, 0)
+
+	indexed, err := ds.IndexExpiries()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, indexed)
+	cs.Commit()
+
+	indexed, err = ds.IndexExpiries()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, indexed)
+
+	released, err = ds.ReleaseExpired(20, balances, olt)
+	assert.NoError(t, err)
+	cs.Commit()
+	assert.Len(t, released, 2)
+	assert.False(t, ds.Exists("old.ol"))
+}
@@ -13,6 +13,20 @@ type Options struct {
 	BaseDomainPrice   balance.Amount `json:"baseDomainPrice"`
 	FirstLevelDomains []string       `json:"firstLevelDomains"`
 
+	// limits of the resolver records of a domain, the defaults are used when not set
+	MaxRecords    int `json:"maxRecords,omitempty"`
+	MaxRecordSize int `json:"maxRecordSize,omitempty"`
+
+	// sealed bid auctions of top level names, they are used instead of first come first served creation when both
+	// windows are set. The winner must finalize within the finalize window, which defaults to the reveal window.
+	AuctionCommitBlocks   int64           `json:"auctionCommitBlocks,omitempty"`
+	AuctionRevealBlocks   int64           `json:"auctionRevealBlocks,omitempty"`
+	AuctionFinalizeBlocks int64           `json:"auctionFinalizeBlocks,omitempty"`
+	MinAuctionBid         *balance.Amount `json:"minAuctionBid,omitempty"`
+
+	// blocks after the expiry during which only the owner can renew a domain, it is released at the end of them
+	GracePeriodBlocks int64 `json:"gracePeriodBlocks,omitempty"`
+
 	firstLevel map[string]bool
 	protocols  map[string]bool
 }
@@ -49,3 +63,45 @@ func (opt *Options) IsValidURI(uri string) bool {
 	_, ok := opt.protocols[u.Scheme]
 	return ok
 }
+
+const (
+	defaultMaxRecords    = 32
+	defaultMaxRecordSize = 512
+)
+
+// GetMaxRecords returns the most resolver records a domain can have
+func (opt *Options) GetMaxRecords() int {
+	if opt.MaxRecords <= 0 {
+		return defaultMaxRecords
+	}
+	return opt.MaxRecords
+}
+
+// GetMaxRecordSize returns the most bytes a resolver record can take
+func (opt *Options) GetMaxRecordSize() int {
+	if opt.MaxRecordSize <= 0 {
+		return defaultMaxRecordSize
+	}
+	return opt.MaxRecordSize
+}
+
+// AuctionsEnabled tells whether top level names are sold by auction
+func (opt *Options) AuctionsEnabled() bool {
+	return opt.AuctionCommitBlocks > 0 && opt.AuctionRevealBlocks > 0
+}
+
+// GetAuctionFinalizeBlocks returns how long the winner of an auction has to finalize it
+func (opt *Options) GetAuctionFinalizeBlocks() int64 {
+	if opt.AuctionFinalizeBlocks <= 0 {
+		return opt.AuctionRevealBlocks
+	}
+	return opt.AuctionFinalizeBlocks
+}
+
+// GetMinAuctionBid returns the lowest bid of an auction, which is never below the base domain price
+func (opt *Options) GetMinAuctionBid() balance.Amount {
+	if opt.MinAuctionBid == nil || opt.MinAuctionBid.BigInt().Cmp(opt.BaseDomainPrice.BigInt()) < 0 {
+		return opt.BaseDomainPrice
+	}
+	return *opt.MinAuctionBid
+}
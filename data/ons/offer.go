@@ -0,0 +1,195 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// OfferEscrow is the module account holding the funds of the offers made on domains, no one has its key
+var OfferEscrow = keys.Address(hash([]byte("ons.offer.escrow"))[:20])
+
+var ErrOfferNotFound = errors.New("offer doesn't exist")
+
+// Offer is a bid on a domain which is not necessarily for sale. The amount is escrowed until the owner accepts the
+// offer, the bidder cancels it or it expires.
+type Offer struct {
+	Name   Name           `json:"name"`
+	Bidder keys.Address   `json:"bidder"`
+	Amount balance.Amount `json:"amount"`
+	// the offer can be accepted up to this height, it is refunded at the end of it
+	ExpireHeight   int64 `json:"expireHeight"`
+	CreationHeight int64 `json:"creationHeight"`
+}
+
+// GetOffer returns the offer of the bidder on the domain
+func (ds *DomainStore) GetOffer(name Name, bidder keys.Address) (*Offer, error) {
+	return ds.getOffer(ds.offerKey(name, bidder))
+}
+
+// SetOffer stores a new offer, indexed by bidder and by expiry
+func (ds *DomainStore) SetOffer(offer *Offer) error {
+	key := ds.offerKey(offer.Name, offer.Bidder)
+	data, err := ds.szlr.Serialize(offer)
+	if err != nil {
+		return err
+	}
+	err = ds.State.Set(key, data)
+	if err != nil {
+		return err
+	}
+	err = ds.State.Set(ds.bidderKey(offer.Bidder, offer.Name), key)
+	if err != nil {
+		return err
+	}
+	return ds.State.Set(ds.offerExpiryKey(offer), key)
+}
+
+// DeleteOffer removes the offer and its indexes, the escrowed amount is left for the caller to release
+func (ds *DomainStore) DeleteOffer(offer *Offer) error {
+	_, err := ds.State.Delete(ds.offerKey(offer.Name, offer.Bidder))
+	if err != nil {
+		return err
+	}
+	_, err = ds.State.Delete(ds.bidderKey(offer.Bidder, offer.Name))
+	if err != nil {
+		return err
+	}
+	_, err = ds.State.Delete(ds.offerExpiryKey(offer))
+	return err
+}
+
+// RefundOffer gives the escrowed amount of the offer back to the bidder and removes the offer
+func (ds *DomainStore) RefundOffer(offer *Offer, balances *balance.Store, currency balance.Currency) error {
+	coin := currency.NewCoinFromAmount(offer.Amount)
+	err := balances.MinusFromAddress(OfferEscrow, coin)
+	if err != nil {
+		return errors.Wrap(err, "failed to release offer")
+	}
+	err = balances.AddToAddress(offer.Bidder, coin)
+	if err != nil {
+		return errors.Wrap(err, "failed to refund offer")
+	}
+	return ds.DeleteOffer(offer)
+}
+
+// IterateOffers goes through the offers made on the domain
+func (ds *DomainStore) IterateOffers(name Name, fn func(offer *Offer) bool) (stopped bool) {
+	return ds.IterateOffersFrom(name, nil, true, fn)
+}
+
+// IterateOffersFrom goes through the offers made on the domain in bidder order, from the offer of the start bidder on
+// or from the first one if there is no start bidder
+func (ds *DomainStore) IterateOffersFrom(name Name, start keys.Address, ascending bool, fn func(offer *Offer) bool) (stopped bool) {
+	prefix := storage.Prefix(string(ds.offerPrefix) + name.String())
+	var startKey []byte
+	if len(start) > 0 {
+		startKey = []byte(hex.EncodeToString(start))
+	}
+	from, to := storage.PageRange(prefix, startKey, ascending)
+	return ds.State.IterateRange(
+		from,
+		to,
+		ascending,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, prefix) {
+				return false
+			}
+			offer := &Offer{}
+			err := ds.szlr.Deserialize(value, offer)
+			if err != nil {
+				return false
+			}
+			return fn(offer)
+		},
+	)
+}
+
+// IterateOffersByBidder goes through the offers made by the bidder
+func (ds *DomainStore) IterateOffersByBidder(bidder keys.Address, fn func(offer *Offer) bool) (stopped bool) {
+	return ds.IterateOffersByBidderFrom(bidder, "", true, fn)
+}
+
+// IterateOffersByBidderFrom goes through the offers made by the bidder in name order, from the offer on the start
+// name on or from the first one if there is no start name
+func (ds *DomainStore) IterateOffersByBidderFrom(bidder keys.Address, start Name, ascending bool, fn func(offer *Offer) bool) (stopped bool) {
+	prefix := storage.Prefix(string(ds.bidderPrefix) + hex.EncodeToString(bidder))
+	from, to := storage.PageRange(prefix, []byte(start.String()), ascending)
+	return ds.State.IterateRange(
+		from,
+		to,
+		ascending,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, prefix) {
+				return false
+			}
+			offer, err := ds.getOffer(value)
+			if err != nil {
+				return false
+			}
+			return fn(offer)
+		},
+	)
+}
+
+// ExpireOffers refunds the offers expiring at the height or before
+func (ds *DomainStore) ExpireOffers(height int64, balances *balance.Store, currency balance.Currency) error {
+	offers := make([]*Offer, 0)
+	ds.State.IterateRange(
+		ds.offerExpiryPrefix,
+		[]byte(fmt.Sprintf("%s%020d", ds.offerExpiryPrefix, height+1)),
+		true,
+		func(key, value []byte) bool {
+			offer, err := ds.getOffer(value)
+			if err == nil {
+				offers = append(offers, offer)
+			}
+			return false
+		},
+	)
+
+	for _, offer := range offers {
+		err := ds.RefundOffer(offer, balances, currency)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ds *DomainStore) getOffer(key []byte) (*Offer, error) {
+	data, err := ds.State.Get(key)
+	if err != nil || len(data) == 0 {
+		return nil, ErrOfferNotFound
+	}
+
+	offer := &Offer{}
+	err = ds.szlr.Deserialize(data, offer)
+	if err != nil {
+		return nil, errors.Wrap(err, "error de-serializing offer")
+	}
+	return offer, nil
+}
+
+func (ds *DomainStore) offerKey(name Name, bidder keys.Address) storage.StoreKey {
+	return storage.StoreKey(string(ds.offerPrefix) + name.String() + storage.DB_PREFIX + hex.EncodeToString(bidder))
+}
+
+func (ds *DomainStore) bidderKey(bidder keys.Address, name Name) storage.StoreKey {
+	return storage.StoreKey(string(ds.bidderPrefix) + hex.EncodeToString(bidder) + storage.DB_PREFIX + name.String())
+}
+
+func (ds *DomainStore) offerExpiryKey(offer *Offer) storage.StoreKey {
+	return storage.StoreKey(fmt.Sprintf("%s%020d%s%s%s%s", ds.offerExpiryPrefix, offer.ExpireHeight,
+		storage.DB_PREFIX, offer.Name, storage.DB_PREFIX, hex.EncodeToString(offer.Bidder)))
+}
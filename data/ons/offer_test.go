@@ -0,0 +1,79 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+func offer(t *testing.T, ds *DomainStore, balances *balance.Store, name Name, bidder keys.Address, amount, expire int64) {
+	coin := olt.NewCoinFromAmount(*balance.NewAmount(amount))
+	assert.NoError(t, balances.AddToAddress(OfferEscrow, coin))
+	assert.NoError(t, ds.SetOffer(&Offer{
+		Name:         name,
+		Bidder:       bidder,
+		Amount:       *balance.NewAmount(amount),
+		ExpireHeight: expire,
+	}))
+}
+
+func TestDomainStore_Offers(t *testing.T) {
+	ds, balances, _, cs := setupAuction(t)
+
+	alice, bob := keys.Address("alice"), keys.Address("bob")
+	offer(t, ds, balances, "short.ol", alice, 100, 10)
+	offer(t, ds, balances, "short.ol", bob, 200, 20)
+	offer(t, ds, balances, "shorter.ol", alice, 300, 30)
+	cs.Commit()
+
+	count := func(iterate func(fn func(offer *Offer) bool) bool) int {
+		n := 0
+		iterate(func(offer *Offer) bool {
+			n++
+			return false
+		})
+		return n
+	}
+	assert.Equal(t, 2, count(func(fn func(offer *Offer) bool) bool { return ds.IterateOffers("short.ol", fn) }))
+	assert.Equal(t, 1, count(func(fn func(offer *Offer) bool) bool { return ds.IterateOffers("shorter.ol", fn) }))
+	assert.Equal(t, 2, count(func(fn func(offer *Offer) bool) bool { return ds.IterateOffersByBidder(alice, fn) }))
+
+	// pages start from the bidder, or the name, in either order
+	assert.Equal(t, 1, count(func(fn func(offer *Offer) bool) bool { return ds.IterateOffersFrom("short.ol", bob, true, fn) }))
+	assert.Equal(t, 1, count(func(fn func(offer *Offer) bool) bool { return ds.IterateOffersFrom("short.ol", alice, false, fn) }))
+	assert.Equal(t, 1, count(func(fn func(offer *Offer) bool) bool {
+		return ds.IterateOffersByBidderFrom(alice, "shorter.ol", true, fn)
+	}))
+	assert.Equal(t, 1, count(func(fn func(offer *Offer) bool) bool {
+		return ds.IterateOffersByBidderFrom(alice, "short.ol", false, fn)
+	}))
+
+	got, err := ds.GetOffer("short.ol", bob)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, got.Amount.BigInt().Int64())
+
+	// only the offers expired by the height are refunded
+	assert.NoError(t, ds.ExpireOffers(20, balances, olt))
+	cs.Commit()
+	assert.EqualValues(t, 100, held(t, balances, alice))
+	assert.EqualValues(t, 200, held(t, balances, bob))
+	assert.EqualValues(t, 300, held(t, balances, OfferEscrow))
+
+	_, err = ds.GetOffer("short.ol", bob)
+	assert.Equal(t, ErrOfferNotFound, err)
+	assert.Equal(t, 1, count(func(fn func(offer *Offer) bool) bool { return ds.IterateOffersByBidder(alice, fn) }))
+
+	got, err = ds.GetOffer("shorter.ol", alice)
+	assert.NoError(t, err)
+	assert.NoError(t, ds.RefundOffer(got, balances, olt))
+	cs.Commit()
+	assert.EqualValues(t, 400, held(t, balances, alice))
+	assert.EqualValues(t, 0, held(t, balances, OfferEscrow))
+}
@@ -0,0 +1,145 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// RecordType is the kind of a resolver record of a domain
+type RecordType string
+
+const (
+	// address of the domain on a chain, keyed by the chain
+	RecordAddress RecordType = "addr"
+	// text record like email, avatar or url, keyed by its name
+	RecordText RecordType = "text"
+	// hash of the content the domain points to, a domain has at most one
+	RecordContentHash RecordType = "contenthash"
+	// arbitrary key/value entry
+	RecordData RecordType = "data"
+)
+
+var (
+	ErrInvalidRecord  = errors.New("invalid resolver record")
+	ErrRecordTooLarge = errors.New("resolver record too large")
+	ErrTooManyRecords = errors.New("too many resolver records")
+)
+
+// Record is a resolver record of a domain. A record is identified by its type with the chain for address records,
+// or with the key for text and data records.
+type Record struct {
+	Type  RecordType `json:"type"`
+	Chain chain.Type `json:"chain,omitempty"`
+	Key   string     `json:"key,omitempty"`
+	Value string     `json:"value"`
+}
+
+// Is tells whether the record has the identity of the other one
+func (r Record) Is(other Record) bool {
+	return r.Type == other.Type && r.Chain == other.Chain && r.Key == other.Key
+}
+
+// Size is the number of bytes of the record counted against the record size limit
+func (r Record) Size() int {
+	return len(r.Type) + len(r.Key) + len(r.Value)
+}
+
+// Validate checks the record is well formed, a record with an empty value removes the record it identifies
+func (r Record) Validate(opt *Options) error {
+	if r.Size() > opt.GetMaxRecordSize() {
+		return ErrRecordTooLarge
+	}
+
+	switch r.Type {
+	case RecordAddress:
+		if len(r.Key) > 0 || r.Chain.String() == "INVALID" {
+			return ErrInvalidRecord
+		}
+		if len(r.Value) == 0 {
+			return nil
+		}
+		return validateChainAddress(r.Chain, r.Value)
+	case RecordText, RecordData:
+		if len(r.Key) == 0 || r.Chain != chain.ONELEDGER {
+			return ErrInvalidRecord
+		}
+	case RecordContentHash:
+		if len(r.Key) > 0 || r.Chain != chain.ONELEDGER {
+			return ErrInvalidRecord
+		}
+	default:
+		return ErrInvalidRecord
+	}
+	return nil
+}
+
+func validateChainAddress(chainType chain.Type, value string) error {
+	switch chainType {
+	case chain.ONELEDGER:
+		addr := keys.Address{}
+		err := addr.UnmarshalText([]byte(value))
+		if err != nil {
+			return errors.Wrap(ErrInvalidRecord, err.Error())
+		}
+		if err := addr.Err(); err != nil {
+			return errors.Wrap(ErrInvalidRecord, err.Error())
+		}
+	case chain.BITCOIN:
+		if !isBitcoinAddress(value) {
+			return errors.Wrap(ErrInvalidRecord, "not a bitcoin address")
+		}
+	case chain.ETHEREUM:
+		if !common.IsHexAddress(value) {
+			return errors.Wrap(ErrInvalidRecord, "not an ethereum address")
+		}
+	}
+	return nil
+}
+
+// isBitcoinAddress checks the value decodes as an address of one of the bitcoin networks, a domain may point to
+// addresses on another network than the one the node bridges to
+func isBitcoinAddress(value string) bool {
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNet3Params, &chaincfg.RegressionNetParams} {
+		if _, err := btcutil.DecodeAddress(value, params); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRecord replaces the record with the same identity, or adds it. A record with an empty value removes it.
+func (d *Domain) SetRecord(record Record) {
+	for i, r := range d.Records {
+		if !r.Is(record) {
+			continue
+		}
+		if len(record.Value) == 0 {
+			d.Records = append(d.Records[:i], d.Records[i+1:]...)
+		} else {
+			d.Records[i] = record
+		}
+		return
+	}
+	if len(record.Value) > 0 {
+		d.Records = append(d.Records, record)
+	}
+}
+
+// GetRecord returns the record of the domain with the identity of the one given
+func (d *Domain) GetRecord(record Record) (Record, bool) {
+	for _, r := range d.Records {
+		if r.Is(record) {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
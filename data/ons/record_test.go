@@ -0,0 +1,70 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestRecord_Validate(t *testing.T) {
+	opt := &Options{MaxRecordSize: 64}
+
+	assert.NoError(t, Record{Type: RecordAddress, Chain: chain.BITCOIN, Value: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"}.Validate(opt))
+	assert.NoError(t, Record{Type: RecordAddress, Chain: chain.ETHEREUM, Value: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}.Validate(opt))
+	assert.NoError(t, Record{Type: RecordAddress, Chain: chain.ETHEREUM}.Validate(opt))
+	assert.NoError(t, Record{Type: RecordText, Key: "email", Value: "alice@example.com"}.Validate(opt))
+	assert.NoError(t, Record{Type: RecordContentHash, Value: "ipfs://QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"}.Validate(opt))
+
+	assert.NoError(t, Record{Type: RecordAddress, Chain: chain.BITCOIN, Value: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"}.Validate(opt))
+	assert.NoError(t, Record{Type: RecordAddress, Chain: chain.BITCOIN, Value: "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"}.Validate(opt))
+	assert.Error(t, Record{Type: RecordAddress, Chain: chain.BITCOIN, Value: "1BoatSLRHtKNngkdXEeobR76b53LETtpyU"}.Validate(opt))
+	assert.Error(t, Record{Type: RecordAddress, Chain: chain.ETHEREUM, Value: "not an address"}.Validate(opt))
+	assert.Error(t, Record{Type: RecordAddress, Chain: chain.Type(99), Value: "x"}.Validate(opt))
+	assert.Error(t, Record{Type: RecordText, Value: "no key"}.Validate(opt))
+	assert.Error(t, Record{Type: RecordContentHash, Key: "key", Value: "x"}.Validate(opt))
+	assert.Error(t, Record{Type: "other", Key: "key", Value: "x"}.Validate(opt))
+
+	large := make([]byte, 64)
+	assert.Equal(t, ErrRecordTooLarge, Record{Type: RecordData, Key: "k", Value: string(large)}.Validate(opt))
+}
+
+func TestDomain_SetRecord(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("records", db.NewDB("test", db.MemDBBackend, "")))
+	ds := NewDomainStore("d", cs)
+
+	d, err := NewDomain(keys.Address("abcd"), nil, "alice.ol", 1, "", 100)
+	assert.NoError(t, err)
+
+	btc := Record{Type: RecordAddress, Chain: chain.BITCOIN, Value: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"}
+	d.SetRecord(btc)
+	d.SetRecord(Record{Type: RecordText, Key: "email", Value: "alice@example.com"})
+	d.SetRecord(Record{Type: RecordText, Key: "email", Value: "alice@example.org"})
+	assert.Len(t, d.Records, 2)
+
+	assert.NoError(t, ds.Set(d))
+	cs.Commit()
+
+	d, err = ds.Get("alice.ol")
+	assert.NoError(t, err)
+	record, ok := d.GetRecord(Record{Type: RecordAddress, Chain: chain.BITCOIN})
+	assert.True(t, ok)
+	assert.Equal(t, btc, record)
+	record, ok = d.GetRecord(Record{Type: RecordText, Key: "email"})
+	assert.True(t, ok)
+	assert.Equal(t, "alice@example.org", record.Value)
+
+	// an empty value removes the record
+	d.SetRecord(Record{Type: RecordAddress, Chain: chain.BITCOIN})
+	_, ok = d.GetRecord(Record{Type: RecordAddress, Chain: chain.BITCOIN})
+	assert.False(t, ok)
+	assert.Len(t, d.Records, 1)
+}
@@ -5,8 +5,11 @@
 package ons
 
 import (
+	"bytes"
+
 	"github.com/pkg/errors"
 
+	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/serialize"
 	"github.com/Oneledger/protocol/storage"
 )
@@ -18,15 +21,34 @@ type DomainStore struct {
 	opt    *Options
 	szlr   serialize.Serializer
 	prefix []byte
+	// top level domains by the height they expire at
+	expiryPrefix []byte
+	// primary name of an address, the reverse of the domain pointing to it
+	primaryPrefix []byte
+	// auctions of top level names by name, and the names by the height their auction ends at
+	auctionPrefix []byte
+	endingPrefix  []byte
+	// offers on domains by domain, by bidder and by the height they expire at
+	offerPrefix       []byte
+	bidderPrefix      []byte
+	offerExpiryPrefix []byte
 }
 
 // NewDomainStore creates a new storage object from filepath and other configurations
 func NewDomainStore(prefix string, state *storage.State) *DomainStore {
 
 	return &DomainStore{
-		State:  state,
-		szlr:   serialize.GetSerializer(serialize.PERSISTENT),
-		prefix: storage.Prefix(prefix),
+		State:         state,
+		szlr:          serialize.GetSerializer(serialize.PERSISTENT),
+		prefix:        storage.Prefix(prefix),
+		expiryPrefix:  storage.Prefix(prefix + "e"),
+		primaryPrefix: storage.Prefix(prefix + "p"),
+		auctionPrefix: storage.Prefix(prefix + "a"),
+		endingPrefix:  storage.Prefix(prefix + "q"),
+
+		offerPrefix:       storage.Prefix(prefix + "o"),
+		bidderPrefix:      storage.Prefix(prefix + "w"),
+		offerExpiryPrefix: storage.Prefix(prefix + "x"),
 	}
 }
 
@@ -64,6 +86,29 @@ func (ds *DomainStore) Get(name Name) (*Domain, error) {
 }
 
 func (ds *DomainStore) Set(d *Domain) error {
+	// the domain no longer resolves to its old beneficiary, which loses it as primary name
+	old, err := ds.Get(d.Name)
+	if err == nil && !bytes.Equal(old.Beneficiary, d.Beneficiary) {
+		err = ds.clearPrimary(old)
+		if err != nil {
+			return err
+		}
+	}
+
+	// subdomains expire with their parent, only top level domains are indexed by expiry
+	if !d.Name.IsSub() {
+		if old != nil && old.ExpireHeight != d.ExpireHeight {
+			_, err = ds.State.Delete(ds.expiryKey(old))
+			if err != nil {
+				return err
+			}
+		}
+		err = ds.State.Set(ds.expiryKey(d), []byte(d.Name))
+		if err != nil {
+			return err
+		}
+	}
+
 	key := d.Name.toKey()
 
 	data, err := ds.szlr.Serialize(d)
@@ -92,6 +137,36 @@ func (ds *DomainStore) Iterate(fn func(name Name, domain *Domain) bool) (stopped
 		storage.Rangefix(string(ds.prefix)),
 		true,
 		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, ds.prefix) {
+				return false
+			}
+			nameKey := string(key[len(ds.prefix):])
+			domain := &Domain{}
+			err := ds.szlr.Deserialize(value, domain)
+			if err != nil {
+				return false
+			}
+			return fn(Name(reverse(nameKey)), domain)
+		},
+	)
+}
+
+// IterateFrom goes through the domains in key order from the domain with the start name on, or from the first
+// domain if the name is empty
+func (ds *DomainStore) IterateFrom(start Name, ascending bool, fn func(name Name, domain *Domain) bool) (stopped bool) {
+	var startKey []byte
+	if len(start) > 0 {
+		startKey = start.toKey()
+	}
+	from, to := storage.PageRange(ds.prefix, startKey, ascending)
+	return ds.State.IterateRange(
+		from,
+		to,
+		ascending,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, ds.prefix) {
+				return false
+			}
 			nameKey := string(key[len(ds.prefix):])
 			domain := &Domain{}
 			err := ds.szlr.Deserialize(value, domain)
@@ -104,12 +179,23 @@ func (ds *DomainStore) Iterate(fn func(name Name, domain *Domain) bool) (stopped
 }
 
 func (ds *DomainStore) IterateSubDomain(parentName Name, fn func(name Name, domain *Domain) bool) (stopped bool) {
-	start := append(ds.prefix, ("." + parentName).toKey()...)
-	end := storage.Rangefix(string(start))
+	return ds.IterateSubDomainFrom(parentName, "", true, fn)
+}
+
+// IterateSubDomainFrom goes through the subdomains of the parent in key order, from the start subdomain on or from the
+// first one if there is no start name
+func (ds *DomainStore) IterateSubDomainFrom(parentName Name, start Name, ascending bool,
+	fn func(name Name, domain *Domain) bool) (stopped bool) {
+	prefix := append(append([]byte{}, ds.prefix...), ("." + parentName).toKey()...)
+	var startKey []byte
+	if key := append(append([]byte{}, ds.prefix...), start.toKey()...); len(start) > 0 && bytes.HasPrefix(key, prefix) {
+		startKey = key[len(prefix):]
+	}
+	from, to := storage.PageRange(prefix, startKey, ascending)
 	return ds.State.IterateRange(
-		start,
-		end,
-		true,
+		from,
+		to,
+		ascending,
 		func(key, value []byte) bool {
 			name := string(key[len(ds.prefix):])
 			domain := &Domain{}
@@ -125,9 +211,13 @@ func (ds *DomainStore) IterateSubDomain(parentName Name, fn func(name Name, doma
 func (ds *DomainStore) DeleteAllSubdomains(name Name) error {
 
 	ds.IterateSubDomain(name, func(name Name, domain *Domain) bool {
+		err := ds.clearPrimary(domain)
+		if err != nil {
+			return false
+		}
 
 		prefixed := append(ds.prefix, name.toKey()...)
-		_, err := ds.State.Delete(prefixed)
+		_, err = ds.State.Delete(prefixed)
 		if err != nil {
 			return false
 		}
@@ -146,8 +236,58 @@ func (ds *DomainStore) DeleteASubdomain(subdomainName Name) error {
 		return errors.New("not a subdomain")
 	}
 
+	err = ds.clearPrimary(domain)
+	if err != nil {
+		return err
+	}
+
 	prefixed := append(ds.prefix, subdomainName.toKey()...)
 	_, err = ds.State.Delete(prefixed)
 
 	return err
 }
+
+// SetPrimary makes the name the primary name of the address, an empty name clears it
+func (ds *DomainStore) SetPrimary(addr keys.Address, name Name) error {
+	key := storage.StoreKey(string(ds.primaryPrefix) + addr.String())
+	if len(name) == 0 {
+		_, err := ds.State.Delete(key)
+		return err
+	}
+	return ds.State.Set(key, []byte(name))
+}
+
+// GetPrimary returns the name claimed as primary by the address, whether or not it still resolves to the address
+func (ds *DomainStore) GetPrimary(addr keys.Address) (Name, bool) {
+	key := storage.StoreKey(string(ds.primaryPrefix) + addr.String())
+	data, err := ds.State.Get(key)
+	if err != nil || len(data) == 0 {
+		return "", false
+	}
+	return Name(data), true
+}
+
+// ReverseLookup returns the primary name of the address, as long as the domain is active and resolves back to it
+func (ds *DomainStore) ReverseLookup(addr keys.Address, height int64) (Name, error) {
+	name, ok := ds.GetPrimary(addr)
+	if !ok {
+		return "", ErrDomainNotFound
+	}
+	d, err := ds.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if !d.IsActive(height) || !bytes.Equal(d.Beneficiary, addr) {
+		return "", ErrDomainNotFound
+	}
+	return name, nil
+}
+
+// clearPrimary removes the domain as primary name of its beneficiary
+func (ds *DomainStore) clearPrimary(d *Domain) error {
+	name, ok := ds.GetPrimary(d.Beneficiary)
+	if !ok || name != d.Name {
+		return nil
+	}
+	return ds.SetPrimary(d.Beneficiary, "")
+}
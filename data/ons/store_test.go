@@ -0,0 +1,91 @@
+/*
+
+ */
+
+package ons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestDomainStore_ReverseLookup(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("primary", db.NewDB("test", db.MemDBBackend, "")))
+	ds := NewDomainStore("d", cs)
+
+	alice := keys.Address("aaaaaaaaaaaaaaaaaaaa")
+	bob := keys.Address("bbbbbbbbbbbbbbbbbbbb")
+
+	d, err := NewDomain(alice, alice, "alice.ol", 1, "", 100)
+	assert.NoError(t, err)
+	assert.NoError(t, ds.Set(d))
+	sub, err := NewDomain(alice, alice, "pay.alice.ol", 1, "", 100)
+	assert.NoError(t, err)
+	assert.NoError(t, ds.Set(sub))
+	assert.NoError(t, ds.SetPrimary(alice, "alice.ol"))
+	cs.Commit()
+
+	name, err := ds.ReverseLookup(alice, 10)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "alice.ol", name)
+
+	// the name is hidden once it expires
+	_, err = ds.ReverseLookup(alice, 100)
+	assert.Error(t, err)
+
+	// iterating the domains skips the primary names kept next to them
+	count := 0
+	ds.Iterate(func(name Name, domain *Domain) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 2, count)
+
+	// the domain points somewhere else, so alice loses it
+	d.SetAccountAddress(bob)
+	assert.NoError(t, ds.Set(d))
+	cs.Commit()
+	_, ok := ds.GetPrimary(alice)
+	assert.False(t, ok)
+
+	// deleting the subdomain clears the primary name pointing to it
+	assert.NoError(t, ds.SetPrimary(alice, "pay.alice.ol"))
+	cs.Commit()
+	assert.NoError(t, ds.DeleteASubdomain("pay.alice.ol"))
+	cs.Commit()
+	_, ok = ds.GetPrimary(alice)
+	assert.False(t, ok)
+	_, err = ds.ReverseLookup(alice, 10)
+	assert.Error(t, err)
+}
+
+func TestDomainStore_IterateSubDomainFrom(t *testing.T) {
+	cs := storage.NewState(storage.NewChainState("subdomains", db.NewDB("test", db.MemDBBackend, "")))
+	ds := NewDomainStore("d", cs)
+
+	alice := keys.Address("aaaaaaaaaaaaaaaaaaaa")
+	for _, name := range []string{"alice.ol", "a.alice.ol", "b.alice.ol", "c.alice.ol", "malice.ol", "x.malice.ol"} {
+		d, err := NewDomain(alice, alice, name, 1, "", 100)
+		assert.NoError(t, err)
+		assert.NoError(t, ds.Set(d))
+	}
+	cs.Commit()
+
+	names := func(start Name, ascending bool) []Name {
+		list := make([]Name, 0)
+		ds.IterateSubDomainFrom("alice.ol", start, ascending, func(name Name, domain *Domain) bool {
+			list = append(list, name)
+			return false
+		})
+		return list
+	}
+	// only the subdomains of the parent, not the ones of a name ending like it
+	assert.Equal(t, []Name{"a.alice.ol", "b.alice.ol", "c.alice.ol"}, names("", true))
+	assert.Equal(t, []Name{"b.alice.ol", "c.alice.ol"}, names("b.alice.ol", true))
+	assert.Equal(t, []Name{"b.alice.ol", "a.alice.ol"}, names("b.alice.ol", false))
+}
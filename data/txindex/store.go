@@ -0,0 +1,169 @@
+/*
+
+ */
+
+package txindex
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// AddressTags are the tags of the tx events whose values are addresses taking part in the tx
+var AddressTags = []string{
+	"tx.owner", "tx.from", "tx.to", "tx.grantee", "tx.locker", "tx.redeemer", "tx.contributor", "tx.funder",
+	"tx.proposer", "tx.voter", "tx.validator", "tx.multisig",
+}
+
+const (
+	addressPrefix = "a:"
+	typePrefix    = "t:"
+	heightPrefix  = "h:"
+)
+
+// Record is what the index keeps of a delivered tx, the tx itself is read from tendermint by its hash
+type Record struct {
+	Hash   []byte `json:"hash"`
+	Height int64  `json:"height"`
+	Type   string `json:"type"`
+	// result code of the tx, failed txs are indexed too
+	Code uint32 `json:"code"`
+}
+
+// Position returns where the record sits in the index, records are ordered by height
+func (r Record) Position() string {
+	return fmt.Sprintf("%020d:%s", r.Height, hex.EncodeToString(r.Hash))
+}
+
+// Filter selects the records of a search, empty fields match every record
+type Filter struct {
+	Address keys.Address
+	Type    string
+	// inclusive height range, 0 for no bound
+	MinHeight int64
+	MaxHeight int64
+}
+
+// Store is the local secondary index of the txs of the chain, by address, type and height. It is kept by every node
+// for its own queries and is not part of the chain state.
+type Store struct {
+	storage.SessionedStorage
+	ser  serialize.Serializer
+	lock sync.RWMutex
+}
+
+func NewStore(config config.Server, dbDir string) *Store {
+
+	store := storage.NewStorageDB(storage.KEYVALUE, "txIndex", dbDir, config.Node.DB)
+
+	return &Store{
+		SessionedStorage: store,
+		ser:              serialize.GetSerializer(serialize.LOCAL),
+	}
+}
+
+// Add indexes the record under every address taking part in the tx, under its type and under its height. It is
+// stored once Commit is called.
+func (s *Store) Add(record Record, addrs []keys.Address) error {
+	dat, err := s.ser.Serialize(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize tx record")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	session := s.BeginSession()
+	pos := record.Position()
+	indexed := make(map[string]bool)
+	for _, addr := range addrs {
+		if len(addr) == 0 || indexed[string(addr)] {
+			continue
+		}
+		indexed[string(addr)] = true
+		err = session.Set(storage.StoreKey(addressKey(addr)+pos), dat)
+		if err != nil {
+			return err
+		}
+	}
+	err = session.Set(storage.StoreKey(typeKey(record.Type)+pos), dat)
+	if err != nil {
+		return err
+	}
+	return session.Set(storage.StoreKey(heightPrefix+pos), dat)
+}
+
+// Commit stores the records added since the last commit, it is called once per block
+func (s *Store) Commit() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ok := s.BeginSession().Commit()
+	if !ok {
+		return errors.New("error committing to tx index")
+	}
+	return nil
+}
+
+// Iterate goes through the records matching the filter in height order, from the record at the start position on
+func (s *Store) Iterate(filter Filter, start string, ascending bool, fn func(pos string, record Record) bool) bool {
+	prefix := heightPrefix
+	switch {
+	case len(filter.Address) > 0:
+		prefix = addressKey(filter.Address)
+	case len(filter.Type) > 0:
+		prefix = typeKey(filter.Type)
+	}
+
+	from := []byte(prefix)
+	if filter.MinHeight > 0 {
+		from = []byte(fmt.Sprintf("%s%020d", prefix, filter.MinHeight))
+	}
+	to := storage.Rangefix(prefix)
+	if filter.MaxHeight > 0 {
+		to = []byte(fmt.Sprintf("%s%020d", prefix, filter.MaxHeight+1))
+	}
+	if len(start) > 0 {
+		key := []byte(prefix + start)
+		if ascending && bytes.Compare(key, from) > 0 {
+			from = key
+		}
+		// the end of a range is left out of it
+		key = append(key, 0)
+		if !ascending && bytes.Compare(key, to) < 0 {
+			to = key
+		}
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.BeginSession().GetIterable().IterateRange(from, to, ascending, func(key, value []byte) bool {
+		record := Record{}
+		err := s.ser.Deserialize(value, &record)
+		if err != nil {
+			return false
+		}
+		if len(filter.Type) > 0 && record.Type != filter.Type {
+			return false
+		}
+		return fn(string(key[len(prefix):]), record)
+	})
+}
+
+func addressKey(addr keys.Address) string {
+	return addressPrefix + hex.EncodeToString(addr) + ":"
+}
+
+func typeKey(txType string) string {
+	return typePrefix + txType + ":"
+}
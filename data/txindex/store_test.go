@@ -0,0 +1,68 @@
+package txindex
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Oneledger/protocol/config"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+func setup(t *testing.T) (*Store, func()) {
+	dir, err := ioutil.TempDir("", "txindex")
+	assert.NoError(t, err)
+
+	store := NewStore(*config.DefaultServerConfig(), dir)
+	return store, func() { _ = os.RemoveAll(dir) }
+}
+
+func record(height int64, b byte, txType string) Record {
+	return Record{Hash: []byte{b, b, b, b}, Height: height, Type: txType}
+}
+
+func collect(store *Store, filter Filter, start string, ascending bool) ([]int64, []string) {
+	heights := make([]int64, 0)
+	positions := make([]string, 0)
+	store.Iterate(filter, start, ascending, func(pos string, record Record) bool {
+		heights = append(heights, record.Height)
+		positions = append(positions, pos)
+		return false
+	})
+	return heights, positions
+}
+
+func TestStore_Iterate(t *testing.T) {
+	store, cleanup := setup(t)
+	defer cleanup()
+
+	alice := keys.Address{1, 1, 1}
+	bob := keys.Address{2, 2, 2}
+
+	assert.NoError(t, store.Add(record(1, 1, "SEND"), []keys.Address{alice, bob, alice}))
+	assert.NoError(t, store.Add(record(2, 2, "DELEGATE"), []keys.Address{alice}))
+	assert.NoError(t, store.Add(record(3, 3, "SEND"), []keys.Address{bob}))
+	assert.NoError(t, store.Add(record(12, 4, "SEND"), []keys.Address{alice}))
+	assert.NoError(t, store.Commit())
+
+	heights, _ := collect(store, Filter{}, "", true)
+	assert.Equal(t, []int64{1, 2, 3, 12}, heights)
+
+	heights, _ = collect(store, Filter{Address: alice}, "", false)
+	assert.Equal(t, []int64{12, 2, 1}, heights)
+
+	heights, _ = collect(store, Filter{Address: alice, Type: "SEND"}, "", true)
+	assert.Equal(t, []int64{1, 12}, heights)
+
+	heights, _ = collect(store, Filter{Type: "SEND", MinHeight: 2, MaxHeight: 3}, "", true)
+	assert.Equal(t, []int64{3}, heights)
+
+	// the start position is part of the page
+	_, positions := collect(store, Filter{}, "", false)
+	heights, _ = collect(store, Filter{}, positions[1], false)
+	assert.Equal(t, []int64{3, 2, 1}, heights)
+	heights, _ = collect(store, Filter{}, positions[1], true)
+	assert.Equal(t, []int64{3, 12}, heights)
+}
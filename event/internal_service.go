@@ -5,12 +5,16 @@
 package event
 
 import (
+	"strings"
+	"sync"
+
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/action/eth"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/chains/ethereum"
 	"github.com/Oneledger/protocol/consensus"
 	ethereum2 "github.com/Oneledger/protocol/data/ethereum"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/log"
 
 	"github.com/google/uuid"
@@ -28,14 +32,25 @@ type Service struct {
 
 	//only support local client for broadcasting internal txs
 	tmrpc *tmclient.Local
+
+	// nonces in the committed state, and the next nonce after the internal txs still waiting in the mempool
+	nonces  *nonce.Store
+	pending *pendingNonce
 }
 
-func NewService(ctx node.Context, logger *log.Logger, router action.Router, tmnode *consensus.Node) *Service {
+type pendingNonce struct {
+	sync.Mutex
+	next uint64
+}
+
+func NewService(ctx node.Context, logger *log.Logger, router action.Router, tmnode *consensus.Node, nonces *nonce.Store) *Service {
 	return &Service{
 		nodeCtx: ctx,
 		logger:  logger,
 		router:  router,
 		tmrpc:   tmclient.NewLocal(tmnode),
+		nonces:  nonces,
+		pending: &pendingNonce{},
 	}
 }
 
@@ -81,6 +96,19 @@ func (svc Service) InternalBroadcast(request InternalBroadcastRequest, reply *Br
 	if err != nil {
 		return errors.Wrap(err, "wrong node private validator key")
 	}
+
+	// internal txs are broadcast one after the other, so a tx may have to follow others not committed yet
+	svc.pending.Lock()
+	defer svc.pending.Unlock()
+	committed, err := svc.nonces.Get(svc.nodeCtx.ValidatorAddress())
+	if err != nil {
+		return errors.Wrap(err, "failed to get nonce")
+	}
+	if svc.pending.next < committed {
+		svc.pending.next = committed
+	}
+	request.RawTx.Nonce = svc.pending.next
+
 	signed, err := h.Sign(request.RawTx.RawBytes())
 	if err != nil {
 		return errors.Wrap(err, "signing failed")
@@ -99,6 +127,12 @@ func (svc Service) InternalBroadcast(request InternalBroadcastRequest, reply *Br
 	}
 
 	reply.FromResultBroadcastTx(result)
+	if reply.OK {
+		svc.pending.next++
+	} else if strings.Contains(reply.Log, action.ErrInvalidNonce.Msg) {
+		// txs in between were dropped from the mempool, start over from the committed nonce
+		svc.pending.next = 0
+	}
 	return nil
 
 }
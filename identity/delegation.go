@@ -0,0 +1,32 @@
+package identity
+
+import (
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+// Delegation is the stake a token holder puts behind a validator without running a node
+type Delegation struct {
+	Delegator keys.Address   `json:"delegator"`
+	Validator keys.Address   `json:"validator"`
+	Amount    balance.Amount `json:"amount,string"`
+}
+
+func (d *Delegation) Bytes() []byte {
+	value, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(d)
+	if err != nil {
+		logger.Error("delegation not serializable", err)
+		return []byte{}
+	}
+	return value
+}
+
+func (d *Delegation) FromBytes(msg []byte) (*Delegation, error) {
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(msg, d)
+	if err != nil {
+		logger.Error("failed to deserialize delegation from bytes", err)
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// DelegationStore keeps the delegations keyed by (validator, delegator), so the delegators of a validator can be
+// iterated without going through the whole store
+type DelegationStore struct {
+	prefix []byte
+	store  *storage.State
+}
+
+func NewDelegationStore(prefix string, state *storage.State) *DelegationStore {
+	return &DelegationStore{
+		prefix: storage.Prefix(prefix),
+		store:  state,
+	}
+}
+
+func (ds *DelegationStore) WithState(state *storage.State) *DelegationStore {
+	ds.store = state
+	return ds
+}
+
+func (ds *DelegationStore) validatorPrefix(validator keys.Address) []byte {
+	prefix := append([]byte{}, ds.prefix...)
+	return append(prefix, validator.String()+storage.DB_PREFIX...)
+}
+
+func (ds *DelegationStore) key(delegator keys.Address, validator keys.Address) storage.StoreKey {
+	return append(ds.validatorPrefix(validator), delegator.String()...)
+}
+
+// Get returns the amount delegated by a delegator to a validator, zero if there is no delegation
+func (ds *DelegationStore) Get(delegator keys.Address, validator keys.Address) (*balance.Amount, error) {
+	value, err := ds.store.Get(ds.key(delegator, validator))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get delegation")
+	}
+	if len(value) == 0 {
+		return balance.NewAmount(0), nil
+	}
+	delegation, err := (&Delegation{}).FromBytes(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deserialize delegation")
+	}
+	return &delegation.Amount, nil
+}
+
+// Set stores a delegation, a delegation with no amount left is removed
+func (ds *DelegationStore) Set(delegation Delegation) error {
+	key := ds.key(delegation.Delegator, delegation.Validator)
+	if delegation.Amount.BigInt().Sign() <= 0 {
+		_, err := ds.store.Delete(key)
+		return errors.Wrap(err, "failed to delete delegation")
+	}
+	err := ds.store.Set(key, delegation.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set delegation")
+	}
+	return nil
+}
+
+// Iterate goes through every delegation
+func (ds *DelegationStore) Iterate(fn func(delegation *Delegation) bool) (stopped bool) {
+	return ds.iterate(ds.prefix, fn)
+}
+
+// IterateValidator goes through the delegations made to a validator
+func (ds *DelegationStore) IterateValidator(validator keys.Address, fn func(delegation *Delegation) bool) (stopped bool) {
+	return ds.iterate(ds.validatorPrefix(validator), fn)
+}
+
+func (ds *DelegationStore) iterate(prefix []byte, fn func(delegation *Delegation) bool) bool {
+	return ds.store.IterateRange(
+		prefix,
+		storage.Rangefix(string(prefix)),
+		true,
+		func(key, value []byte) bool {
+			if len(value) == 0 {
+				// removed in the current block
+				return false
+			}
+			delegation, err := (&Delegation{}).FromBytes(value)
+			if err != nil {
+				logger.Error("failed to deserialize delegation")
+				return false
+			}
+			return fn(delegation)
+		},
+	)
+}
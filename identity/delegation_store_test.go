@@ -0,0 +1,104 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func testAddress(b byte) keys.Address {
+	addr := make(keys.Address, 20)
+	addr[0] = b
+	return addr
+}
+
+func setupDelegations() (*ValidatorStore, *DelegationStore, *fees.Store, *storage.State) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	cs := storage.NewState(storage.NewChainState("delegation", db))
+
+	feePool := fees.NewStore("f", cs)
+	feePool.SetupOpt(&fees.FeeOption{
+		FeeCurrency:   balance.Currency{Name: "OLT", Chain: chain.ONELEDGER, Decimal: 18},
+		MinFeeDecimal: 9,
+	})
+	return NewValidatorStore("v", cs), NewDelegationStore("dl", cs), feePool, cs
+}
+
+func TestDelegationStore_SetGet(t *testing.T) {
+	_, ds, _, cs := setupDelegations()
+	validator := testAddress(1)
+
+	amt, err := ds.Get(testAddress(2), validator)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, amt.BigInt().Int64())
+
+	assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(2), Validator: validator, Amount: *balance.NewAmount(10)}))
+	assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(3), Validator: validator, Amount: *balance.NewAmount(30)}))
+	assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(2), Validator: testAddress(4), Amount: *balance.NewAmount(5)}))
+	cs.Commit()
+
+	amt, err = ds.Get(testAddress(2), validator)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 10, amt.BigInt().Int64())
+
+	total := int64(0)
+	ds.IterateValidator(validator, func(delegation *Delegation) bool {
+		assert.Equal(t, validator, delegation.Validator)
+		total += delegation.Amount.BigInt().Int64()
+		return false
+	})
+	assert.EqualValues(t, 40, total)
+
+	// a delegation with nothing left is removed
+	assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(3), Validator: validator, Amount: *balance.NewAmount(0)}))
+	cs.Commit()
+	amt, err = ds.Get(testAddress(3), validator)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, amt.BigInt().Int64())
+}
+
+func TestValidatorStore_DistributeFee(t *testing.T) {
+	vs, ds, feePool, cs := setupDelegations()
+	vs.SetOptions(&Options{CommissionRate: 10})
+
+	validatorAddr := testAddress(1)
+	stake := prepareStake("")
+	stake.ValidatorAddress = validatorAddr
+	stake.StakeAddress = validatorAddr
+	stake.Amount = *balance.NewAmount(1000)
+	assert.NoError(t, vs.HandleStake(stake))
+
+	assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(2), Validator: validatorAddr, Amount: *balance.NewAmount(600)}))
+	assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(3), Validator: validatorAddr, Amount: *balance.NewAmount(400)}))
+	assert.NoError(t, vs.HandleDelegation(validatorAddr, *balance.NewAmount(1000)))
+	cs.Commit()
+
+	validator, err := vs.Get(validatorAddr)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2000, validator.Power)
+
+	ctx := NewValidatorContext(nil, feePool, ds, nil, nil, nil)
+	feeShare := feePool.GetOpt().FeeCurrency.NewCoinFromAmount(*balance.NewAmount(10000))
+	assert.NoError(t, vs.distributeFee(ctx, validator, feeShare))
+
+	// delegators earn half of the fee, less 10% of commission
+	coin, _ := feePool.Get(testAddress(2))
+	assert.EqualValues(t, 2700, coin.Amount.BigInt().Int64())
+	coin, _ = feePool.Get(testAddress(3))
+	assert.EqualValues(t, 1800, coin.Amount.BigInt().Int64())
+	coin, _ = feePool.Get(validatorAddr)
+	assert.EqualValues(t, 5500, coin.Amount.BigInt().Int64())
+
+	assert.NoError(t, vs.HandleUndelegation(validatorAddr, *balance.NewAmount(1000)))
+	assert.Error(t, vs.HandleUndelegation(validatorAddr, *balance.NewAmount(1)))
+
+	// delegations can still be taken out of a validator purged from the set
+	assert.NoError(t, vs.HandleUndelegation(testAddress(9), *balance.NewAmount(1)))
+}
@@ -1,6 +1,8 @@
 package identity
 
 import (
+	"bytes"
+
 	"github.com/Oneledger/protocol/data/chain"
 	"github.com/Oneledger/protocol/data/keys"
 	"github.com/Oneledger/protocol/storage"
@@ -68,6 +70,29 @@ func (ws *WitnessStore) Iterate(chain chain.Type, fn func(addr keys.Address, wit
 }
 
 // Get witness addresses
+// IterateFrom goes through the witnesses of the chain in address order from the start address on, or from the first
+// witness if the address is empty
+func (ws *WitnessStore) IterateFrom(chain chain.Type, start keys.Address, ascending bool, fn func(addr keys.Address, witness *Witness) bool) (stopped bool) {
+	prefix := storage.StoreKey(string(ws.prefix) + chain.String() + storage.DB_PREFIX)
+	from, to := storage.PageRange(prefix, start, ascending)
+	return ws.store.IterateRange(
+		from,
+		to,
+		ascending,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, prefix) {
+				return false
+			}
+			witness, err := (&Witness{}).FromBytes(value)
+			if err != nil {
+				logger.Error("failed to deserialize witness")
+				return false
+			}
+			return fn(key[len(prefix):], witness)
+		},
+	)
+}
+
 func (ws *WitnessStore) GetWitnessAddresses(chain chain.Type) ([]keys.Address, error) {
 	witnessList := make([]keys.Address, 0)
 	ws.Iterate(chain, func(addr keys.Address, witness *Witness) bool {
@@ -0,0 +1,30 @@
+package identity
+
+import (
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// Options are the staking parameters set in genesis and changed through governance
+type Options struct {
+	// percentage of the delegators' fee share a validator keeps as commission
+	CommissionRate int64 `json:"commissionRate"`
+	// number of blocks unstaked funds wait before they are paid back
+	UnbondingBlocks int64 `json:"unbondingBlocks"`
+	// amount of stake that gives one voting power, stake is counted as is if not set
+	PowerReduction *balance.Amount `json:"powerReduction"`
+	// largest share of the total voting power a validator gets in consensus, 0 means no cap
+	MaxPowerPercent int64 `json:"maxPowerPercent"`
+	// percentage of the stake slashed from a validator caught double signing
+	DoubleSignSlashPercent int64 `json:"doubleSignSlashPercent"`
+	// percentage of the stake slashed from a validator missing too many blocks
+	DowntimeSlashPercent int64 `json:"downtimeSlashPercent"`
+	// number of blocks the missed blocks are counted over, 0 disables the downtime check
+	SignedBlocksWindow int64 `json:"signedBlocksWindow"`
+	// percentage of the window a validator has to sign to stay out of jail
+	MinSignedPercent int64 `json:"minSignedPercent"`
+	// number of blocks a slashed validator stays jailed before it can unjail
+	JailBlocks int64 `json:"jailBlocks"`
+	// account the slashed stake is paid to, the stake is burnt if not set
+	SlashRecipient keys.Address `json:"slashRecipient"`
+}
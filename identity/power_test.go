@@ -0,0 +1,122 @@
+package identity
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Oneledger/protocol/data/balance"
+)
+
+func TestValidatorStore_CalculatePower(t *testing.T) {
+	vs := setup()
+
+	// without a power reduction unit the stake is the power
+	assert.EqualValues(t, 1000, vs.calculatePower(*balance.NewAmount(1000)))
+	assert.EqualValues(t, 0, vs.calculatePower(*balance.NewAmount(0)))
+
+	// 18 decimals amounts are reduced to whole tokens
+	unit := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(18), nil)
+	vs.SetOptions(&Options{PowerReduction: balance.NewAmountFromBigInt(unit)})
+	stake := big.NewInt(0).Mul(big.NewInt(2500), unit)
+	assert.EqualValues(t, 2500, vs.calculatePower(*balance.NewAmountFromBigInt(stake)))
+	assert.EqualValues(t, 0, vs.calculatePower(*balance.NewAmountFromBigInt(big.NewInt(0).Sub(unit, big.NewInt(1)))))
+}
+
+func TestValidatorStore_CalculatePower_Int64Boundary(t *testing.T) {
+	vs := setup()
+
+	maxInt := big.NewInt(math.MaxInt64)
+	assert.EqualValues(t, maxValidatorPower, vs.calculatePower(*balance.NewAmountFromBigInt(maxInt)))
+
+	overflow := big.NewInt(0).Add(maxInt, big.NewInt(1))
+	assert.EqualValues(t, maxValidatorPower, vs.calculatePower(*balance.NewAmountFromBigInt(overflow)))
+
+	huge := big.NewInt(0).Mul(maxInt, maxInt)
+	assert.EqualValues(t, maxValidatorPower, vs.calculatePower(*balance.NewAmountFromBigInt(huge)))
+
+	assert.EqualValues(t, maxValidatorPower, vs.calculatePower(*balance.NewAmount(maxValidatorPower)))
+	assert.EqualValues(t, maxValidatorPower-1, vs.calculatePower(*balance.NewAmount(maxValidatorPower - 1)))
+
+	// reduced back into range
+	vs.SetOptions(&Options{PowerReduction: balance.NewAmount(16)})
+	assert.EqualValues(t, math.MaxInt64/16, vs.calculatePower(*balance.NewAmountFromBigInt(maxInt)))
+}
+
+func sum(powers []int64) *big.Int {
+	total := big.NewInt(0)
+	for _, power := range powers {
+		total.Add(total, big.NewInt(power))
+	}
+	return total
+}
+
+// share of the power in percent
+func share(power int64, powers []int64) float64 {
+	return float64(power) * 100 / float64(sum(powers).Int64())
+}
+
+func TestValidatorStore_ConsensusPowers_Cap(t *testing.T) {
+	vs := setup()
+	vs.SetOptions(&Options{MaxPowerPercent: 40})
+
+	// the cap is a share of the capped total, the excess of the largest validator isn't counted
+	powers := vs.consensusPowers([]int64{1000, 100, 100, 100})
+	assert.Equal(t, []int64{200, 100, 100, 100}, powers)
+	assert.LessOrEqual(t, share(powers[0], powers), float64(40))
+
+	powers = vs.consensusPowers([]int64{5000, 4000, 100, 100, 100, 0})
+	for _, power := range powers {
+		assert.LessOrEqual(t, share(power, powers), float64(40))
+	}
+	assert.EqualValues(t, 0, powers[5])
+
+	// no cap keeps a share under 33% with three validators, they get equal powers
+	vs.SetOptions(&Options{MaxPowerPercent: 33})
+	assert.Equal(t, []int64{1, 1, 1}, vs.consensusPowers([]int64{100, 1, 1}))
+
+	// shares within the cap are left alone
+	assert.Equal(t, []int64{30, 30, 30, 10}, vs.consensusPowers([]int64{30, 30, 30, 10}))
+
+	vs.SetOptions(&Options{})
+	assert.Equal(t, []int64{900, 100}, vs.consensusPowers([]int64{900, 100}))
+}
+
+func TestValidatorStore_ConsensusPowers_Saturated(t *testing.T) {
+	vs := setup()
+
+	// several saturated validators are scaled down together under the total tendermint accepts
+	powers := vs.consensusPowers([]int64{maxValidatorPower, maxValidatorPower, maxValidatorPower, 1000})
+	assert.True(t, sum(powers).Cmp(big.NewInt(maxValidatorPower)) <= 0)
+	assert.Equal(t, powers[0], powers[1])
+	assert.Equal(t, powers[0], powers[2])
+	assert.True(t, powers[0] > powers[3])
+
+	vs.SetOptions(&Options{MaxPowerPercent: 33})
+	powers = vs.consensusPowers([]int64{maxValidatorPower, maxValidatorPower, maxValidatorPower, maxValidatorPower / 2})
+	assert.True(t, sum(powers).Cmp(big.NewInt(maxValidatorPower)) <= 0)
+	for _, power := range powers {
+		assert.LessOrEqual(t, share(power, powers), float64(33))
+	}
+}
+
+func TestValidatorStore_UpdatePowers(t *testing.T) {
+	vs := setup()
+
+	stake := prepareStake("")
+	stake.ValidatorAddress = testAddress(1)
+	stake.StakeAddress = testAddress(1)
+	stake.Amount = *balance.NewAmount(5000)
+	assert.NoError(t, vs.HandleStake(stake))
+	vs.store.Commit()
+
+	vs.SetOptions(&Options{PowerReduction: balance.NewAmount(100)})
+	assert.NoError(t, vs.UpdatePowers())
+	vs.store.Commit()
+
+	validator, err := vs.Get(testAddress(1))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 50, validator.Power)
+}
@@ -0,0 +1,209 @@
+package identity
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/abci/types"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+)
+
+// HandleSlashing punishes the validators reported by tendermint at the beginning of a block. Validators with evidence
+// of double signing, and validators which missed too many blocks in the signing window, lose part of their stake and
+// get jailed. This should happen before the validator queue is set up, so jailed validators enter it without power.
+// It returns the jailed validators, which are only taken out of consensus with SetJailedInBlock once the state
+// changes are kept.
+func (vs *ValidatorStore) HandleSlashing(ctx *ValidatorContext, req types.RequestBeginBlock) ([]Validator, error) {
+	jailed := make([]Validator, 0)
+	height := req.Header.Height
+
+	for _, evidence := range req.ByzantineValidators {
+		validator, err := vs.Get(evidence.Validator.Address)
+		if err != nil {
+			logger.Error("evidence for unknown validator", keys.Address(evidence.Validator.Address).String())
+			continue
+		}
+		if validator.Jailed {
+			continue
+		}
+		err = vs.slash(ctx, validator, vs.options.DoubleSignSlashPercent, height, evidence.Height)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to slash byzantine validator")
+		}
+		jailed = append(jailed, *validator)
+	}
+
+	window := vs.options.SignedBlocksWindow
+	if window <= 0 {
+		return jailed, nil
+	}
+	maxMissed := window * (100 - vs.options.MinSignedPercent) / 100
+
+	for _, vote := range req.LastCommitInfo.Votes {
+		validator, err := vs.Get(vote.Validator.Address)
+		if err != nil || validator.Jailed {
+			continue
+		}
+
+		changed := false
+		if height-validator.WindowStart >= window {
+			validator.WindowStart = height
+			validator.MissedBlocks = 0
+			changed = true
+		}
+		if !vote.SignedLastBlock {
+			validator.MissedBlocks++
+			changed = true
+		}
+
+		if validator.MissedBlocks > maxMissed {
+			err = vs.slash(ctx, validator, vs.options.DowntimeSlashPercent, height, height)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to slash offline validator")
+			}
+			jailed = append(jailed, *validator)
+			continue
+		}
+		if changed {
+			err = vs.set(*validator)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to update signing info")
+			}
+		}
+	}
+
+	return jailed, nil
+}
+
+// SetJailedInBlock sets the validators jailed in the current block, they are taken out of consensus at the end of it
+func (vs *ValidatorStore) SetJailedInBlock(jailed []Validator) {
+	vs.byzantine = make([]Validator, 0, len(jailed))
+	vs.byzantine = append(vs.byzantine, jailed...)
+}
+
+// slash takes the percentage of the validator's own stake, of every delegation to it and of the stake unbonding from
+// it since the infraction, and jails it. The slashed stake goes to the slash recipient from the options, or is burnt
+// if there is none.
+func (vs *ValidatorStore) slash(ctx *ValidatorContext, validator *Validator, percent int64, height int64, infractionHeight int64) error {
+	if percent > 100 {
+		percent = 100
+	}
+	slashed := big.NewInt(0)
+	if percent > 0 {
+		slashed.Mul(validator.Staking.BigInt(), big.NewInt(percent))
+		slashed.Quo(slashed, big.NewInt(100))
+	}
+	delegated, err := vs.slashDelegations(ctx, validator.Address, percent)
+	if err != nil {
+		return errors.Wrap(err, "failed to slash delegations")
+	}
+	unbonding, err := ctx.Unbondings.Slash(validator.Address, infractionHeight, percent, vs.options.UnbondingBlocks)
+	if err != nil {
+		return errors.Wrap(err, "failed to slash unbondings")
+	}
+
+	validator.Staking = *balance.NewAmountFromBigInt(big.NewInt(0).Sub(validator.Staking.BigInt(), slashed))
+	validator.Delegation = *balance.NewAmountFromBigInt(big.NewInt(0).Sub(validator.Delegation.BigInt(), delegated))
+	slashed.Add(slashed, delegated)
+	slashed.Add(slashed, unbonding)
+	validator.Jailed = true
+	validator.JailedUntil = height + vs.options.JailBlocks
+	validator.MissedBlocks = 0
+	validator.Power = 0
+	err = vs.set(*validator)
+	if err != nil {
+		return err
+	}
+
+	if slashed.Sign() > 0 {
+		currency, ok := ctx.Currencies.GetCurrencyByName("VT")
+		if !ok {
+			return errors.New("stake token not registered")
+		}
+		amount := *balance.NewAmountFromBigInt(slashed)
+		if len(vs.options.SlashRecipient) > 0 {
+			err = ctx.Balances.AddToAddress(vs.options.SlashRecipient, currency.NewCoinFromAmount(amount))
+			if err != nil {
+				return errors.Wrap(err, "failed to pay the slashed stake")
+			}
+		} else {
+			err = ctx.Supplies.Remove(currency, amount)
+			if err != nil {
+				return errors.Wrap(err, "failed to burn the slashed stake")
+			}
+		}
+	}
+
+	logger.Info("validator slashed and jailed", validator.Address.String(), "slashed", slashed.String(),
+		"jailedUntil", validator.JailedUntil)
+	return nil
+}
+
+// slashDelegations takes the percentage of every delegation to the validator, and returns the total taken
+func (vs *ValidatorStore) slashDelegations(ctx *ValidatorContext, validatorAddress keys.Address, percent int64) (*big.Int, error) {
+	total := big.NewInt(0)
+	if percent <= 0 {
+		return total, nil
+	}
+
+	delegations := make([]Delegation, 0)
+	ctx.Delegations.IterateValidator(validatorAddress, func(delegation *Delegation) bool {
+		delegations = append(delegations, *delegation)
+		return false
+	})
+
+	for _, delegation := range delegations {
+		cut := big.NewInt(0).Mul(delegation.Amount.BigInt(), big.NewInt(percent))
+		cut.Quo(cut, big.NewInt(100))
+		delegation.Amount = *balance.NewAmountFromBigInt(big.NewInt(0).Sub(delegation.Amount.BigInt(), cut))
+		err := ctx.Delegations.Set(delegation)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, cut)
+	}
+	return total, nil
+}
+
+// HandleUnjail brings a jailed validator back into the validator set once its jail time is over
+func (vs *ValidatorStore) HandleUnjail(validatorAddress keys.Address, height int64) error {
+	validator, err := vs.Get(validatorAddress)
+	if err != nil {
+		return errors.Wrap(err, "error deserialize validator")
+	}
+
+	if !validator.Jailed {
+		return errors.New("validator is not jailed")
+	}
+	if height < validator.JailedUntil {
+		return fmt.Errorf("validator is jailed until height %d", validator.JailedUntil)
+	}
+
+	validator.Jailed = false
+	validator.JailedUntil = 0
+	validator.WindowStart = height
+	validator.MissedBlocks = 0
+	validator.Power = vs.votingPower(validator)
+	if validator.Power <= 0 {
+		return errors.New("not enough stake left to unjail")
+	}
+
+	err = vs.set(*validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to set validator for unjail")
+	}
+	return nil
+}
+
+func (vs *ValidatorStore) isJailedInBlock(addr keys.Address) bool {
+	for _, v := range vs.byzantine {
+		if bytes.Equal(v.Address, addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func setupSlashing(t *testing.T) (*ValidatorStore, *ValidatorContext, *storage.State) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	cs := storage.NewState(storage.NewChainState("slashing", db))
+
+	currencies := balance.NewCurrencySet()
+	assert.NoError(t, currencies.Register(balance.Currency{Id: 1, Name: "VT", Chain: chain.ONELEDGER, Unit: "vt"}))
+
+	vs := NewValidatorStore("v", cs)
+	vs.SetOptions(&Options{
+		DoubleSignSlashPercent: 10,
+		DowntimeSlashPercent:   1,
+		SignedBlocksWindow:     10,
+		MinSignedPercent:       50,
+		JailBlocks:             100,
+		SlashRecipient:         testAddress(9),
+	})
+	for _, b := range []byte{1, 2} {
+		stake := prepareStake("")
+		stake.ValidatorAddress = testAddress(b)
+		stake.StakeAddress = testAddress(b)
+		stake.Amount = *balance.NewAmount(1000)
+		assert.NoError(t, vs.HandleStake(stake))
+	}
+	ds := NewDelegationStore("d", cs)
+	for _, d := range []struct {
+		delegator byte
+		amount    int64
+	}{{3, 500}, {4, 300}} {
+		assert.NoError(t, ds.Set(Delegation{Delegator: testAddress(d.delegator), Validator: testAddress(1),
+			Amount: *balance.NewAmount(d.amount)}))
+		assert.NoError(t, vs.HandleDelegation(testAddress(1), *balance.NewAmount(d.amount)))
+	}
+	us := NewUnbondingStore("u", cs)
+	for _, created := range []int64{2, 4} {
+		assert.NoError(t, us.Add(Unbonding{Address: testAddress(3), Validator: testAddress(1),
+			Amount: *balance.NewAmount(100), MatureHeight: created + 10, CreationHeight: created}))
+	}
+	supplies := balance.NewSupplyStore("s", cs)
+	assert.NoError(t, supplies.Add(balance.Currency{Id: 1, Name: "VT", Chain: chain.ONELEDGER, Unit: "vt"}, *balance.NewAmount(2800)))
+	cs.Commit()
+
+	ctx := NewValidatorContext(balance.NewStore("b", cs), nil, ds, currencies, supplies, us)
+	return vs, ctx, cs
+}
+
+func TestValidatorStore_HandleSlashing_DoubleSign(t *testing.T) {
+	vs, ctx, cs := setupSlashing(t)
+
+	req := types.RequestBeginBlock{
+		Header: types.Header{Height: 5},
+		ByzantineValidators: []types.Evidence{
+			{Type: "duplicate/vote", Validator: types.Validator{Address: testAddress(1)}, Height: 4},
+		},
+	}
+	jailed, err := vs.HandleSlashing(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, jailed, 1)
+	// nothing leaves consensus until the slashing is kept
+	assert.Len(t, vs.byzantine, 0)
+	cs.Commit()
+	vs.SetJailedInBlock(jailed)
+	assert.True(t, vs.isJailedInBlock(testAddress(1)))
+
+	validator, err := vs.Get(testAddress(1))
+	assert.NoError(t, err)
+	assert.True(t, validator.Jailed)
+	assert.EqualValues(t, 105, validator.JailedUntil)
+	assert.EqualValues(t, 0, validator.Power)
+	assert.EqualValues(t, 900, validator.Staking.BigInt().Int64())
+	assert.EqualValues(t, 720, validator.Delegation.BigInt().Int64())
+	assert.False(t, vs.IsValidatorAddress(testAddress(1)))
+
+	// the delegations are slashed pro rata
+	amt, err := ctx.Delegations.Get(testAddress(3), testAddress(1))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 450, amt.BigInt().Int64())
+	amt, err = ctx.Delegations.Get(testAddress(4), testAddress(1))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 270, amt.BigInt().Int64())
+
+	// so is the stake unbonding since the infraction
+	unbonded := make([]int64, 0)
+	ctx.Unbondings.Iterate(func(unbonding *Unbonding) bool {
+		unbonded = append(unbonded, unbonding.Amount.BigInt().Int64())
+		return false
+	})
+	assert.Equal(t, []int64{100, 90}, unbonded)
+
+	coin, err := ctx.Balances.GetBalance(testAddress(9), ctx.Currencies)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 190, coin.GetCoin(balance.Currency{Id: 1, Name: "VT", Chain: chain.ONELEDGER, Unit: "vt"}).Amount.BigInt().Int64())
+
+	// staking more doesn't give the power back while jailed
+	stake := prepareStake("")
+	stake.ValidatorAddress = testAddress(1)
+	stake.StakeAddress = testAddress(1)
+	stake.Amount = *balance.NewAmount(100)
+	assert.NoError(t, vs.HandleStake(stake))
+	cs.Commit()
+
+	assert.Error(t, vs.HandleUnjail(testAddress(1), 104))
+	assert.NoError(t, vs.HandleUnjail(testAddress(1), 105))
+	cs.Commit()
+	validator, err = vs.Get(testAddress(1))
+	assert.NoError(t, err)
+	assert.False(t, validator.Jailed)
+	assert.EqualValues(t, 1720, validator.Power)
+	assert.Error(t, vs.HandleUnjail(testAddress(1), 106))
+}
+
+func TestValidatorStore_HandleSlashing_Downtime(t *testing.T) {
+	vs, ctx, cs := setupSlashing(t)
+	ctx.Balances = nil
+	vs.options.SlashRecipient = nil
+
+	for height := int64(1); height <= 6; height++ {
+		req := types.RequestBeginBlock{
+			Header: types.Header{Height: height},
+			LastCommitInfo: types.LastCommitInfo{Votes: []types.VoteInfo{
+				{Validator: types.Validator{Address: testAddress(1)}, SignedLastBlock: false},
+				{Validator: types.Validator{Address: testAddress(2)}, SignedLastBlock: true},
+			}},
+		}
+		_, err := vs.HandleSlashing(ctx, req)
+		assert.NoError(t, err)
+		cs.Commit()
+	}
+
+	// 6 missed blocks is more than half of the window
+	validator, err := vs.Get(testAddress(1))
+	assert.NoError(t, err)
+	assert.True(t, validator.Jailed)
+	assert.EqualValues(t, 106, validator.JailedUntil)
+	assert.EqualValues(t, 990, validator.Staking.BigInt().Int64())
+
+	// with no slash recipient the slashed stake is burnt
+	ts, err := ctx.Supplies.Get("VT")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2782, ts.Supply.BigInt().Int64())
+
+	validator, err = vs.Get(testAddress(2))
+	assert.NoError(t, err)
+	assert.False(t, validator.Jailed)
+	assert.EqualValues(t, 1000, validator.Power)
+}
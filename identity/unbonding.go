@@ -0,0 +1,36 @@
+package identity
+
+import (
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/serialize"
+)
+
+// Unbonding is stake or a delegation taken out of a validator, it is paid back to the stake address or the delegator
+// once the chain reaches the mature height
+type Unbonding struct {
+	Address      keys.Address   `json:"address"`
+	Validator    keys.Address   `json:"validator"`
+	Amount       balance.Amount `json:"amount,string"`
+	MatureHeight int64          `json:"matureHeight"`
+	// height the unbonding started at, it is slashed for infractions of the validator since then
+	CreationHeight int64 `json:"creationHeight"`
+}
+
+func (u *Unbonding) Bytes() []byte {
+	value, err := serialize.GetSerializer(serialize.PERSISTENT).Serialize(u)
+	if err != nil {
+		logger.Error("unbonding not serializable", err)
+		return []byte{}
+	}
+	return value
+}
+
+func (u *Unbonding) FromBytes(msg []byte) (*Unbonding, error) {
+	err := serialize.GetSerializer(serialize.PERSISTENT).Deserialize(msg, u)
+	if err != nil {
+		logger.Error("failed to deserialize unbonding from bytes", err)
+		return nil, err
+	}
+	return u, nil
+}
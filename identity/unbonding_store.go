@@ -0,0 +1,149 @@
+package identity
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/storage"
+)
+
+// UnbondingStore is the queue of stake waiting for its unbonding period to end, ordered by mature height
+type UnbondingStore struct {
+	prefix []byte
+	store  *storage.State
+}
+
+func NewUnbondingStore(prefix string, state *storage.State) *UnbondingStore {
+	return &UnbondingStore{
+		prefix: storage.Prefix(prefix),
+		store:  state,
+	}
+}
+
+func (us *UnbondingStore) WithState(state *storage.State) *UnbondingStore {
+	us.store = state
+	return us
+}
+
+func (us *UnbondingStore) heightPrefix(height int64) []byte {
+	// heights are zero padded so the queue is iterated in order
+	prefix := append([]byte{}, us.prefix...)
+	return append(prefix, fmt.Sprintf("%020d", height)+storage.DB_PREFIX...)
+}
+
+func (us *UnbondingStore) key(height int64, address keys.Address, validator keys.Address) storage.StoreKey {
+	return append(us.heightPrefix(height), address.String()+storage.DB_PREFIX+validator.String()...)
+}
+
+// Add queues an unbonding, it is merged with the one maturing at the same height for the same address and validator
+func (us *UnbondingStore) Add(unbonding Unbonding) error {
+	key := us.key(unbonding.MatureHeight, unbonding.Address, unbonding.Validator)
+	value, err := us.store.Get(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to get unbonding")
+	}
+	if len(value) > 0 {
+		queued, err := (&Unbonding{}).FromBytes(value)
+		if err != nil {
+			return errors.Wrap(err, "error deserialize unbonding")
+		}
+		amt := big.NewInt(0).Add(queued.Amount.BigInt(), unbonding.Amount.BigInt())
+		unbonding.Amount = *balance.NewAmountFromBigInt(amt)
+	}
+
+	err = us.store.Set(key, unbonding.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set unbonding")
+	}
+	return nil
+}
+
+// Iterate goes through the whole queue
+func (us *UnbondingStore) Iterate(fn func(unbonding *Unbonding) bool) (stopped bool) {
+	return us.iterate(us.prefix, storage.Rangefix(string(us.prefix)), fn)
+}
+
+// IterateMatured goes through the unbondings that are mature at the given height
+func (us *UnbondingStore) IterateMatured(height int64, fn func(unbonding *Unbonding) bool) (stopped bool) {
+	return us.iterate(us.prefix, us.heightPrefix(height+1), fn)
+}
+
+func (us *UnbondingStore) iterate(start, end []byte, fn func(unbonding *Unbonding) bool) bool {
+	return us.store.IterateRange(
+		start,
+		end,
+		true,
+		func(key, value []byte) bool {
+			if len(value) == 0 {
+				// released in the current block
+				return false
+			}
+			unbonding, err := (&Unbonding{}).FromBytes(value)
+			if err != nil {
+				logger.Error("failed to deserialize unbonding")
+				return false
+			}
+			return fn(unbonding)
+		},
+	)
+}
+
+// Slash takes the percentage of every unbonding from the validator which started at or after the infraction height,
+// and returns the total taken. Unbondings queued before the creation height was kept are dated from the unbonding
+// blocks.
+func (us *UnbondingStore) Slash(validator keys.Address, infractionHeight int64, percent int64, unbondingBlocks int64) (*big.Int, error) {
+	total := big.NewInt(0)
+	if percent <= 0 {
+		return total, nil
+	}
+
+	unbondings := make([]*Unbonding, 0)
+	us.Iterate(func(unbonding *Unbonding) bool {
+		created := unbonding.CreationHeight
+		if created == 0 {
+			created = unbonding.MatureHeight - unbondingBlocks
+		}
+		if bytes.Equal(unbonding.Validator, validator) && created >= infractionHeight {
+			unbondings = append(unbondings, unbonding)
+		}
+		return false
+	})
+
+	for _, unbonding := range unbondings {
+		cut := big.NewInt(0).Mul(unbonding.Amount.BigInt(), big.NewInt(percent))
+		cut.Quo(cut, big.NewInt(100))
+		unbonding.Amount = *balance.NewAmountFromBigInt(big.NewInt(0).Sub(unbonding.Amount.BigInt(), cut))
+		err := us.store.Set(us.key(unbonding.MatureHeight, unbonding.Address, unbonding.Validator), unbonding.Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set unbonding")
+		}
+		total.Add(total, cut)
+	}
+	return total, nil
+}
+
+// Release pays back every mature unbonding to its address and removes it from the queue
+func (us *UnbondingStore) Release(height int64, balances *balance.Store, currency balance.Currency) error {
+	matured := make([]*Unbonding, 0)
+	us.IterateMatured(height, func(unbonding *Unbonding) bool {
+		matured = append(matured, unbonding)
+		return false
+	})
+
+	for _, unbonding := range matured {
+		_, err := us.store.Delete(us.key(unbonding.MatureHeight, unbonding.Address, unbonding.Validator))
+		if err != nil {
+			return errors.Wrap(err, "failed to delete unbonding")
+		}
+		err = balances.AddStakeToAddress(unbonding.Address, currency.NewCoinFromAmount(unbonding.Amount))
+		if err != nil {
+			return errors.Wrap(err, "failed to release unbonding")
+		}
+	}
+	return nil
+}
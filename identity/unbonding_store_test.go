@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	db "github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/storage"
+)
+
+func TestUnbondingStore_Release(t *testing.T) {
+	db := db.NewDB("test", db.MemDBBackend, "")
+	cs := storage.NewState(storage.NewChainState("unbonding", db))
+	us := NewUnbondingStore("ub", cs)
+	balances := balance.NewStore("b", cs)
+	vt := balance.Currency{Name: "VT", Chain: chain.ONELEDGER}
+
+	validator := testAddress(1)
+	assert.NoError(t, us.Add(Unbonding{Address: testAddress(2), Validator: validator, Amount: *balance.NewAmount(10), MatureHeight: 5}))
+	assert.NoError(t, us.Add(Unbonding{Address: testAddress(2), Validator: validator, Amount: *balance.NewAmount(15), MatureHeight: 5}))
+	assert.NoError(t, us.Add(Unbonding{Address: testAddress(3), Validator: validator, Amount: *balance.NewAmount(20), MatureHeight: 12}))
+	cs.Commit()
+
+	matured := 0
+	us.IterateMatured(5, func(unbonding *Unbonding) bool {
+		assert.EqualValues(t, 25, unbonding.Amount.BigInt().Int64())
+		matured++
+		return false
+	})
+	assert.Equal(t, 1, matured)
+
+	assert.NoError(t, us.Release(4, balances, vt))
+	cs.Commit()
+	coin, err := balances.GetBalanceForCurr(testAddress(2), &vt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, coin.Amount.BigInt().Int64())
+
+	assert.NoError(t, us.Release(10, balances, vt))
+	cs.Commit()
+	coin, err = balances.GetBalanceForCurr(testAddress(2), &vt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 25, coin.Amount.BigInt().Int64())
+
+	left := 0
+	us.Iterate(func(unbonding *Unbonding) bool {
+		assert.EqualValues(t, 12, unbonding.MatureHeight)
+		left++
+		return false
+	})
+	assert.Equal(t, 1, left)
+}
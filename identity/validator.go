@@ -1,6 +1,8 @@
 package identity
 
 import (
+	"math/big"
+
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil"
 
@@ -18,6 +20,17 @@ type Validator struct {
 	Power        int64          `json:"power"`
 	Name         string         `json:"name"`
 	Staking      balance.Amount `json:"staking,string"`
+	Delegation   balance.Amount `json:"delegation,string"`
+	Jailed       bool           `json:"jailed"`
+	JailedUntil  int64          `json:"jailedUntil"`
+	// signing info for the current downtime window
+	WindowStart  int64 `json:"windowStart"`
+	MissedBlocks int64 `json:"missedBlocks"`
+}
+
+// TotalStake returns the validator's own stake plus the stake delegated to it
+func (v *Validator) TotalStake() balance.Amount {
+	return *balance.NewAmountFromBigInt(big.NewInt(0).Add(v.Staking.BigInt(), v.Delegation.BigInt()))
 }
 
 func (v *Validator) Bytes() []byte {
@@ -68,14 +81,23 @@ type Unstake struct {
 }
 
 type ValidatorContext struct {
-	Balances *balance.Store
-	FeePool  *fees.Store
+	Balances    *balance.Store
+	FeePool     *fees.Store
+	Delegations *DelegationStore
+	Currencies  *balance.CurrencySet
+	Supplies    *balance.SupplyStore
+	Unbondings  *UnbondingStore
 	// TODO: add necessary config
 }
 
-func NewValidatorContext(balances *balance.Store, feePool *fees.Store) *ValidatorContext {
+func NewValidatorContext(balances *balance.Store, feePool *fees.Store, delegations *DelegationStore,
+	currencies *balance.CurrencySet, supplies *balance.SupplyStore, unbondings *UnbondingStore) *ValidatorContext {
 	return &ValidatorContext{
-		Balances: balances,
-		FeePool:  feePool,
+		Balances:    balances,
+		FeePool:     feePool,
+		Delegations: delegations,
+		Currencies:  currencies,
+		Supplies:    supplies,
+		Unbondings:  unbondings,
 	}
 }
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/fees"
@@ -17,6 +19,9 @@ import (
 	"github.com/Oneledger/protocol/utils"
 )
 
+// maxValidatorPower is the total voting power tendermint accepts, it bounds the power of a validator and of the set
+const maxValidatorPower = tmtypes.MaxTotalVotingPower
+
 type ValidatorStore struct {
 	prefix      []byte
 	store       *storage.State
@@ -25,6 +30,7 @@ type ValidatorStore struct {
 	byzantine   []Validator
 	totalPower  int64
 	isValidator bool
+	options     *Options
 }
 
 func NewValidatorStore(prefix string, state *storage.State) *ValidatorStore {
@@ -36,6 +42,7 @@ func NewValidatorStore(prefix string, state *storage.State) *ValidatorStore {
 		queue:      ValidatorQueue{PriorityQueue: make(utils.PriorityQueue, 0, 100)},
 		byzantine:  make([]Validator, 0),
 		totalPower: 0,
+		options:    &Options{},
 	}
 }
 
@@ -44,6 +51,14 @@ func (vs *ValidatorStore) WithState(state *storage.State) *ValidatorStore {
 	return vs
 }
 
+func (vs *ValidatorStore) SetOptions(opt *Options) {
+	vs.options = opt
+}
+
+func (vs *ValidatorStore) GetOptions() *Options {
+	return vs.options
+}
+
 func (vs *ValidatorStore) Get(addr keys.Address) (*Validator, error) {
 	key := append(vs.prefix, addr...)
 	value, _ := vs.store.Get(key)
@@ -122,29 +137,12 @@ func (vs *ValidatorStore) Init(req types.RequestInitChain, currencies *balance.C
 // setup the validators according to begin block
 func (vs *ValidatorStore) Setup(req types.RequestBeginBlock, nodeValidatorAddress keys.Address) error {
 	vs.proposer = req.Header.GetProposerAddress()
-	var def error
-	// update the byzantine node that need to be slashed
-	// this should happened before initialize the queue.
-	vs.byzantine = make([]Validator, 0)
-	vs.byzantine = makingslash(vs, req.ByzantineValidators)
-	for _, remove := range vs.byzantine {
-		key := append(vs.prefix, remove.Address.Bytes()...)
-		err := vs.store.Set(key, remove.Bytes())
-		if err != nil {
-			logger.Error("failed to set byzantine validator power")
-			// TODO: add fatal status for here after we decide what to do with byzantine validator
-			def = err
-		}
-	}
-
 	// initialize the queue for validators
 	vs.queue.PriorityQueue = make(utils.PriorityQueue, 0, 100)
 	i := 0
-	vs.totalPower = 0
 	vs.Iterate(func(addr keys.Address, validator *Validator) bool {
 		queued := utils.NewQueued(addr, validator.Power, i)
 		vs.queue.append(queued)
-		vs.totalPower += validator.Power
 		if bytes.Equal(addr, nodeValidatorAddress) {
 			vs.isValidator = true
 		}
@@ -153,10 +151,32 @@ func (vs *ValidatorStore) Setup(req types.RequestBeginBlock, nodeValidatorAddres
 	})
 	vs.queue.Init()
 
-	return def
+	return nil
+}
+
+// IterateFrom goes through the validators in address order from the start address on, or from the first validator
+// if the address is empty
+func (vs *ValidatorStore) IterateFrom(start keys.Address, ascending bool, fn func(addr keys.Address, validator *Validator) bool) (stopped bool) {
+	from, to := storage.PageRange(vs.prefix, start, ascending)
+	return vs.store.IterateRange(
+		from,
+		to,
+		ascending,
+		func(key, value []byte) bool {
+			if !bytes.HasPrefix(key, vs.prefix) {
+				return false
+			}
+			validator, err := (&Validator{}).FromBytes(value)
+			if err != nil {
+				logger.Error("failed to deserialize validator")
+				return false
+			}
+			addr := key[len(vs.prefix):]
+			return fn(addr, validator)
+		},
+	)
 }
 
-// get validators set
 func (vs *ValidatorStore) GetValidatorSet() ([]Validator, error) {
 
 	validatorSet := make([]Validator, 0)
@@ -203,7 +223,7 @@ func (vs *ValidatorStore) HandleStake(apply Stake) error {
 			StakeAddress: apply.StakeAddress,
 			PubKey:       apply.Pubkey,
 			ECDSAPubKey:  apply.ECDSAPubKey,
-			Power:        calculatePower(apply.Amount),
+			Power:        vs.calculatePower(apply.Amount),
 			Name:         apply.Name,
 			Staking:      apply.Amount,
 		}
@@ -218,8 +238,7 @@ func (vs *ValidatorStore) HandleStake(apply Stake) error {
 		amt := big.NewInt(0).Add(validator.Staking.BigInt(), apply.Amount.BigInt())
 
 		validator.Staking = *balance.NewAmountFromBigInt(amt)
-		validator.Power = calculatePower(validator.Staking)
-		validator = validator
+		validator.Power = vs.votingPower(validator)
 	}
 	value := (validator).Bytes()
 	vkey := append(vs.prefix, validator.Address.Bytes()...)
@@ -231,29 +250,119 @@ func (vs *ValidatorStore) HandleStake(apply Stake) error {
 	return nil
 }
 
-func calculatePower(stake balance.Amount) int64 {
-	// TODO: change to correct power function @TODO99
-	return stake.BigInt().Int64()
+// calculatePower converts a stake to voting power, one power for every power reduction unit staked. The result
+// saturates at maxValidatorPower instead of overflowing int64.
+func (vs *ValidatorStore) calculatePower(stake balance.Amount) int64 {
+	power := big.NewInt(0).Set(stake.BigInt())
+	if reduction := vs.options.PowerReduction; reduction != nil && reduction.BigInt().Sign() > 0 {
+		power.Quo(power, reduction.BigInt())
+	}
+	if power.Sign() <= 0 {
+		return 0
+	}
+	if power.Cmp(big.NewInt(maxValidatorPower)) > 0 {
+		return maxValidatorPower
+	}
+	return power.Int64()
+}
+
+// votingPower of a validator, jailed validators have no power until they unjail
+func (vs *ValidatorStore) votingPower(validator *Validator) int64 {
+	if validator.Jailed {
+		return 0
+	}
+	return vs.calculatePower(validator.TotalStake())
 }
 
-// TODO: implement the proper slashing
-func makingslash(vs *ValidatorStore, evidences []types.Evidence) []Validator {
-	remove := make([]Validator, 0)
-	for _, evidence := range evidences {
-		if vs.store.Exists(evidence.Validator.Address) {
-			value, _ := vs.store.Get(evidence.Validator.GetAddress())
-			if value == nil {
-				logger.Error("failed to get validator from store", evidence.Validator.Address)
-			}
-			validator, err := (&Validator{}).FromBytes(value)
-			if err != nil {
-				logger.Error("error deserialize validator")
-			}
-			validator.Power = 0
-			remove = append(remove, *validator)
+// consensusPowers returns the powers the validators get in consensus. They are scaled down together when their sum
+// is over the total voting power tendermint accepts, then capped so no validator has more than the max power share
+// of the capped total.
+func (vs *ValidatorStore) consensusPowers(powers []int64) []int64 {
+	total := big.NewInt(0)
+	for _, power := range powers {
+		if power > 0 {
+			total.Add(total, big.NewInt(power))
+		}
+	}
+
+	result := make([]int64, len(powers))
+	limit := big.NewInt(maxValidatorPower)
+	for i, power := range powers {
+		if power <= 0 {
+			continue
+		}
+		if total.Cmp(limit) > 0 {
+			scaled := big.NewInt(0).Mul(big.NewInt(power), limit)
+			power = scaled.Quo(scaled, total).Int64()
+		}
+		result[i] = power
+	}
+
+	percent := vs.options.MaxPowerPercent
+	if percent <= 0 || percent >= 100 {
+		return result
+	}
+	powerCap := capPower(result, percent)
+	for i, power := range result {
+		if power > powerCap {
+			result[i] = powerCap
+		}
+	}
+	return result
+}
+
+// capPower finds the highest power the largest validators can be capped at so each share of the capped total stays
+// within the percent. With the k largest powers capped the cap is percent * rest / (100 - percent * k), rest being
+// the sum of the other powers. When there are too few validators for any cap to work they all get the smallest power.
+func capPower(powers []int64, percent int64) int64 {
+	sorted := make([]int64, 0, len(powers))
+	rest := big.NewInt(0)
+	for _, power := range powers {
+		if power > 0 {
+			sorted = append(sorted, power)
+			rest.Add(rest, big.NewInt(power))
+		}
+	}
+	if len(sorted) == 0 {
+		return 0
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	for k := range sorted {
+		denominator := 100 - percent*int64(k)
+		if denominator <= 0 {
+			break
+		}
+		powerCap := big.NewInt(0).Mul(rest, big.NewInt(percent))
+		powerCap.Quo(powerCap, big.NewInt(denominator))
+		if powerCap.Cmp(big.NewInt(sorted[k])) >= 0 {
+			return powerCap.Int64()
+		}
+		rest.Sub(rest, big.NewInt(sorted[k]))
+	}
+	return sorted[len(sorted)-1]
+}
+
+// UpdatePowers recalculates the power of every validator, after the options used to calculate it changed
+func (vs *ValidatorStore) UpdatePowers() error {
+	validators := make([]*Validator, 0)
+	vs.Iterate(func(addr keys.Address, validator *Validator) bool {
+		validators = append(validators, validator)
+		return false
+	})
+
+	for _, validator := range validators {
+		power := vs.votingPower(validator)
+		if power == validator.Power {
+			continue
+		}
+		validator.Power = power
+		err := vs.set(*validator)
+		if err != nil {
+			return errors.Wrap(err, "failed to update validator power")
 		}
 	}
-	return remove
+	return nil
 }
 
 func (vs *ValidatorStore) HandleUnstake(unstake Unstake) error {
@@ -267,7 +376,7 @@ func (vs *ValidatorStore) HandleUnstake(unstake Unstake) error {
 	amt := big.NewInt(0).Sub(validator.Staking.BigInt(), unstake.Amount.BigInt())
 
 	validator.Staking = *balance.NewAmountFromBigInt(amt)
-	validator.Power = calculatePower(validator.Staking)
+	validator.Power = vs.votingPower(validator)
 	err = vs.set(*validator)
 	if err != nil {
 		return errors.Wrap(err, "failed to set validator for unstake")
@@ -276,6 +385,52 @@ func (vs *ValidatorStore) HandleUnstake(unstake Unstake) error {
 	return nil
 }
 
+// handle delegate action, the delegated stake adds to the power of the validator
+func (vs *ValidatorStore) HandleDelegation(validatorAddress keys.Address, amount balance.Amount) error {
+	validator, err := vs.Get(validatorAddress)
+	if err != nil {
+		return errors.Wrap(err, "error deserialize validator")
+	}
+
+	amt := big.NewInt(0).Add(validator.Delegation.BigInt(), amount.BigInt())
+
+	validator.Delegation = *balance.NewAmountFromBigInt(amt)
+	validator.Power = vs.votingPower(validator)
+	err = vs.set(*validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to set validator for delegation")
+	}
+
+	return nil
+}
+
+// handle undelegate action
+func (vs *ValidatorStore) HandleUndelegation(validatorAddress keys.Address, amount balance.Amount) error {
+	// a purged validator is gone from the set, its delegations are only left in the delegation store
+	if !vs.Exists(validatorAddress) {
+		return nil
+	}
+
+	validator, err := vs.Get(validatorAddress)
+	if err != nil {
+		return errors.Wrap(err, "error deserialize validator")
+	}
+
+	amt := big.NewInt(0).Sub(validator.Delegation.BigInt(), amount.BigInt())
+	if amt.Sign() < 0 {
+		return errors.New("undelegate more than delegated to validator")
+	}
+
+	validator.Delegation = *balance.NewAmountFromBigInt(amt)
+	validator.Power = vs.votingPower(validator)
+	err = vs.set(*validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to set validator for undelegation")
+	}
+
+	return nil
+}
+
 func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.RequestEndBlock) []types.ValidatorUpdate {
 
 	validatorUpdates := make([]types.ValidatorUpdate, 0)
@@ -287,8 +442,8 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 		distribute = true
 	}
 	if req.Height > 1 || (len(vs.byzantine) > 0) {
-		cnt := 0
-		for vs.queue.Len() > 0 && cnt < 64 {
+		validators := make([]*Validator, 0)
+		for vs.queue.Len() > 0 && len(validators) < 64 {
 			queued := vs.queue.Pop()
 			addr := queued.Value()
 
@@ -297,8 +452,13 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 				logger.Error(err, "error deserialize validator")
 				continue
 			}
-			// purge validator who's power is 0
-			if validator.Power <= 0 {
+			jailedNow := vs.isJailedInBlock(validator.Address)
+			// jailed validators were already removed from the consensus set when they got jailed
+			if validator.Jailed && !jailedNow {
+				continue
+			}
+			// purge validator who's power is 0, jailed validators are kept so they can unjail
+			if validator.Power <= 0 && !validator.Jailed {
 				vKey := append(vs.prefix, validator.Address.Bytes()...)
 				//TODO: validator delete will not properly delete the item because of state implementation
 				ok, err := vs.store.Delete(vKey)
@@ -306,23 +466,39 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 					logger.Error(err.Error())
 				}
 			}
+			validators = append(validators, validator)
+		}
+
+		// the powers of the whole update are bounded together, tendermint rejects an update over its total power
+		powers := make([]int64, len(validators))
+		for i, validator := range validators {
+			powers[i] = validator.Power
+		}
+		powers = vs.consensusPowers(powers)
+		vs.totalPower = 0
+		for i, validator := range validators {
+			if !validator.Jailed {
+				vs.totalPower += powers[i]
+			}
+		}
+
+		for i, validator := range validators {
 			validatorUpdates = append(validatorUpdates, types.ValidatorUpdate{
 				PubKey: validator.PubKey.GetABCIPubKey(),
-				Power:  validator.Power,
+				Power:  powers[i],
 			})
 			//distribute the fee for validators
-			if distribute {
-				feeShare := total.MultiplyInt64(queued.Priority()).DivideInt64(vs.totalPower)
+			if distribute && !validator.Jailed && vs.totalPower > 0 {
+				feeShare := total.MultiplyInt64(powers[i]).DivideInt64(vs.totalPower)
 				err = ctx.FeePool.MinusFromPool(feeShare)
 				if err != nil {
 					logger.Fatal("failed to minus from fee pool")
 				}
-				err = ctx.FeePool.AddToAddress(validator.StakeAddress, feeShare)
+				err = vs.distributeFee(ctx, validator, feeShare)
 				if err != nil {
 					logger.Fatal("failed to distribute fee")
 				}
 			}
-			cnt++
 		}
 	}
 
@@ -330,6 +506,44 @@ func (vs *ValidatorStore) GetEndBlockUpdate(ctx *ValidatorContext, req types.Req
 	return validatorUpdates
 }
 
+// distributeFee splits the fee share of a validator with its delegators, in proportion to the stake each of them
+// put in. The validator keeps the commission on the delegators' part and whatever is left from rounding.
+func (vs *ValidatorStore) distributeFee(ctx *ValidatorContext, validator *Validator, feeShare balance.Coin) error {
+	delegated := validator.Delegation.BigInt()
+	totalStake := validator.TotalStake()
+	if ctx.Delegations == nil || delegated.Sign() <= 0 || totalStake.BigInt().Sign() <= 0 {
+		return ctx.FeePool.AddToAddress(validator.StakeAddress, feeShare)
+	}
+
+	// the part of the fee earned by delegated stake, less the validator commission
+	delegatorsShare := big.NewInt(0).Mul(feeShare.Amount.BigInt(), delegated)
+	delegatorsShare.Quo(delegatorsShare, totalStake.BigInt())
+	commission := big.NewInt(0).Mul(delegatorsShare, big.NewInt(vs.options.CommissionRate))
+	commission.Quo(commission, big.NewInt(100))
+	delegatorsShare.Sub(delegatorsShare, commission)
+
+	remaining := big.NewInt(0).Set(feeShare.Amount.BigInt())
+	var err error
+	ctx.Delegations.IterateValidator(validator.Address, func(delegation *Delegation) bool {
+		share := big.NewInt(0).Mul(delegatorsShare, delegation.Amount.BigInt())
+		share.Quo(share, delegated)
+		if share.Sign() <= 0 {
+			return false
+		}
+		err = ctx.FeePool.AddToAddress(delegation.Delegator, feeShare.Currency.NewCoinFromAmount(*balance.NewAmountFromBigInt(share)))
+		if err != nil {
+			return true
+		}
+		remaining.Sub(remaining, share)
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctx.FeePool.AddToAddress(validator.StakeAddress, feeShare.Currency.NewCoinFromAmount(*balance.NewAmountFromBigInt(remaining)))
+}
+
 func (vs *ValidatorStore) GetBitcoinKeys(net *chaincfg.Params) (list []*btcutil.AddressPubKey, err error) {
 
 	list = make([]*btcutil.AddressPubKey, 0)
@@ -195,6 +195,30 @@ func TestValidatorStore_GetValidatorSet(t *testing.T) {
 	assert.Empty(t, validatorSet)
 }
 
+func TestValidatorStore_IterateFrom(t *testing.T) {
+	vs := setup()
+	for _, b := range []byte{1, 2, 3} {
+		stake := prepareStake("")
+		stake.ValidatorAddress = testAddress(b)
+		stake.Amount = *balance.NewAmount(100)
+		assert.NoError(t, vs.HandleStake(stake))
+	}
+	vs.store.Commit()
+
+	list := func(start keys.Address, ascending bool) []keys.Address {
+		addrs := make([]keys.Address, 0)
+		vs.IterateFrom(start, ascending, func(addr keys.Address, validator *Validator) bool {
+			addrs = append(addrs, addr)
+			return false
+		})
+		return addrs
+	}
+	assert.Equal(t, []keys.Address{testAddress(1), testAddress(2), testAddress(3)}, list(nil, true))
+	assert.Equal(t, []keys.Address{testAddress(2), testAddress(3)}, list(testAddress(2), true))
+	assert.Equal(t, []keys.Address{testAddress(2), testAddress(1)}, list(testAddress(2), false))
+	assert.Equal(t, []keys.Address{testAddress(3), testAddress(2), testAddress(1)}, list(nil, false))
+}
+
 func setupForHandleStake() Stake {
 	address := "f529ec288fbd333895cfa1aca272950064f1dbc1"
 	return prepareStake(address)
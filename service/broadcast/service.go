@@ -2,11 +2,15 @@ package broadcast
 
 import (
 	"errors"
+	"fmt"
+
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/rpc"
@@ -20,12 +24,15 @@ type Service struct {
 	trackers   *bitcoin.TrackerStore
 	feePool    *fees.Store
 	domains    *ons.DomainStore
+	multiSig   *multisig.Store
+	nonces     *nonce.Store
 	ext        client.ExtServiceContext
 }
 
 func NewService(ctx client.ExtServiceContext, router action.Router, currencies *balance.CurrencySet,
 	feePool *fees.Store, domains *ons.DomainStore,
-	logger *log.Logger, trackers *bitcoin.TrackerStore,
+	logger *log.Logger, trackers *bitcoin.TrackerStore, multiSig *multisig.Store,
+	nonces *nonce.Store,
 ) *Service {
 	return &Service{
 		ext:        ctx,
@@ -34,6 +41,8 @@ func NewService(ctx client.ExtServiceContext, router action.Router, currencies *
 		trackers:   trackers,
 		feePool:    feePool,
 		domains:    domains,
+		multiSig:   multiSig,
+		nonces:     nonces,
 		logger:     logger,
 	}
 }
@@ -52,6 +61,10 @@ func (svc *Service) validateAndSignTx(req client.BroadcastRequest) ([]byte, erro
 	}
 
 	sigs := []action.Signature{{Signer: req.PublicKey, Signed: req.Signature}}
+	if len(req.Signatures) > 0 {
+		// signatures of a multisig account's members
+		sigs = req.Signatures
+	}
 	signedTx := action.SignedTx{
 		RawTx:      tx,
 		Signatures: sigs,
@@ -59,8 +72,8 @@ func (svc *Service) validateAndSignTx(req client.BroadcastRequest) ([]byte, erro
 
 	handler := svc.router.Handler(tx.Type)
 	ctx := action.NewContext(svc.router, nil, nil, nil, nil, svc.currencies,
-		svc.feePool, nil, nil, svc.domains, svc.trackers, nil, nil, nil,
-		svc.logger)
+		svc.feePool, nil, nil, svc.domains, svc.trackers, nil, nil, nil, nil, nil, nil,
+		svc.multiSig, nil, nil, nil, svc.logger)
 
 	_, err = handler.Validate(ctx, signedTx)
 	if err != nil {
@@ -68,6 +81,22 @@ func (svc *Service) validateAndSignTx(req client.BroadcastRequest) ([]byte, erro
 		return nil, err
 	}
 
+	// a nonce already used in the committed state can never pass, txs waiting in the mempool are checked by the node
+	h, err := req.PublicKey.GetHandler()
+	if len(req.Signatures) > 0 {
+		h, err = req.Signatures[0].Signer.GetHandler()
+	}
+	if err != nil {
+		return nil, rpc.InvalidRequestError(action.ErrInvalidPubkey.Error())
+	}
+	next, err := svc.nonces.Get(h.Address())
+	if err != nil {
+		return nil, rpc.InternalError(err.Error())
+	}
+	if tx.Nonce < next {
+		return nil, rpc.InvalidRequestError(fmt.Sprintf("%s: nonce %d already used, next: %d", action.ErrInvalidNonce.Msg, tx.Nonce, next))
+	}
+
 	return signedTx.SignedBytes(), nil
 }
 
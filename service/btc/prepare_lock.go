@@ -183,13 +183,20 @@ func (s *Service) AddUserSignatureAndProcessLock(args client.BTCLockRequest, rep
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &lock)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.BTC_LOCK,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.BTC_LOCK,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -66,13 +66,20 @@ func (s *Service) PrepareRedeem(args client.BTCRedeemRequest, reply *client.BTCR
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &redeem)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.BTC_REDEEM,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.BTC_REDEEM,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -9,6 +9,8 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 )
@@ -26,6 +28,8 @@ type Service struct {
 
 	validators   *identity.ValidatorStore
 	trackerStore *bitcoin.TrackerStore
+	nonces       *nonce.Store
+	multiSig     *multisig.Store
 }
 
 func NewService(
@@ -35,6 +39,8 @@ func NewService(
 	nodeCtx node.Context,
 	validators *identity.ValidatorStore,
 	trackerStore *bitcoin.TrackerStore,
+	nonces *nonce.Store,
+	multiSig *multisig.Store,
 	logger *log.Logger,
 ) *Service {
 
@@ -44,6 +50,8 @@ func NewService(
 		accounts:     accounts,
 		validators:   validators,
 		trackerStore: trackerStore,
+		nonces:       nonces,
+		multiSig:     multiSig,
 		logger:       logger,
 	}
 }
@@ -21,13 +21,20 @@ func (svc *Service) PrepareOLTERC20Lock(req *OLTERC20LockRequest, out *OLTReply)
 		return codes.ErrPreparingErc20OLTLock
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &erc20lock)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{req.Fee, req.Gas}
 	tx := &action.RawTx{
-		Type: action.ERC20_LOCK,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.ERC20_LOCK,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 	packets, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
 	if err != nil {
@@ -23,13 +23,20 @@ func (svc *Service) CreateRawExtERC20Redeem(req RedeemRequest, out *OLTReply) er
 		return codes.ErrUnmarshaling
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &redeemERC20)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{Price: req.Fee, Gas: req.Gas}
 	tx := &action.RawTx{
-		Type: action.ERC20_REDEEM,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.ERC20_REDEEM,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
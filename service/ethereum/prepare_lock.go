@@ -19,7 +19,12 @@ import (
 
 func (svc *Service) CreateRawExtLock(req OLTLockRequest, out *OLTReply) error {
 
-	packets, err := createRawLock(req.Address, req.RawTx, req.Fee, req.Gas)
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &eth.Lock{Locker: req.Address})
+	if err != nil {
+		return err
+	}
+
+	packets, err := createRawLock(req.Address, req.RawTx, req.Fee, req.Gas, nonce, nonceAccount)
 	if err != nil {
 		svc.logger.Error(err, codes.ErrPreparingOLTLock.ErrorMsg())
 		return codes.ErrPreparingOLTLock
@@ -34,7 +39,7 @@ func (svc *Service) CreateRawExtLock(req OLTLockRequest, out *OLTReply) error {
 // Helper Function to create Lock ,and send back unsigned OLT transaction
 // Data Field is Lock struct (Tx.data.ETHTxn)
 
-func createRawLock(locker action.Address, rawTx []byte, userfee action.Amount, gas int64) ([]byte, error) {
+func createRawLock(locker action.Address, rawTx []byte, userfee action.Amount, gas int64, nonce uint64, nonceAccount action.Address) ([]byte, error) {
 	// First accept the rawTx
 	//tracker := tracker.NewTracker(common.BytesToHash(rawTx))
 	lock := eth.Lock{
@@ -50,10 +55,12 @@ func createRawLock(locker action.Address, rawTx []byte, userfee action.Amount, g
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{userfee, gas}
 	tx := &action.RawTx{
-		Type: action.ETH_LOCK,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.ETH_LOCK,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -23,13 +23,20 @@ func (svc *Service) CreateRawExtRedeem(req RedeemRequest, out *OLTReply) error {
 		return codes.ErrUnmarshaling
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &redeem)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{Price: req.Fee, Gas: req.Gas}
 	tx := &action.RawTx{
-		Type: action.ETH_REDEEM,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.ETH_REDEEM,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
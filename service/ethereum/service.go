@@ -12,6 +12,8 @@ import (
 	"github.com/Oneledger/protocol/data/accounts"
 	ethTracker "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 )
@@ -28,6 +30,8 @@ type Service struct {
 	nodeContext node.Context
 	validators  *identity.ValidatorStore
 	trackers    *ethTracker.TrackerStore
+	nonces      *nonce.Store
+	multiSig    *multisig.Store
 }
 
 // Returns a new Service, should be passed as an RPC handler
@@ -39,7 +43,8 @@ func NewService(
 	nodeCtx node.Context,
 	validators *identity.ValidatorStore,
 	trackerStore *ethTracker.TrackerStore,
-
+	nonces *nonce.Store,
+	multiSig *multisig.Store,
 	logger *log.Logger,
 ) *Service {
 	return &Service{
@@ -50,6 +55,8 @@ func NewService(
 		accounts:    accounts,
 		validators:  validators,
 		trackers:    trackerStore,
+		nonces:      nonces,
+		multiSig:    multiSig,
 		logger:      logger,
 	}
 }
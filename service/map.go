@@ -12,7 +12,11 @@ import (
 	"github.com/Oneledger/protocol/data/bitcoin"
 	ethTracker "github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/txindex"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/service/broadcast"
@@ -35,6 +39,12 @@ type Context struct {
 	WitnessSet   *identity.WitnessStore
 	Trackers     *bitcoin.TrackerStore
 	EthTrackers  *ethTracker.TrackerStore
+	MultiSig     *multisig.Store
+	Nonces       *nonce.Store
+	Unbondings   *identity.UnbondingStore
+	ProposalFund *governance.ProposalFundStore
+	Supplies     *balance.SupplyStore
+	TxIndex      *txindex.Store
 	// configurations
 	Cfg        config.Server
 	Currencies *balance.CurrencySet
@@ -54,13 +64,13 @@ type Map map[string]interface{}
 func NewMap(ctx *Context) (Map, error) {
 
 	defaultMap := Map{
-		broadcast.Name(): broadcast.NewService(ctx.Services, ctx.Router, ctx.Currencies, ctx.FeePool, ctx.Domains, ctx.Logger, ctx.Trackers),
+		broadcast.Name(): broadcast.NewService(ctx.Services, ctx.Router, ctx.Currencies, ctx.FeePool, ctx.Domains, ctx.Logger, ctx.Trackers, ctx.MultiSig, ctx.Nonces),
 		nodesvc.Name():   nodesvc.NewService(ctx.NodeContext, &ctx.Cfg, ctx.Logger),
 		owner.Name():     owner.NewService(ctx.Accounts, ctx.Logger),
-		query.Name():     query.NewService(ctx.Services, ctx.Balances, ctx.Currencies, ctx.ValidatorSet, ctx.WitnessSet, ctx.Domains, ctx.FeePool, ctx.Logger, ctx.TxTypes),
-		tx.Name():        tx.NewService(ctx.Balances, ctx.Router, ctx.Accounts, ctx.FeePool.GetOpt(), ctx.NodeContext, ctx.Logger),
-		btc.Name():       btc.NewService(ctx.Balances, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.Trackers, ctx.Logger),
-		ethereum.Name():  ethereum.NewService(ctx.Cfg.EthChainDriver, ctx.Router, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.EthTrackers, ctx.Logger),
+		query.Name():     query.NewService(ctx.Services, ctx.Balances, ctx.Currencies, ctx.ValidatorSet, ctx.WitnessSet, ctx.Domains, ctx.FeePool, ctx.Nonces, ctx.Unbondings, ctx.ProposalFund, ctx.Supplies, ctx.Trackers, ctx.EthTrackers, ctx.TxIndex, ctx.Logger, ctx.TxTypes),
+		tx.Name():        tx.NewService(ctx.Balances, ctx.Router, ctx.Accounts, ctx.FeePool.GetOpt(), ctx.NodeContext, ctx.Nonces, ctx.MultiSig, ctx.Logger),
+		btc.Name():       btc.NewService(ctx.Balances, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.Trackers, ctx.Nonces, ctx.MultiSig, ctx.Logger),
+		ethereum.Name():  ethereum.NewService(ctx.Cfg.EthChainDriver, ctx.Router, ctx.Accounts, ctx.NodeContext, ctx.ValidatorSet, ctx.EthTrackers, ctx.Nonces, ctx.MultiSig, ctx.Logger),
 	}
 
 	serviceMap := Map{}
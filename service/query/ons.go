@@ -7,6 +7,11 @@ import (
 )
 
 func (sv *Service) ONS_GetDomainByName(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
 	domains := sv.ons
 	if len(req.Name) <= 0 {
 		return codes.ErrBadName
@@ -29,135 +34,287 @@ func (sv *Service) ONS_GetDomainByName(req client.ONSGetDomainsRequest, reply *c
 	return nil
 }
 
-func (sv *Service) ONS_GetDomainByOwner(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := sv.ons
-	if req.Owner == nil {
-		return codes.ErrBadOwner
+// ONS_ResolveRecord returns a resolver record of an active domain, like its address on another chain
+func (sv *Service) ONS_ResolveRecord(req client.ONSResolveRecordRequest, reply *client.ONSResolveRecordReply) error {
+	if len(req.Name) <= 0 {
+		return codes.ErrBadName
 	}
-	ds := make([]ons.Domain, 0)
 
-	domains.Iterate(func(name ons.Name, domain *ons.Domain) bool {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
 
-		if domain.Owner.Equal(req.Owner) {
-			if req.OnSale && !domain.OnSaleFlag {
-				return false
-			}
-			ds = append(ds, *domain)
-		}
-		return false
-	})
+	d, err := sv.ons.Get(ons.Name(req.Name))
+	if err != nil || !d.IsActive(sv.ons.State.Version()) {
+		return codes.ErrDomainNotFound
+	}
 
-	*reply = client.ONSGetDomainsReply{
-		Domains: ds,
-		Height:  sv.ons.State.Version(),
+	record, ok := d.GetRecord(ons.Record{Type: req.Type, Chain: req.Chain, Key: req.Key})
+	if !ok {
+		return codes.ErrRecordNotFound
 	}
 
+	*reply = client.ONSResolveRecordReply{
+		Record: record,
+		Height: sv.ons.State.Version(),
+	}
 	return nil
 }
 
-func (sv *Service) ONS_GetParentDomainByOwner(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := sv.ons
-	if req.Owner == nil {
-		return codes.ErrBadOwner
+// ONS_ReverseLookup returns the primary name of an address, if the domain still resolves to the address
+func (sv *Service) ONS_ReverseLookup(req client.ONSReverseLookupRequest, reply *client.ONSReverseLookupReply) error {
+	if err := req.Address.Err(); err != nil {
+		return codes.ErrBadAddress
 	}
-	ds := make([]ons.Domain, 0)
-
-	domains.Iterate(func(name ons.Name, domain *ons.Domain) bool {
 
-		if domain.Owner.Equal(req.Owner) {
-			if req.OnSale && !domain.OnSaleFlag {
-				return false
-			}
-			if domain.Name.IsSub() {
-				return false
-			}
-			ds = append(ds, *domain)
-		}
-		return false
-	})
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
 
-	*reply = client.ONSGetDomainsReply{
-		Domains: ds,
-		Height:  sv.ons.State.Version(),
+	height := sv.ons.State.Version()
+	name, err := sv.ons.ReverseLookup(req.Address, height)
+	if err != nil {
+		return codes.ErrDomainNotFound
 	}
 
+	*reply = client.ONSReverseLookupReply{
+		Name:   name.String(),
+		Height: height,
+	}
 	return nil
 }
 
-func (sv *Service) ONS_GetSubDomainByName(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := sv.ons
+// ONS_GetAuction returns the auction of a top level name, sealed bids show only their deposit until revealed
+func (sv *Service) ONS_GetAuction(req client.ONSGetAuctionRequest, reply *client.ONSGetAuctionReply) error {
 	if len(req.Name) <= 0 {
 		return codes.ErrBadName
 	}
 
-	_, err := domains.Get(ons.Name(req.Name))
+	sv, err := sv.atHeight(req.Height)
 	if err != nil {
-		return codes.ErrDomainNotFound
+		return err
 	}
 
-	reqName := ons.GetNameFromString(req.Name)
+	auction, err := sv.ons.GetAuction(ons.Name(req.Name))
+	if err != nil {
+		return codes.ErrAuctionNotFound
+	}
 
-	ds := make([]ons.Domain, 0)
+	*reply = client.ONSGetAuctionReply{
+		Auction: *auction,
+		Height:  sv.ons.State.Version(),
+	}
+	return nil
+}
 
-	domains.Iterate(func(name ons.Name, domain *ons.Domain) bool {
+// ONS_GetOffersByDomain returns a page of the open offers made on a domain, ordered by bidder
+func (sv *Service) ONS_GetOffersByDomain(req client.ONSGetOffersRequest, reply *client.ONSGetOffersReply) error {
+	if len(req.Name) <= 0 {
+		return codes.ErrBadName
+	}
 
-		if !domain.Name.IsSub() {
-			return false
-		}
-		if !domain.Name.EqualTo(reqName) && domain.Name.IsSubTo(reqName) {
-			ds = append(ds, *domain)
-		}
+	pg, err := newPage(req.PageRequest)
+	if err != nil {
+		return err
+	}
+	start, err := startAddress(req.PageRequest)
+	if err != nil {
+		return err
+	}
 
+	sv, err = sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	offers := make([]ons.Offer, 0)
+	sv.ons.IterateOffersFrom(ons.Name(req.Name), start, !req.Reverse, func(offer *ons.Offer) bool {
+		if !pg.add(offer.Bidder.String()) {
+			return true
+		}
+		offers = append(offers, *offer)
 		return false
 	})
 
-	*reply = client.ONSGetDomainsReply{
-		Domains: ds,
-		Height:  sv.ons.State.Version(),
+	*reply = client.ONSGetOffersReply{
+		Offers:    offers,
+		PageReply: pg.reply(),
+		Height:    sv.ons.State.Version(),
 	}
-
 	return nil
 }
 
+// ONS_GetOffersByBidder returns a page of the open offers made by an address, ordered by domain name
+func (sv *Service) ONS_GetOffersByBidder(req client.ONSGetOffersRequest, reply *client.ONSGetOffersReply) error {
+	if err := req.Bidder.Err(); err != nil {
+		return codes.ErrBadAddress
+	}
 
-func (sv *Service) ONS_GetDomainOnSale(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
-	domains := sv.ons
-	if req.OnSale == false {
-		return codes.ErrFlagNotSet
+	pg, err := newPage(req.PageRequest)
+	if err != nil {
+		return err
 	}
 
-	ds := make([]ons.Domain, 0)
-	domains.Iterate(func(name ons.Name, domain *ons.Domain) bool {
-		if domain.OnSaleFlag {
-			ds = append(ds, *domain)
+	sv, err = sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	offers := make([]ons.Offer, 0)
+	sv.ons.IterateOffersByBidderFrom(req.Bidder, ons.Name(req.StartKey), !req.Reverse, func(offer *ons.Offer) bool {
+		if !pg.add(offer.Name.String()) {
+			return true
 		}
+		offers = append(offers, *offer)
 		return false
 	})
 
-	*reply = client.ONSGetDomainsReply{
-		Domains: ds,
-		Height:  sv.ons.State.Version(),
+	*reply = client.ONSGetOffersReply{
+		Offers:    offers,
+		PageReply: pg.reply(),
+		Height:    sv.ons.State.Version(),
 	}
 	return nil
 }
 
-func (sv *Service) ONS_GetDomainByBeneficiary(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+func (sv *Service) ONS_GetDomainByOwner(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	if req.Owner == nil {
+		return codes.ErrBadOwner
+	}
+
+	return sv.listDomains(req, func(domain *ons.Domain) bool {
+		if !domain.Owner.Equal(req.Owner) {
+			return false
+		}
+		return !req.OnSale || domain.OnSaleFlag
+	}, reply)
+}
+
+func (sv *Service) ONS_GetParentDomainByOwner(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	if req.Owner == nil {
+		return codes.ErrBadOwner
+	}
+
+	return sv.listDomains(req, func(domain *ons.Domain) bool {
+		if !domain.Owner.Equal(req.Owner) || domain.Name.IsSub() {
+			return false
+		}
+		return !req.OnSale || domain.OnSaleFlag
+	}, reply)
+}
+
+func (sv *Service) ONS_GetSubDomainByName(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
 	domains := sv.ons
+	if len(req.Name) <= 0 {
+		return codes.ErrBadName
+	}
+
+	_, err = domains.Get(ons.Name(req.Name))
+	if err != nil {
+		return codes.ErrDomainNotFound
+	}
+
+	reqName := ons.GetNameFromString(req.Name)
+	if len(req.StartKey) > 0 && !ons.Name(req.StartKey).IsSubTo("."+reqName) {
+		return codes.ErrBadPagination
+	}
+
+	return sv.pageDomains(req, func(fn func(name ons.Name, domain *ons.Domain) bool) {
+		domains.IterateSubDomainFrom(reqName, ons.Name(req.StartKey), !req.Reverse, fn)
+	}, func(domain *ons.Domain) bool {
+		return true
+	}, reply)
+}
+
+func (sv *Service) ONS_GetDomainOnSale(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	if req.OnSale == false {
+		return codes.ErrFlagNotSet
+	}
+
+	return sv.listDomains(req, func(domain *ons.Domain) bool {
+		return domain.OnSaleFlag
+	}, reply)
+}
+
+func (sv *Service) ONS_GetDomainByBeneficiary(req client.ONSGetDomainsRequest, reply *client.ONSGetDomainsReply) error {
+	sv, err := sv.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
 	if req.Beneficiary == nil {
 		return codes.ErrBadAddress
 	}
 
+	return sv.listDomains(req, func(domain *ons.Domain) bool {
+		return domain.Beneficiary.Equal(req.Beneficiary)
+	}, reply)
+}
+
+// listDomains replies with a page of the domains matching the request, from the domain named by the start key on.
+// Besides the match of each call the expiry and price filters of the request apply.
+func (sv *Service) listDomains(req client.ONSGetDomainsRequest, match func(domain *ons.Domain) bool,
+	reply *client.ONSGetDomainsReply) error {
+	return sv.pageDomains(req, func(fn func(name ons.Name, domain *ons.Domain) bool) {
+		sv.ons.IterateFrom(ons.Name(req.StartKey), !req.Reverse, fn)
+	}, match, reply)
+}
+
+// pageDomains replies with a page of the domains the iteration goes through which match the request, see listDomains
+func (sv *Service) pageDomains(req client.ONSGetDomainsRequest, iterate func(fn func(name ons.Name, domain *ons.Domain) bool),
+	match func(domain *ons.Domain) bool, reply *client.ONSGetDomainsReply) error {
+	pg, err := newPage(req.PageRequest)
+	if err != nil {
+		return err
+	}
+
 	ds := make([]ons.Domain, 0)
-	domains.Iterate(func(name ons.Name, domain *ons.Domain) bool {
-		if domain.Beneficiary.Equal(req.Beneficiary) {
-			ds = append(ds, *domain)
+	iterate(func(name ons.Name, domain *ons.Domain) bool {
+		if req.ExpireBefore > 0 && domain.ExpireHeight >= req.ExpireBefore {
+			return false
+		}
+		if req.MaxPrice != nil {
+			if !domain.OnSaleFlag || domain.SalePrice == nil ||
+				domain.SalePrice.BigInt().Cmp(req.MaxPrice.BigInt()) > 0 {
+				return false
+			}
 		}
+		if !match(domain) {
+			return false
+		}
+		if !pg.add(name.String()) {
+			return true
+		}
+		ds = append(ds, *domain)
 		return false
 	})
 
 	*reply = client.ONSGetDomainsReply{
-		Domains: ds,
-		Height:  sv.ons.State.Version(),
+		Domains:   ds,
+		PageReply: pg.reply(),
+		Height:    sv.ons.State.Version(),
 	}
 	return nil
 }
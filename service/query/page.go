@@ -0,0 +1,59 @@
+package query
+
+import (
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/keys"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// page collects the items of a list page. The items passing the filters of the list are added in order, once the
+// page is full the key of the next one is kept as the start key of the next page.
+type page struct {
+	limit int
+	count int
+	next  string
+}
+
+func newPage(req client.PageRequest) (*page, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	if limit < 0 || limit > maxPageLimit {
+		return nil, codes.ErrBadPagination
+	}
+	return &page{limit: limit}, nil
+}
+
+// add makes room for the item with the key in the page, it returns false when the page is full and the iteration
+// should stop
+func (p *page) add(key string) bool {
+	if p.count >= p.limit {
+		p.next = key
+		return false
+	}
+	p.count++
+	return true
+}
+
+func (p *page) reply() client.PageReply {
+	return client.PageReply{NextKey: p.next}
+}
+
+// startAddress reads the start key of a list keyed by address
+func startAddress(req client.PageRequest) (keys.Address, error) {
+	if len(req.StartKey) == 0 {
+		return nil, nil
+	}
+	addr := keys.Address{}
+	err := addr.UnmarshalText([]byte(req.StartKey))
+	if err != nil {
+		return nil, codes.ErrBadPagination
+	}
+	return addr, nil
+}
@@ -7,8 +7,14 @@ import (
 	"github.com/Oneledger/protocol/action"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/bitcoin"
+	"github.com/Oneledger/protocol/data/ethereum"
 	"github.com/Oneledger/protocol/data/fees"
+	"github.com/Oneledger/protocol/data/governance"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/data/txindex"
 	"github.com/Oneledger/protocol/identity"
 	"github.com/Oneledger/protocol/log"
 	codes "github.com/Oneledger/protocol/status_codes"
@@ -24,8 +30,18 @@ type Service struct {
 	witnesses  *identity.WitnessStore
 	ons        *ons.DomainStore
 	feePool    *fees.Store
-	logger     *log.Logger
-	txTypes    *[]action.TxTypeDescribe
+	nonces     *nonce.Store
+	unbondings *identity.UnbondingStore
+	// funds raised by governance proposals, held until the proposal ends
+	proposalFunds *governance.ProposalFundStore
+	// supply of the currencies, kept up to date as they are minted and burnt
+	supplies *balance.SupplyStore
+	// the bridges hold the amounts locked on the other chains at their total supply addresses
+	btcTrackers *bitcoin.TrackerStore
+	ethTrackers *ethereum.TrackerStore
+	txIndex     *txindex.Store
+	logger      *log.Logger
+	txTypes     *[]action.TxTypeDescribe
 }
 
 func Name() string {
@@ -33,27 +49,77 @@ func Name() string {
 }
 
 func NewService(ctx client.ExtServiceContext, balances *balance.Store, currencies *balance.CurrencySet, validators *identity.ValidatorStore, witnesses *identity.WitnessStore,
-	domains *ons.DomainStore, feePool *fees.Store, logger *log.Logger, txTypes *[]action.TxTypeDescribe) *Service {
+	domains *ons.DomainStore, feePool *fees.Store, nonces *nonce.Store, unbondings *identity.UnbondingStore,
+	proposalFunds *governance.ProposalFundStore, supplies *balance.SupplyStore, btcTrackers *bitcoin.TrackerStore, ethTrackers *ethereum.TrackerStore, txIndex *txindex.Store, logger *log.Logger,
+	txTypes *[]action.TxTypeDescribe) *Service {
 	return &Service{
-		name:       "query",
-		ext:        ctx,
-		currencies: currencies,
-		balances:   balances,
-		validators: validators,
-		witnesses:  witnesses,
-		ons:        domains,
-		feePool:    feePool,
-		logger:     logger,
-		txTypes:    txTypes,
+		name:          "query",
+		ext:           ctx,
+		currencies:    currencies,
+		balances:      balances,
+		validators:    validators,
+		witnesses:     witnesses,
+		ons:           domains,
+		feePool:       feePool,
+		nonces:        nonces,
+		unbondings:    unbondings,
+		proposalFunds: proposalFunds,
+		supplies:      supplies,
+		btcTrackers:   btcTrackers,
+		ethTrackers:   ethTrackers,
+		txIndex:       txIndex,
+		logger:        logger,
+		txTypes:       txTypes,
 	}
 }
 
+// atHeight returns the service reading the state committed at the given height, the service itself reads the
+// latest committed state and is returned when height is 0
+func (svc *Service) atHeight(height int64) (*Service, error) {
+	if height == 0 {
+		return svc, nil
+	}
+
+	state, err := svc.balances.State.AtVersion(height)
+	if err != nil {
+		return nil, codes.ErrStateNotFound.Wrap(err)
+	}
+
+	// copies of the stores, so concurrent queries at other heights are not affected
+	balances := *svc.balances
+	validators := *svc.validators
+	witnesses := *svc.witnesses
+	domains := *svc.ons
+	feePool := *svc.feePool
+	nonces := *svc.nonces
+	unbondings := *svc.unbondings
+	proposalFunds := *svc.proposalFunds
+	supplies := *svc.supplies
+
+	sv := *svc
+	sv.balances = balances.WithState(state)
+	sv.validators = validators.WithState(state)
+	sv.witnesses = witnesses.WithState(state)
+	sv.ons = domains.WithState(state)
+	sv.feePool = feePool.WithState(state)
+	sv.nonces = nonces.WithState(state)
+	sv.unbondings = unbondings.WithState(state)
+	sv.proposalFunds = proposalFunds.WithState(state)
+	sv.supplies = supplies.WithState(state)
+	return &sv, nil
+}
+
 func (svc *Service) Balance(req client.BalanceRequest, resp *client.BalanceReply) error {
 	err := req.Address.Err()
 	if err != nil {
 		return codes.ErrBadAddress
 	}
 
+	svc, err = svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
 	addr := req.Address
 	bal, err := svc.balances.GetBalance(addr, svc.currencies)
 
@@ -69,31 +135,95 @@ func (svc *Service) Balance(req client.BalanceRequest, resp *client.BalanceReply
 	return nil
 }
 
-// ListValidator returns a list of all validator
-func (svc *Service) ListValidators(_ client.ListValidatorsRequest, reply *client.ListValidatorsReply) error {
-	validators, err := svc.validators.GetValidatorSet()
+// Nonce returns the nonce the next tx signed first by the address must carry
+func (svc *Service) Nonce(req client.NonceRequest, resp *client.NonceReply) error {
+	err := req.Address.Err()
+	if err != nil {
+		return codes.ErrBadAddress
+	}
+
+	svc, err = svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := svc.nonces.Get(req.Address)
+	if err != nil {
+		svc.logger.Error("error getting nonce", err)
+		return codes.ErrGettingNonce
+	}
+
+	*resp = client.NonceReply{
+		Nonce:  nonce,
+		Height: svc.balances.State.Version(),
+	}
+	return nil
+}
+
+// ListValidator returns a page of the validators, ordered by address
+func (svc *Service) ListValidators(req client.ListValidatorsRequest, reply *client.ListValidatorsReply) error {
+	pg, err := newPage(req.PageRequest)
 	if err != nil {
-		svc.logger.Error("error listing validators")
-		return codes.ErrListValidators
+		return err
+	}
+	start, err := startAddress(req.PageRequest)
+	if err != nil {
+		return err
 	}
 
+	svc, err = svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	validators := make([]identity.Validator, 0)
+	svc.validators.IterateFrom(start, !req.Reverse, func(addr keys.Address, validator *identity.Validator) bool {
+		if req.ExcludeJailed && validator.Jailed {
+			return false
+		}
+		if !pg.add(addr.String()) {
+			return true
+		}
+		validators = append(validators, *validator)
+		return false
+	})
+
 	*reply = client.ListValidatorsReply{
 		Validators: validators,
+		PageReply:  pg.reply(),
 		Height:     svc.balances.State.Version(),
 	}
 	return nil
 }
 
-// ListWitnesses returns a list of all witness
+// ListWitnesses returns a page of the witnesses of a chain, ordered by address
 func (svc *Service) ListWitnesses(req client.ListWitnessesRequest, reply *client.ListWitnessesReply) error {
-	witnesses, err := svc.witnesses.GetWitnessAddresses(req.ChainType)
+	pg, err := newPage(req.PageRequest)
 	if err != nil {
-		svc.logger.Error("error listing witnesses")
-		return codes.ErrListWitnesses
+		return err
+	}
+	start, err := startAddress(req.PageRequest)
+	if err != nil {
+		return err
 	}
 
+	svc, err = svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	witnesses := make([]keys.Address, 0)
+	svc.witnesses.IterateFrom(req.ChainType, start, !req.Reverse, func(addr keys.Address, _ *identity.Witness) bool {
+		if !pg.add(addr.String()) {
+			return true
+		}
+		witnesses = append(witnesses, addr)
+		return false
+	})
+
 	*reply = client.ListWitnessesReply{
 		Witnesses: witnesses,
+		PageReply: pg.reply(),
 		Height:    svc.balances.State.Version(),
 	}
 	return nil
@@ -115,6 +245,11 @@ func (svc *Service) CurrencyBalance(req client.CurrencyBalanceRequest, resp *cli
 		return codes.ErrFindingCurrency
 	}
 
+	svc, err = svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
 	addr := req.Address
 	bal, err := svc.balances.GetBalance(addr, svc.currencies)
 
@@ -140,6 +275,40 @@ func (svc *Service) FeeOptions(_ struct{}, reply *client.FeeOptionsReply) error
 	return nil
 }
 
+// BaseFee returns the minimal fee price a tx needs to enter the mempool, it follows how full the recent blocks were
+func (svc *Service) BaseFee(req client.BaseFeeRequest, reply *client.BaseFeeReply) error {
+	svc, err := svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	baseFee, err := svc.feePool.GetBaseFee()
+	if err != nil {
+		svc.logger.Error("error getting base fee", err)
+		return codes.ErrGettingBaseFee
+	}
+
+	*reply = client.BaseFeeReply{
+		BaseFee: baseFee,
+		Height:  svc.balances.State.Version(),
+	}
+	return nil
+}
+
+// FeeSplit returns the fees burnt, paid to the treasury and shared out to validators over all the blocks
+func (svc *Service) FeeSplit(req client.FeeSplitRequest, reply *client.FeeSplitReply) error {
+	svc, err := svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	*reply = client.FeeSplitReply{
+		Totals: svc.feePool.GetSplitTotals(),
+		Height: svc.balances.State.Version(),
+	}
+	return nil
+}
+
 func (svc *Service) ListTxTypes(_ client.ListTxTypesRequest, reply *client.ListTxTypesReply) error {
 	var txTypes []action.TxTypeDescribe
 	//find all const types that less than EOF marker
@@ -174,3 +343,39 @@ func (svc *Service) Tx(req client.TxRequest, reply *client.TxResponse) error {
 
 	return nil
 }
+
+// TxSearch returns a page of the txs delivered by this node, by the address taking part in them, their type and
+// their height. Txs are listed oldest first unless the page is reversed.
+func (svc *Service) TxSearch(req client.TxSearchRequest, reply *client.TxSearchReply) error {
+	pg, err := newPage(req.PageRequest)
+	if err != nil {
+		return err
+	}
+	if req.MinHeight < 0 || req.MaxHeight < 0 || (req.MaxHeight > 0 && req.MaxHeight < req.MinHeight) {
+		return codes.ErrBadPagination
+	}
+
+	filter := txindex.Filter{
+		Address:   req.Address,
+		MinHeight: req.MinHeight,
+		MaxHeight: req.MaxHeight,
+	}
+	if req.Type != 0 {
+		filter.Type = req.Type.String()
+	}
+
+	txs := make([]txindex.Record, 0)
+	svc.txIndex.Iterate(filter, req.StartKey, !req.Reverse, func(pos string, record txindex.Record) bool {
+		if !pg.add(pos) {
+			return true
+		}
+		txs = append(txs, record)
+		return false
+	})
+
+	*reply = client.TxSearchReply{
+		Txs:       txs,
+		PageReply: pg.reply(),
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package query
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/data/balance"
+	"github.com/Oneledger/protocol/data/chain"
+	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/identity"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+// bridgeAddress returns the address holding the total locked on the bridge of the currency's chain, nil if the
+// currency isn't bridged
+func (svc *Service) bridgeAddress(currency balance.Currency) keys.Address {
+	switch currency.Chain {
+	case chain.BITCOIN:
+		if svc.btcTrackers != nil {
+			return keys.Address(svc.btcTrackers.GetOption().TotalSupplyAddr)
+		}
+	case chain.ETHEREUM:
+		if svc.ethTrackers != nil && svc.ethTrackers.GetOption() != nil {
+			return keys.Address(svc.ethTrackers.GetOption().TotalSupplyAddr)
+		}
+	}
+	return nil
+}
+
+// TotalSupply returns how much of the currency exists, split by where it is held. The total is the supply tracked as
+// the currency is minted and burnt, the split only adds up to it when nothing is missing from the holdings.
+func (svc *Service) TotalSupply(req client.TotalSupplyRequest, reply *client.TotalSupplyReply) error {
+	currency, ok := svc.currencies.GetCurrencyByName(req.Currency)
+	if !ok {
+		return codes.ErrFindingCurrency
+	}
+
+	svc, err := svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	held, err := svc.balances.GetHoldings(currency.Name)
+	if err != nil {
+		svc.logger.Error("error getting holdings", err)
+		return codes.ErrGettingSupply
+	}
+	balances := held.BigInt()
+
+	locked := big.NewInt(0)
+	if bridge := svc.bridgeAddress(currency); len(bridge) > 0 {
+		coin, err := svc.balances.GetBalanceForCurr(bridge, &currency)
+		if err != nil {
+			svc.logger.Error("error getting bridge balance", err)
+			return codes.ErrGettingSupply
+		}
+		locked = coin.Amount.BigInt()
+		balances.Sub(balances, locked)
+	}
+
+	feePool := big.NewInt(0)
+	proposalFunds := big.NewInt(0)
+	if opt := svc.feePool.GetOpt(); opt != nil && opt.FeeCurrency.Name == currency.Name {
+		svc.feePool.Iterate(func(_ keys.Address, coin balance.Coin) bool {
+			if coin.Amount != nil {
+				feePool.Add(feePool, coin.Amount.BigInt())
+			}
+			return false
+		})
+		// proposals are funded in the fee currency
+		proposalFunds = svc.proposalFunds.GetTotalFunds().BigInt()
+	}
+
+	staked := big.NewInt(0)
+	if currency.Name == "VT" {
+		svc.validators.Iterate(func(_ keys.Address, validator *identity.Validator) bool {
+			stake := validator.TotalStake()
+			staked.Add(staked, stake.BigInt())
+			return false
+		})
+		svc.unbondings.Iterate(func(unbonding *identity.Unbonding) bool {
+			staked.Add(staked, unbonding.Amount.BigInt())
+			return false
+		})
+	}
+
+	ts, err := svc.supplies.Get(currency.Name)
+	if err != nil {
+		svc.logger.Error("error getting tracked supply", err)
+		return codes.ErrGettingSupply
+	}
+	var total *big.Int
+	if ts != nil {
+		total = ts.Supply.BigInt()
+	} else {
+		// currencies registered before the supply was tracked
+		total = big.NewInt(0).Add(balances, feePool)
+		total.Add(total, staked)
+		total.Add(total, proposalFunds)
+	}
+
+	*reply = client.TotalSupplyReply{
+		Currency:      currency.Name,
+		Total:         *balance.NewAmountFromBigInt(total),
+		Balances:      *balance.NewAmountFromBigInt(balances),
+		FeePool:       *balance.NewAmountFromBigInt(feePool),
+		Staked:        *balance.NewAmountFromBigInt(staked),
+		ProposalFunds: *balance.NewAmountFromBigInt(proposalFunds),
+		BridgeLocked:  *balance.NewAmountFromBigInt(locked),
+		Height:        svc.balances.State.Version(),
+	}
+	return nil
+}
+
+// TopHolders returns a page of the accounts holding the currency, largest balances first
+func (svc *Service) TopHolders(req client.TopHoldersRequest, reply *client.TopHoldersReply) error {
+	currency, ok := svc.currencies.GetCurrencyByName(req.Currency)
+	if !ok {
+		return codes.ErrFindingCurrency
+	}
+
+	pg, err := newPage(req.PageRequest)
+	if err != nil {
+		return err
+	}
+	start, err := hex.DecodeString(req.StartKey)
+	if err != nil {
+		return codes.ErrBadPagination
+	}
+
+	svc, err = svc.atHeight(req.Height)
+	if err != nil {
+		return err
+	}
+
+	bridge := svc.bridgeAddress(currency)
+	holders := make([]client.Holder, 0)
+	svc.balances.IterateHolders(currency.Name, start, req.Reverse, func(addr keys.Address, amt balance.Amount) bool {
+		// the bridge only keeps count of what the holders got
+		if len(bridge) > 0 && addr.Equal(bridge) {
+			return false
+		}
+		if !pg.add(hex.EncodeToString(balance.HolderKey(amt, addr))) {
+			return true
+		}
+		holders = append(holders, client.Holder{Address: addr, Balance: amt})
+		return false
+	})
+
+	*reply = client.TopHoldersReply{
+		Currency:  currency.Name,
+		Holders:   holders,
+		PageReply: pg.reply(),
+		Height:    svc.balances.State.Version(),
+	}
+	return nil
+}
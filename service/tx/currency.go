@@ -0,0 +1,123 @@
+package tx
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/currency"
+	"github.com/Oneledger/protocol/client"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+func (svc *Service) CreateRawCurrencyRegister(args client.CurrencyRegisterRequest, reply *client.CreateTxReply) error {
+	register := currency.RegisterCurrency{
+		Issuer:    args.Issuer,
+		Name:      args.Name,
+		Decimal:   args.Decimal,
+		Unit:      args.Unit,
+		SupplyCap: args.SupplyCap,
+	}
+	data, err := register.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing register currency object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &register)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.CURRENCY_REGISTER,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing register currency transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (svc *Service) CreateRawMint(args client.MintRequest, reply *client.CreateTxReply) error {
+	mint := currency.Mint{
+		Issuer: args.Issuer,
+		To:     args.To,
+		Amount: args.Amount,
+	}
+	data, err := mint.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing mint object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &mint)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.MINT,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing mint transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (svc *Service) CreateRawBurn(args client.BurnRequest, reply *client.CreateTxReply) error {
+	burn := currency.Burn{
+		Issuer: args.Issuer,
+		Amount: args.Amount,
+	}
+	data, err := burn.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing burn object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &burn)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.BURN,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing burn transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
@@ -31,13 +31,20 @@ func (s *Service) ONS_CreateRawCreate(args client.ONSCreateRequest, reply *clien
 		return err
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &domainCreate)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_CREATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_CREATE,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -68,13 +75,20 @@ func (s *Service) ONS_CreateRawUpdate(args client.ONSUpdateRequest, reply *clien
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &domainUpdate)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_UPDATE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_UPDATE,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -90,6 +104,89 @@ func (s *Service) ONS_CreateRawUpdate(args client.ONSUpdateRequest, reply *clien
 	return nil
 }
 
+func (s *Service) ONS_CreateRawSetRecords(args client.ONSSetRecordsRequest, reply *client.CreateTxReply) error {
+
+	setRecords := ons.DomainSetRecords{
+		Owner:   args.Owner,
+		Name:    ons2.GetNameFromString(args.Name),
+		Records: args.Records,
+	}
+	data, err := setRecords.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing domain records object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &setRecords)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	fee := action.Fee{args.GasPrice, args.Gas}
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_SET_RECORDS,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing domain records transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{
+		RawTx: packet,
+	}
+
+	return nil
+}
+
+func (s *Service) ONS_CreateRawSetPrimary(args client.ONSSetPrimaryRequest, reply *client.CreateTxReply) error {
+
+	setPrimary := ons.DomainSetPrimary{
+		Address: args.Address,
+		Name:    ons2.GetNameFromString(args.Name),
+	}
+	data, err := setPrimary.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing domain primary object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &setPrimary)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	fee := action.Fee{args.GasPrice, args.Gas}
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_SET_PRIMARY,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing domain primary transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{
+		RawTx: packet,
+	}
+
+	return nil
+}
+
 func (s *Service) ONS_CreateRawRenew(args client.ONSRenewRequest, reply *client.CreateTxReply) error {
 
 	name := ons2.GetNameFromString(args.Name)
@@ -104,13 +201,20 @@ func (s *Service) ONS_CreateRawRenew(args client.ONSRenewRequest, reply *client.
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &renewDomain)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_RENEW,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_RENEW,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -141,13 +245,20 @@ func (s *Service) ONS_CreateRawSale(args client.ONSSaleRequest, reply *client.Cr
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &domainSale)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_SELL,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_SELL,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -178,13 +289,20 @@ func (s *Service) ONS_CreateRawBuy(args client.ONSPurchaseRequest, reply *client
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &domainPurchase)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_PURCHASE,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_PURCHASE,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -214,13 +332,20 @@ func (s *Service) ONS_CreateRawSend(args client.ONSSendRequest, reply *client.Cr
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &domainSend)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_SEND,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_SEND,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -249,13 +374,20 @@ func (s *Service) ONS_CreateRawDeleteSub(args client.ONSDeleteSubRequest, reply
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &del)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.DOMAIN_DELETE_SUB,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.DOMAIN_DELETE_SUB,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
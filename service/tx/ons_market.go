@@ -0,0 +1,238 @@
+package tx
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/ons"
+	"github.com/Oneledger/protocol/client"
+	ons2 "github.com/Oneledger/protocol/data/ons"
+	"github.com/Oneledger/protocol/serialize"
+	codes "github.com/Oneledger/protocol/status_codes"
+)
+
+func (s *Service) ONS_CreateRawBidCommit(args client.ONSBidCommitRequest, reply *client.CreateTxReply) error {
+	commit := ons.BidCommit{
+		Bidder:     args.Bidder,
+		Name:       ons2.GetNameFromString(args.Name),
+		Commitment: args.Commitment,
+		Deposit:    args.Deposit,
+	}
+	data, err := commit.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing bid commit object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &commit)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_BID_COMMIT,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing bid commit transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (s *Service) ONS_CreateRawBidReveal(args client.ONSBidRevealRequest, reply *client.CreateTxReply) error {
+	reveal := ons.BidReveal{
+		Bidder: args.Bidder,
+		Name:   ons2.GetNameFromString(args.Name),
+		Amount: args.Amount,
+		Salt:   args.Salt,
+	}
+	data, err := reveal.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing bid reveal object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &reveal)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_BID_REVEAL,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing bid reveal transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (s *Service) ONS_CreateRawAuctionFinalize(args client.ONSAuctionFinalizeRequest, reply *client.CreateTxReply) error {
+	finalize := ons.AuctionFinalize{
+		Winner:      args.Winner,
+		Beneficiary: args.Account,
+		Name:        ons2.GetNameFromString(args.Name),
+		Uri:         args.Uri,
+	}
+	data, err := finalize.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing auction finalize object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &finalize)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_AUCTION_FINALIZE,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing auction finalize transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (s *Service) ONS_CreateRawMakeOffer(args client.ONSMakeOfferRequest, reply *client.CreateTxReply) error {
+	offer := ons.MakeOffer{
+		Bidder:       args.Bidder,
+		Name:         ons2.GetNameFromString(args.Name),
+		Amount:       args.Amount,
+		ExpireHeight: args.ExpireHeight,
+	}
+	data, err := offer.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing make offer object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &offer)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_MAKE_OFFER,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing make offer transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (s *Service) ONS_CreateRawCancelOffer(args client.ONSCancelOfferRequest, reply *client.CreateTxReply) error {
+	cancel := ons.CancelOffer{
+		Bidder: args.Bidder,
+		Name:   ons2.GetNameFromString(args.Name),
+	}
+	data, err := cancel.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing cancel offer object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &cancel)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_CANCEL_OFFER,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing cancel offer transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (s *Service) ONS_CreateRawAcceptOffer(args client.ONSAcceptOfferRequest, reply *client.CreateTxReply) error {
+	accept := ons.AcceptOffer{
+		Owner:  args.Owner,
+		Name:   ons2.GetNameFromString(args.Name),
+		Bidder: args.Bidder,
+		Amount: args.Amount,
+	}
+	data, err := accept.Marshal()
+	if err != nil {
+		s.logger.Error("error in serializing accept offer object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(s.nonces, s.multiSig, &accept)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.DOMAIN_ACCEPT_OFFER,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		s.logger.Error("error in serializing accept offer transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
@@ -4,14 +4,18 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Oneledger/protocol/action"
+	"github.com/Oneledger/protocol/action/feegrant"
 	"github.com/Oneledger/protocol/action/staking"
 	"github.com/Oneledger/protocol/action/transfer"
+	"github.com/Oneledger/protocol/action/vesting"
 	"github.com/Oneledger/protocol/app/node"
 	"github.com/Oneledger/protocol/client"
 	"github.com/Oneledger/protocol/data/accounts"
 	"github.com/Oneledger/protocol/data/balance"
 	"github.com/Oneledger/protocol/data/fees"
 	"github.com/Oneledger/protocol/data/keys"
+	"github.com/Oneledger/protocol/data/multisig"
+	"github.com/Oneledger/protocol/data/nonce"
 	"github.com/Oneledger/protocol/log"
 	"github.com/Oneledger/protocol/serialize"
 	codes "github.com/Oneledger/protocol/status_codes"
@@ -26,6 +30,8 @@ type Service struct {
 	router      action.Router
 	accounts    accounts.Wallet
 	feeOpt      *fees.FeeOption
+	nonces      *nonce.Store
+	multiSig    *multisig.Store
 	logger      *log.Logger
 	nodeContext node.Context
 }
@@ -36,6 +42,8 @@ func NewService(
 	accounts accounts.Wallet,
 	feeOpt *fees.FeeOption,
 	nodeCtx node.Context,
+	nonces *nonce.Store,
+	multiSig *multisig.Store,
 	logger *log.Logger,
 ) *Service {
 	return &Service{
@@ -44,6 +52,8 @@ func NewService(
 		nodeContext: nodeCtx,
 		accounts:    accounts,
 		feeOpt:      feeOpt,
+		nonces:      nonces,
+		multiSig:    multiSig,
 		logger:      logger,
 	}
 }
@@ -63,13 +73,20 @@ func (svc *Service) SendTx(args client.SendTxRequest, reply *client.CreateTxRepl
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &send)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := action.RawTx{
-		Type: action.SEND,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.SEND,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	if _, err := svc.accounts.GetAccount(args.From); err != nil {
@@ -110,14 +127,22 @@ func (svc *Service) CreateRawSend(args client.SendTxRequest, reply *client.Creat
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &send)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, err := uuid.NewUUID()
 
 	fee := action.Fee{args.GasPrice, args.Gas}
 	tx := &action.RawTx{
-		Type: action.SEND,
-		Data: data,
-		Fee:  fee,
-		Memo: uuidNew.String(),
+		Type:         action.SEND,
+		Data:         data,
+		Fee:          fee,
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+		FeeGranter:   args.FeeGranter,
 	}
 
 	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
@@ -133,6 +158,90 @@ func (svc *Service) CreateRawSend(args client.SendTxRequest, reply *client.Creat
 	return nil
 }
 
+// CreateRawMultiSend returns an unsigned multi send tx, it must be signed by every input address in the order they
+// first appear in the inputs
+func (svc *Service) CreateRawMultiSend(args client.MultiSendRequest, reply *client.CreateTxReply) error {
+	send := transfer.MultiSend{
+		Inputs:  make([]transfer.Transfer, 0, len(args.Inputs)),
+		Outputs: make([]transfer.Transfer, 0, len(args.Outputs)),
+	}
+	for _, in := range args.Inputs {
+		send.Inputs = append(send.Inputs, transfer.Transfer{Address: in.Address, Amount: in.Amount})
+	}
+	for _, out := range args.Outputs {
+		send.Outputs = append(send.Outputs, transfer.Transfer{Address: out.Address, Amount: out.Amount})
+	}
+	data, err := send.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing multi send object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &send)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.MULTI_SEND,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing multi send transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (svc *Service) CreateRawVesting(args client.CreateVestingRequest, reply *client.CreateTxReply) error {
+	create := vesting.CreateVesting{
+		From:        args.From,
+		To:          args.To,
+		Amount:      args.Amount,
+		StartHeight: args.StartHeight,
+		CliffHeight: args.CliffHeight,
+		EndHeight:   args.EndHeight,
+	}
+	data, err := create.Marshal()
+	if err != nil {
+		svc.logger.Error("error in serializing create vesting object", err)
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &create)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.CREATE_VESTING,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		svc.logger.Error("error in serializing create vesting transaction", err)
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
 func (svc *Service) ApplyValidator(args client.ApplyValidatorRequest, reply *client.ApplyValidatorReply) error {
 	if len(args.Name) < 1 {
 		args.Name = svc.nodeContext.NodeName
@@ -176,21 +285,35 @@ func (svc *Service) ApplyValidator(args client.ApplyValidatorRequest, reply *cli
 		return codes.ErrSerialization
 	}
 
+	h, err := svc.nodeContext.PrivVal().GetHandler()
+	if err != nil {
+		svc.logger.Error("error get validator handler", err)
+		return codes.ErrLoadingNodeKey
+	}
+
+	// the tx is signed with the node key first, which carries the nonce
+	nodeKey, err := h.PubKey().GetHandler()
+	if err != nil {
+		svc.logger.Error("error get validator public key handler", err)
+		return codes.ErrLoadingNodeKey
+	}
+	nonce, err := svc.nonces.Next(nodeKey.Address())
+	if err != nil {
+		svc.logger.Error("error getting nonce", err)
+		return codes.ErrGettingNonce
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	feeAmount := svc.feeOpt.MinFee()
 
 	tx := action.RawTx{
-		Type: action.APPLYVALIDATOR,
-		Data: data,
-		Fee:  action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
-		Memo: uuidNew.String(),
+		Type:  action.APPLYVALIDATOR,
+		Data:  data,
+		Fee:   action.Fee{action.Amount{Currency: "OLT", Value: *feeAmount.Amount}, 100000},
+		Memo:  uuidNew.String(),
+		Nonce: nonce,
 	}
 	rawData := tx.RawBytes()
-	h, err := svc.nodeContext.PrivVal().GetHandler()
-	if err != nil {
-		svc.logger.Error("error get validator handler", err)
-		return codes.ErrLoadingNodeKey
-	}
 	vpubkey := h.PubKey()
 	vsinged, err := h.Sign(rawData)
 
@@ -227,6 +350,11 @@ func (svc *Service) WithdrawReward(args client.WithdrawRewardRequest, reply *cli
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &withdraw)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 
 	tx := action.RawTx{
@@ -236,7 +364,9 @@ func (svc *Service) WithdrawReward(args client.WithdrawRewardRequest, reply *cli
 			Price: args.GasPrice,
 			Gas:   args.Gas,
 		},
-		Memo: uuidNew.String(),
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet := tx.RawBytes()
@@ -260,6 +390,11 @@ func (svc Service) PurgeValidator(req client.PurgeValidatorRequest, reply *clien
 		return codes.ErrSerialization
 	}
 
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &purge)
+	if err != nil {
+		return err
+	}
+
 	uuidNew, _ := uuid.NewUUID()
 	feeAmount := svc.feeOpt.MinFee()
 	tx := action.RawTx{
@@ -269,7 +404,9 @@ func (svc Service) PurgeValidator(req client.PurgeValidatorRequest, reply *clien
 			Price: action.Amount{Currency: "OLT", Value: *feeAmount.Amount},
 			Gas:   80000,
 		},
-		Memo: uuidNew.String(),
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
 	}
 
 	packet := tx.RawBytes()
@@ -278,3 +415,73 @@ func (svc Service) PurgeValidator(req client.PurgeValidatorRequest, reply *clien
 	}
 	return nil
 }
+
+func (svc *Service) CreateRawFeeGrant(args client.FeeGrantRequest, reply *client.CreateTxReply) error {
+	grant := feegrant.FeeGrant{
+		Granter:    args.Granter,
+		Grantee:    args.Grantee,
+		SpendLimit: args.SpendLimit,
+		Expiry:     args.Expiry,
+	}
+	data, err := grant.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &grant)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.FEE_GRANT,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
+
+func (svc *Service) CreateRawFeeRevoke(args client.FeeRevokeRequest, reply *client.CreateTxReply) error {
+	revoke := feegrant.FeeRevoke{
+		Granter: args.Granter,
+		Grantee: args.Grantee,
+	}
+	data, err := revoke.Marshal()
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	nonce, nonceAccount, err := action.NextNonce(svc.nonces, svc.multiSig, &revoke)
+	if err != nil {
+		return err
+	}
+
+	uuidNew, _ := uuid.NewUUID()
+	tx := &action.RawTx{
+		Type:         action.FEE_REVOKE,
+		Data:         data,
+		Fee:          action.Fee{Price: args.GasPrice, Gas: args.Gas},
+		Memo:         uuidNew.String(),
+		Nonce:        nonce,
+		NonceAccount: nonceAccount,
+	}
+
+	packet, err := serialize.GetSerializer(serialize.NETWORK).Serialize(tx)
+	if err != nil {
+		return codes.ErrSerialization
+	}
+
+	*reply = client.CreateTxReply{RawTx: packet}
+	return nil
+}
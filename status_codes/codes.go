@@ -10,6 +10,7 @@ const (
 	DomainMissing       = 100102
 	OwnerAddressMissing = 100103
 	OnSaleFlagNotSet    = 100104
+	InvalidPagination   = 100105
 
 	IOError        = 1002
 	IOErrorNodeKey = 100201
@@ -27,6 +28,9 @@ const (
 	DomainNotFound        = 100502
 	CurrencyNotFound      = 100503
 	TxNotFound            = 100504
+	StateVersionNotFound  = 100505
+	RecordNotFound        = 100506
+	AuctionNotFound       = 100507
 
 	InternalError                           = 1006
 	InternalErrorSerialization              = 100601
@@ -39,6 +43,9 @@ const (
 	InternalErrorTrackerBusy                = 100608
 	InternalErrorTrackerInsufficientBalance = 100609
 	InternalErrorListWitnesses              = 100610
+	InternalErrorGettingNonce               = 100611
+	InternalErrorGettingSupply              = 100612
+	InternalErrorGettingBaseFee             = 100613
 
 	WalletError               = 2006
 	WalletErrorAddingAccount  = 200601
@@ -61,6 +68,10 @@ const (
 	TxErrInsufficientFunds  = 300110
 	TxErrGasOverflow        = 300111
 	TxErrInvalidExtTx       = 300112
+	TxErrMultiSigThreshold  = 300113
+	TxErrInvalidNonce       = 300114
+	TxErrFeePayerNotSigned  = 300115
+	TxErrBelowBaseFee       = 300116
 
 	ExternalErr                        = 400100
 	ExternalErrBitcoinTxNotFound       = 400101
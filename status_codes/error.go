@@ -62,14 +62,21 @@ var (
 	ErrGettingBalance  = ProtocolError{InternalErrorGettingBalance, "error  getting balance"}
 	ErrListValidators  = ProtocolError{InternalErrorListValidators, "error getting list of validators"}
 	ErrListWitnesses  = ProtocolError{InternalErrorListWitnesses, "error getting list of witnesses"}
+	ErrGettingNonce    = ProtocolError{InternalErrorGettingNonce, "error getting account nonce"}
 	ErrFindingCurrency = ProtocolError{CurrencyNotFound, "error finding currency"}
 	ErrGetTx           = ProtocolError{TxNotFound, "error get tx from tendermint"}
+	ErrStateNotFound   = ProtocolError{StateVersionNotFound, "state not available at the requested height"}
+	ErrGettingSupply   = ProtocolError{InternalErrorGettingSupply, "error getting currency supply"}
+	ErrGettingBaseFee  = ProtocolError{InternalErrorGettingBaseFee, "error getting base fee"}
+	ErrBadPagination   = ProtocolError{InvalidPagination, "invalid page or page size"}
 
 	// ONS errors
-	ErrBadName        = ProtocolError{DomainMissing, "domain name not provided"}
-	ErrBadOwner       = ProtocolError{OwnerAddressMissing, "owner address not provided"}
-	ErrDomainNotFound = ProtocolError{DomainNotFound, "domain not found"}
-	ErrFlagNotSet     = ProtocolError{OnSaleFlagNotSet, "onsale flag not set"}
+	ErrBadName         = ProtocolError{DomainMissing, "domain name not provided"}
+	ErrBadOwner        = ProtocolError{OwnerAddressMissing, "owner address not provided"}
+	ErrDomainNotFound  = ProtocolError{DomainNotFound, "domain not found"}
+	ErrFlagNotSet      = ProtocolError{OnSaleFlagNotSet, "onsale flag not set"}
+	ErrRecordNotFound  = ProtocolError{RecordNotFound, "domain record not found"}
+	ErrAuctionNotFound = ProtocolError{AuctionNotFound, "domain auction not found"}
 
 	// Tx errors
 
@@ -152,6 +152,19 @@ func (state *ChainState) GetVersioned(version int64, key StoreKey) (int64, []byt
 	return state.Delivered.GetVersioned(key, version)
 }
 
+// GetVersionedWithProof returns the value of a key at a committed version, together with the iavl proof of its
+// existence, or of its absence if the value is nil
+func (state *ChainState) GetVersionedWithProof(key StoreKey, version int64) ([]byte, *iavl.RangeProof, error) {
+	return state.Delivered.GetVersionedWithProof(key, version)
+}
+
+// GetVersionedRangeWithProof returns up to limit items in [start, end) at a committed version, together with the
+// iavl range proof of the items
+func (state *ChainState) GetVersionedRangeWithProof(start, end []byte, limit int, version int64) (
+	keys, values [][]byte, proof *iavl.RangeProof, err error) {
+	return state.Delivered.GetVersionedRangeWithProof(start, end, limit, version)
+}
+
 // TODO: Should be against the commit tree, not the delivered one!!!
 func (state *ChainState) Exists(key StoreKey) bool {
 	return state.Delivered.ImmutableTree.Has(key)
@@ -202,6 +215,24 @@ func (state *ChainState) Commit() ([]byte, int64) {
 	return hash, version
 }
 
+// AtVersion returns a read only view of the chain state as it was committed at the given version. Writes to the
+// view are not supported.
+func (state *ChainState) AtVersion(version int64) (*ChainState, error) {
+	tree, err := state.Delivered.GetImmutable(version)
+	if err != nil {
+		return nil, ErrVersionPruned
+	}
+
+	return &ChainState{
+		Name:        state.Name,
+		Delivered:   &iavl.MutableTree{ImmutableTree: tree},
+		LastVersion: version - 1,
+		Version:     version,
+		Hash:        tree.Hash(),
+		TreeHeight:  tree.Height(),
+	}, nil
+}
+
 func (state *ChainState) LoadVersion(version int64) (int64, error) {
 	return state.Delivered.LoadVersion(version)
 }
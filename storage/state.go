@@ -220,6 +220,16 @@ func (s *State) GetPrevious(num int64, key StoreKey) []byte {
 	return s.GetVersioned(ver-num, key)
 }
 
+// AtVersion returns a State reading the chain state as it was committed at the given version, it must only be used
+// for reads
+func (s *State) AtVersion(version int64) (*State, error) {
+	cs, err := s.cs.AtVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return NewState(cs), nil
+}
+
 func (s *State) LoadVersion(version int64) (int64, error) {
 	return s.cs.LoadVersion(version)
 }
@@ -7,6 +7,8 @@ import (
 
 	"github.com/magiconair/properties/assert"
 	"github.com/tendermint/tm-db"
+
+	"github.com/Oneledger/protocol/config"
 )
 
 var testcase = make(map[string][]byte)
@@ -115,6 +117,39 @@ func TestState_CommitTxSession(t *testing.T) {
 	assert.Equal(t, hash2, hash, "hash should match")
 	assert.Equal(t, version2, version, "version should match")
 }
+
+func TestState_AtVersion(t *testing.T) {
+	cs := NewChainState("test", getCacheDB())
+	_ = cs.SetupRotation(config.ChainStateRotationCfg{Recent: 1})
+	state := NewState(cs)
+
+	state.Set([]byte("balance"), []byte("100"))
+	_, version := state.Commit()
+	state.Set([]byte("balance"), []byte("50"))
+	state.Set([]byte("other"), []byte("1"))
+	state.Commit()
+
+	old, err := state.AtVersion(version)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, old.Version(), version)
+
+	value, _ := old.Get([]byte("balance"))
+	assert.Equal(t, value, []byte("100"))
+	assert.Equal(t, old.Exists([]byte("other")), false)
+
+	value, _ = state.Get([]byte("balance"))
+	assert.Equal(t, value, []byte("50"))
+
+	_, err = state.AtVersion(version + 5)
+	assert.Equal(t, err, ErrVersionPruned)
+
+	// older versions are pruned by the rotation
+	state.Commit()
+	state.Commit()
+	_, err = state.AtVersion(version)
+	assert.Equal(t, err, ErrVersionPruned)
+}
+
 func getCacheDB() db.DB {
 	return db.NewDB("test", db.MemDBBackend, "")
 }
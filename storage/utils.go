@@ -43,3 +43,20 @@ func Rangefix(prefix string) []byte {
 	a := []byte(prefix + DB_RANGEFIX)
 	return a
 }
+
+// PageRange returns the range to iterate the keys under the prefix from the start key on, in either order. The start
+// key is part of the range, an empty start key begins at the first key under the prefix, or at the last one when
+// iterating backwards.
+func PageRange(prefix []byte, start []byte, ascending bool) (from, to []byte) {
+	from = append([]byte{}, prefix...)
+	to = Rangefix(string(prefix))
+	if len(start) == 0 {
+		return
+	}
+	key := append(append([]byte{}, prefix...), start...)
+	if ascending {
+		return key, to
+	}
+	// the end of a range is left out of it
+	return from, append(key, 0)
+}